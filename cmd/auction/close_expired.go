@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"io"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// closeExpiredCommand é o subcomando que executa uma única varredura de fechamento e
+// encerra, para manutenção manual ou um CronJob do Kubernetes:
+//
+//	go run cmd/auction/main.go close-expired
+const closeExpiredCommand = "close-expired"
+
+// runCloseExpired fecha os leilões expirados uma vez e retorna o código de saída do processo
+func runCloseExpired(
+	ctx context.Context, database *mongo.Database, stdout, stderr io.Writer) int {
+	auctionRepository := auction.NewAuctionRepository(database, auction.WithMonitorDisabled(true))
+
+	closed, err := auctionRepository.ProcessExpiredAuctionsOnce(ctx)
+	if err != nil {
+		fmt.Fprintf(stderr, "error closing expired auctions: %s\n", err.Error())
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "closed %d expired auctions\n", closed)
+	return 0
+}
@@ -2,20 +2,34 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fullcycle-auction_go/configuration/database/mongodb"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/event"
 	"fullcycle-auction_go/internal/infra/api/web/controller/auction_controller"
 	"fullcycle-auction_go/internal/infra/api/web/controller/bid_controller"
+	"fullcycle-auction_go/internal/infra/api/web/controller/health_controller"
 	"fullcycle-auction_go/internal/infra/api/web/controller/user_controller"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
 	"fullcycle-auction_go/internal/infra/database/auction"
 	"fullcycle-auction_go/internal/infra/database/bid"
 	"fullcycle-auction_go/internal/infra/database/user"
+	"fullcycle-auction_go/internal/infra/ratelimit"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
 	"fullcycle-auction_go/internal/usecase/user_usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 func main() {
@@ -26,41 +40,114 @@ func main() {
 		return
 	}
 
+	features, featuresErr := auction.LoadFeatures()
+	if featuresErr != nil {
+		log.Fatal(featuresErr.Error())
+		return
+	}
+	logger.Info("Repository features loaded", features.Fields()...)
+
 	databaseConnection, err := mongodb.NewMongoDBConnection(ctx)
 	if err != nil {
 		log.Fatal(err.Error())
 		return
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == closeExpiredCommand {
+		os.Exit(runCloseExpired(ctx, databaseConnection, os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == repairHighBidsCommand {
+		os.Exit(runRepairHighBids(ctx, databaseConnection, os.Stdout, os.Stderr))
+	}
+
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
 	router := gin.Default()
+	router.Use(middleware.CorrelationId())
+	router.Use(middleware.TraceContext())
+	router.Use(middleware.ActingUser())
 
-	userController, bidController, auctionsController := initDependencies(databaseConnection)
+	userController, bidController, auctionsController, auctionRepository, bidRepository :=
+		initDependencies(databaseConnection, features)
 
 	router.GET("/auction", auctionsController.FindAuctions)
+	router.GET("/auctions", auctionsController.ListAuctions)
+	router.GET("/auctions/active/count", auctionsController.CountActiveAuctions)
 	router.GET("/auction/:auctionId", auctionsController.FindAuctionById)
+	router.GET("/auction/:auctionId/status", auctionsController.FindAuctionStatus)
+	router.GET("/auction/:auctionId/remaining", auctionsController.FindRemainingTime)
 	router.POST("/auction", auctionsController.CreateAuction)
 	router.GET("/auction/winner/:auctionId", auctionsController.FindWinningBidByAuctionId)
+	router.GET("/categories", auctionsController.ListCategories)
 	router.POST("/bid", bidController.CreateBid)
 	router.GET("/bid/:auctionId", bidController.FindBidByAuctionId)
+	router.GET("/auction/:auctionId/bids", bidController.FindBidsPageByAuctionId)
+	router.GET("/auction/:auctionId/proxy", bidController.FindProxyBid)
+	router.DELETE("/bid/:bidId", bidController.WithdrawBid)
 	router.GET("/user/:userId", userController.FindUserById)
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
-	router.Run(":8080")
+	healthController := health_controller.NewHealthController(map[string]health_controller.HealthSource{
+		"auctions": auctionRepository,
+	})
+	router.GET("/healthz", healthController.Liveness)
+	router.GET("/readyz", healthController.Readiness)
+
+	server := &http.Server{Addr: ":8080", Handler: router}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err.Error())
+		}
+	}()
+
+	// Ao receber SIGINT/SIGTERM, para de aceitar requisições e encerra o monitor e o
+	// arquivamento de lances, registrando o resumo do ciclo de vida da instância
+	signalCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-signalCtx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Println("Error trying to shut down http server:", err)
+	}
+	auctionRepository.Shutdown(shutdownCtx)
+	bidRepository.Shutdown(shutdownCtx)
 }
 
-func initDependencies(database *mongo.Database) (
+const shutdownTimeout = 30 * time.Second
+
+func initDependencies(database *mongo.Database, features auction.Features) (
 	userController *user_controller.UserController,
 	bidController *bid_controller.BidController,
-	auctionController *auction_controller.AuctionController) {
+	auctionController *auction_controller.AuctionController,
+	auctionRepository *auction.AuctionRepository,
+	bidRepository *bid.BidRepository) {
 
-	auctionRepository := auction.NewAuctionRepository(database)
-	bidRepository := bid.NewBidRepository(database, auctionRepository)
+	auctionRepository = auction.NewAuctionRepository(database, auction.WithFeatures(features))
+	auctionRepository.MigrateEnumEncoding(context.Background())
+	auctionRepository.MigrateMoneyEncoding(context.Background())
+	auctionRepository.EnsureIndexes(context.Background())
+	bidRepository = bid.NewBidRepository(database, auctionRepository,
+		bid.WithPublisher(event.NewTracingPublisher(
+			auctionRepository.RecordingPublisher(
+				event.NewRetryingPublisher(event.NewLogPublisher())))))
+	bidRepository.MigrateMoneyEncoding(context.Background())
+	bidRepository.EnsureIndexes(context.Background())
 	userRepository := user.NewUserRepository(database)
 
 	userController = user_controller.NewUserController(
 		user_usecase.NewUserUseCase(userRepository))
 	auctionController = auction_controller.NewAuctionController(
-		auction_usecase.NewAuctionUseCase(auctionRepository, bidRepository))
-	bidController = bid_controller.NewBidController(bid_usecase.NewBidUseCase(bidRepository))
+		auction_usecase.NewAuctionUseCase(auctionRepository, bidRepository,
+			auction_usecase.WithPublisher(event.NewTracingPublisher(
+				auctionRepository.RecordingPublisher(
+					event.NewRetryingPublisher(event.NewLogPublisher()))))))
+	bidController = bid_controller.NewBidController(bid_usecase.NewBidUseCase(bidRepository,
+		bid_usecase.WithRateLimiter(ratelimit.NewMemoryRateLimiter()),
+		bid_usecase.WithAuctionRepository(auctionRepository),
+		bid_usecase.WithProxyBookRepository(bid.NewProxyBookRepository(database))))
 
 	return
 }
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/infra/database/bid"
+	"io"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// repairHighBidsCommand é o subcomando que recalcula o current_high_bid de todos os
+// leilões a partir dos lances e encerra, para corrigir divergências após incidentes:
+//
+//	go run cmd/auction/main.go repair-high-bids
+const repairHighBidsCommand = "repair-high-bids"
+
+// runRepairHighBids corrige os leilões divergentes e retorna o código de saída do processo
+func runRepairHighBids(
+	ctx context.Context, database *mongo.Database, stdout, stderr io.Writer) int {
+	auctionRepository := auction.NewAuctionRepository(database, auction.WithMonitorDisabled(true))
+	bidRepository := bid.NewBidRepository(database, auctionRepository, bid.WithBidRetention(0, 0))
+
+	repaired, err := bidRepository.RecomputeAllHighBids(ctx)
+	if err != nil {
+		fmt.Fprintf(stderr, "error repairing auction high bids: %s\n", err.Error())
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "repaired %d auction high bids\n", repaired)
+	return 0
+}
@@ -2,32 +2,112 @@ package mongodb
 
 import (
 	"context"
+	"fmt"
 	"fullcycle-auction_go/configuration/logger"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"os"
+	"strconv"
+	"time"
 )
 
 const (
 	MONGODB_URL = "MONGODB_URL"
 	MONGODB_DB  = "MONGODB_DB"
+
+	MONGO_WRITE_CONCERN = "MONGO_WRITE_CONCERN"
+	MONGO_READ_PREF     = "MONGO_READ_PREF"
 )
 
 func NewMongoDBConnection(ctx context.Context) (*mongo.Database, error) {
 	mongoURL := os.Getenv(MONGODB_URL)
 	mongoDatabase := os.Getenv(MONGODB_DB)
 
-	client, err := mongo.Connect(
-		ctx, options.Client().ApplyURI(mongoURL))
+	// Configuração inválida impede a inicialização, em vez de cair no padrão do driver
+	databaseOptions, err := DatabaseOptions()
+	if err != nil {
+		logger.Error("Error trying to read mongodb database options", err)
+		return nil, err
+	}
+
+	clientOptions := options.Client().ApplyURI(mongoURL)
+	if threshold := getSlowQueryThreshold(); threshold > 0 {
+		clientOptions.SetMonitor(NewSlowQueryMonitor(threshold, logger.Default()))
+	}
+
+	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		logger.Error("Error trying to connect to mongodb database", err)
 		return nil, err
 	}
 
-	if err := client.Ping(ctx, nil); err != nil {
+	// Falha rápido na inicialização quando o MongoDB está inacessível,
+	// em vez de deixar os repositórios insistindo em segundo plano
+	pingCtx, cancel := context.WithTimeout(ctx, getConnectTimeout())
+	defer cancel()
+
+	if err := client.Ping(pingCtx, nil); err != nil {
 		logger.Error("Error trying to ping mongodb database", err)
+		client.Disconnect(ctx)
 		return nil, err
 	}
 
-	return client.Database(mongoDatabase), nil
+	return client.Database(mongoDatabase, databaseOptions), nil
+}
+
+// DatabaseOptions monta o write concern e a read preference do banco a partir de
+// MONGO_WRITE_CONCERN ("majority" ou o número de nós) e MONGO_READ_PREF (primary,
+// primaryPreferred, secondary, secondaryPreferred ou nearest). Todas as coleções
+// obtidas do banco herdam essas opções. Sem as variáveis, vale o padrão do driver
+func DatabaseOptions() (*options.DatabaseOptions, error) {
+	databaseOptions := options.Database()
+
+	if value := os.Getenv(MONGO_WRITE_CONCERN); value != "" {
+		writeConcern, err := parseWriteConcern(value)
+		if err != nil {
+			return nil, err
+		}
+		databaseOptions.SetWriteConcern(writeConcern)
+	}
+
+	if value := os.Getenv(MONGO_READ_PREF); value != "" {
+		mode, err := readpref.ModeFromString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", MONGO_READ_PREF, value, err)
+		}
+
+		readPreference, err := readpref.New(mode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", MONGO_READ_PREF, value, err)
+		}
+		databaseOptions.SetReadPreference(readPreference)
+	}
+
+	return databaseOptions, nil
+}
+
+func parseWriteConcern(value string) (*writeconcern.WriteConcern, error) {
+	if value == "majority" {
+		return writeconcern.Majority(), nil
+	}
+
+	nodes, err := strconv.Atoi(value)
+	if err != nil || nodes < 0 {
+		return nil, fmt.Errorf("invalid %s %q: expected \"majority\" or a number of nodes", MONGO_WRITE_CONCERN, value)
+	}
+
+	return &writeconcern.WriteConcern{W: nodes}, nil
+}
+
+// getConnectTimeout retorna o tempo máximo do ping inicial baseado em
+// MONGODB_CONNECT_TIMEOUT. Se não estiver definido, retorna 10s como padrão
+func getConnectTimeout() time.Duration {
+	timeout, err := time.ParseDuration(os.Getenv("MONGODB_CONNECT_TIMEOUT"))
+	if err != nil || timeout <= 0 {
+		return 10 * time.Second
+	}
+
+	return timeout
 }
@@ -0,0 +1,67 @@
+package mongodb
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func TestDatabaseOptionsConfigureCollections(t *testing.T) {
+	os.Setenv(MONGO_WRITE_CONCERN, "majority")
+	os.Setenv(MONGO_READ_PREF, "secondaryPreferred")
+	defer os.Unsetenv(MONGO_WRITE_CONCERN)
+	defer os.Unsetenv(MONGO_READ_PREF)
+
+	databaseOptions, err := DatabaseOptions()
+	if err != nil {
+		t.Fatalf("Failed to build database options: %v", err)
+	}
+
+	// Connect não abre conexões até a primeira operação
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:1"))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	database := client.Database("auctions", databaseOptions)
+	if database.WriteConcern() == nil || database.WriteConcern().W != "majority" {
+		t.Errorf("Expected majority write concern, got %+v", database.WriteConcern())
+	}
+	if database.ReadPreference() == nil ||
+		database.ReadPreference().Mode() != readpref.SecondaryPreferredMode {
+		t.Errorf("Expected secondaryPreferred read preference, got %v", database.ReadPreference())
+	}
+
+	os.Setenv(MONGO_WRITE_CONCERN, "2")
+	databaseOptions, _ = DatabaseOptions()
+	if w := client.Database("auctions", databaseOptions).WriteConcern().W; w != 2 {
+		t.Errorf("Expected write concern of 2 nodes, got %v", w)
+	}
+}
+
+func TestDatabaseOptionsRejectInvalidValues(t *testing.T) {
+	tests := []struct {
+		env, value string
+	}{
+		{MONGO_WRITE_CONCERN, "all"},
+		{MONGO_WRITE_CONCERN, "-1"},
+		{MONGO_READ_PREF, "closest"},
+	}
+
+	for _, tt := range tests {
+		os.Setenv(tt.env, tt.value)
+		if _, err := DatabaseOptions(); err == nil {
+			t.Errorf("Expected %s=%s to be rejected", tt.env, tt.value)
+		}
+		os.Unsetenv(tt.env)
+	}
+
+	if _, err := DatabaseOptions(); err != nil {
+		t.Errorf("Expected the driver defaults without configuration, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package mongodb
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.uber.org/zap"
+)
+
+const MONGO_SLOW_MS = "MONGO_SLOW_MS"
+
+// getSlowQueryThreshold lê MONGO_SLOW_MS, em milissegundos. Sem a variável, ou com
+// zero, o log de operações lentas fica desligado
+func getSlowQueryThreshold() time.Duration {
+	milliseconds, err := strconv.ParseInt(os.Getenv(MONGO_SLOW_MS), 10, 64)
+	if err != nil || milliseconds <= 0 {
+		return 0
+	}
+
+	return time.Duration(milliseconds) * time.Millisecond
+}
+
+// slowQueryCommand é o resumo de um comando em andamento, guardado até ele terminar
+type slowQueryCommand struct {
+	collection string
+	filter     []string
+}
+
+// NewSlowQueryMonitor cria um monitor de comandos que registra um aviso para cada
+// operação que levar mais que threshold. Instalado no cliente, cobre todas as coleções
+// dos repositórios sem envolver cada chamada. O filtro é resumido aos nomes dos campos,
+// para que valores consultados não cheguem ao log
+func NewSlowQueryMonitor(threshold time.Duration, log logger.Logger) *event.CommandMonitor {
+	var running sync.Map
+
+	finished := func(finishedEvent event.CommandFinishedEvent, failed bool) {
+		started, ok := running.LoadAndDelete(finishedEvent.RequestID)
+		if !ok || finishedEvent.Duration < threshold {
+			return
+		}
+
+		command := started.(slowQueryCommand)
+		log.Warn("Slow MongoDB operation",
+			zap.String("operation", finishedEvent.CommandName),
+			zap.String("database", finishedEvent.DatabaseName),
+			zap.String("collection", command.collection),
+			zap.Strings("filter_fields", command.filter),
+			zap.Duration("duration", finishedEvent.Duration),
+			zap.Duration("threshold", threshold),
+			zap.Bool("failed", failed))
+	}
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, startedEvent *event.CommandStartedEvent) {
+			running.Store(startedEvent.RequestID, summarizeCommand(startedEvent))
+		},
+		Succeeded: func(_ context.Context, succeededEvent *event.CommandSucceededEvent) {
+			finished(succeededEvent.CommandFinishedEvent, false)
+		},
+		Failed: func(_ context.Context, failedEvent *event.CommandFailedEvent) {
+			finished(failedEvent.CommandFinishedEvent, true)
+		},
+	}
+}
+
+// summarizeCommand extrai a coleção e os campos do filtro do comando. O filtro fica em
+// "filter" (find, count, findAndModify usa "query"), no "q" de cada update/delete ou no
+// primeiro $match de um aggregate
+func summarizeCommand(startedEvent *event.CommandStartedEvent) slowQueryCommand {
+	summary := slowQueryCommand{}
+
+	if collection, ok := startedEvent.Command.Lookup(startedEvent.CommandName).StringValueOK(); ok {
+		summary.collection = collection
+	}
+
+	for _, key := range []string{"filter", "query"} {
+		if filter, ok := startedEvent.Command.Lookup(key).DocumentOK(); ok {
+			summary.filter = documentKeys(filter)
+			return summary
+		}
+	}
+
+	for _, key := range []string{"updates", "deletes"} {
+		if statements, ok := startedEvent.Command.Lookup(key).ArrayOK(); ok {
+			if statement, err := statements.IndexErr(0); err == nil {
+				if document, ok := statement.Value().DocumentOK(); ok {
+					if filter, ok := document.Lookup("q").DocumentOK(); ok {
+						summary.filter = documentKeys(filter)
+					}
+				}
+			}
+			return summary
+		}
+	}
+
+	if pipeline, ok := startedEvent.Command.Lookup("pipeline").ArrayOK(); ok {
+		if stages, err := pipeline.Values(); err == nil && len(stages) > 0 {
+			if stage, ok := stages[0].DocumentOK(); ok {
+				if match, ok := stage.Lookup("$match").DocumentOK(); ok {
+					summary.filter = documentKeys(match)
+				}
+			}
+		}
+	}
+
+	return summary
+}
+
+func documentKeys(document bson.Raw) []string {
+	elements, err := document.Elements()
+	if err != nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(elements))
+	for _, element := range elements {
+		keys = append(keys, element.Key())
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,91 @@
+package mongodb
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// warningLogger guarda os avisos recebidos
+type warningLogger struct {
+	mutex    sync.Mutex
+	warnings map[string][]zap.Field
+}
+
+func (l *warningLogger) Info(string, ...zap.Field)         {}
+func (l *warningLogger) Error(string, error, ...zap.Field) {}
+func (l *warningLogger) Warn(message string, tags ...zap.Field) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.warnings[message] = tags
+}
+
+func fieldsOf(tags []zap.Field) map[string]interface{} {
+	encoder := zapcore.NewMapObjectEncoder()
+	for _, tag := range tags {
+		tag.AddTo(encoder)
+	}
+	return encoder.Fields
+}
+
+// runCommand simula um find que leva duration para terminar
+func runCommand(monitor *event.CommandMonitor, requestId int64, duration time.Duration) {
+	command, _ := bson.Marshal(bson.D{
+		{Key: "find", Value: "auctions"},
+		{Key: "filter", Value: bson.D{{Key: "status", Value: "Active"}, {Key: "end_time", Value: bson.M{"$lte": 10}}}},
+	})
+
+	monitor.Started(context.Background(), &event.CommandStartedEvent{
+		Command: command, CommandName: "find", DatabaseName: "auctions", RequestID: requestId,
+	})
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{
+			CommandName: "find", DatabaseName: "auctions", RequestID: requestId, Duration: duration,
+		},
+	})
+}
+
+func TestSlowQueryMonitorWarnsAboveThreshold(t *testing.T) {
+	log := &warningLogger{warnings: map[string][]zap.Field{}}
+	monitor := NewSlowQueryMonitor(100*time.Millisecond, log)
+
+	runCommand(monitor, 1, 10*time.Millisecond)
+	if len(log.warnings) != 0 {
+		t.Fatalf("Expected no warning for a fast operation, got %v", log.warnings)
+	}
+
+	runCommand(monitor, 2, 250*time.Millisecond)
+	tags, ok := log.warnings["Slow MongoDB operation"]
+	if !ok {
+		t.Fatal("Expected a warning for a slow operation")
+	}
+
+	fields := fieldsOf(tags)
+	if fields["operation"] != "find" || fields["collection"] != "auctions" {
+		t.Errorf("Expected the find on auctions to be reported, got %v", fields)
+	}
+	if filter, _ := fields["filter_fields"].([]interface{}); len(filter) != 2 ||
+		filter[0] != "end_time" || filter[1] != "status" {
+		t.Errorf("Expected only the filter field names, got %v", fields["filter_fields"])
+	}
+}
+
+func TestSlowQueryThresholdDisabledByDefault(t *testing.T) {
+	os.Unsetenv(MONGO_SLOW_MS)
+	if threshold := getSlowQueryThreshold(); threshold != 0 {
+		t.Errorf("Expected the slow query log to be disabled, got %s", threshold)
+	}
+
+	os.Setenv(MONGO_SLOW_MS, "200")
+	defer os.Unsetenv(MONGO_SLOW_MS)
+	if threshold := getSlowQueryThreshold(); threshold != 200*time.Millisecond {
+		t.Errorf("Expected a 200ms threshold, got %s", threshold)
+	}
+}
@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type correlationIdKey struct{}
+
+type actingUserKey struct{}
+
+// SystemActor identifica as operações feitas pela própria aplicação, como o
+// fechamento automático pelo monitor, quando não há usuário no contexto
+const SystemActor = "system"
+
+func WithCorrelationId(ctx context.Context, correlationId string) context.Context {
+	return context.WithValue(ctx, correlationIdKey{}, correlationId)
+}
+
+func CorrelationId(ctx context.Context) string {
+	correlationId, _ := ctx.Value(correlationIdKey{}).(string)
+	return correlationId
+}
+
+// WithActingUser guarda no contexto o usuário que está executando a operação,
+// para que a auditoria o registre sem que ele seja repassado como parâmetro
+func WithActingUser(ctx context.Context, userId string) context.Context {
+	return context.WithValue(ctx, actingUserKey{}, userId)
+}
+
+// ActingUser retorna o usuário que está executando a operação, ou SystemActor
+// quando o contexto não tem um
+func ActingUser(ctx context.Context) string {
+	if userId, _ := ctx.Value(actingUserKey{}).(string); userId != "" {
+		return userId
+	}
+
+	return SystemActor
+}
+
+func InfoContext(ctx context.Context, message string, tags ...zap.Field) {
+	Info(message, ContextFields(ctx, tags...)...)
+}
+
+func ErrorContext(ctx context.Context, message string, err error, tags ...zap.Field) {
+	Error(message, err, ContextFields(ctx, tags...)...)
+}
+
+// ContextFields acrescenta aos campos informados os dados de log carregados pelo
+// contexto, para uso com um Logger injetado
+func ContextFields(ctx context.Context, tags ...zap.Field) []zap.Field {
+	if correlationId := CorrelationId(ctx); correlationId != "" {
+		tags = append(tags, zap.String("correlation_id", correlationId))
+	}
+
+	if userId, _ := ctx.Value(actingUserKey{}).(string); userId != "" {
+		tags = append(tags, zap.String("acting_user", userId))
+	}
+
+	return tags
+}
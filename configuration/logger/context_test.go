@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestContextLogsIncludeCorrelationId(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	previous := log
+	log = zap.New(core)
+	defer func() { log = previous }()
+
+	ctx := WithCorrelationId(context.Background(), "req-123")
+
+	InfoContext(ctx, "Auction created", zap.String("auction_id", "auction-1"))
+	ErrorContext(ctx, "Error trying to insert auction", errors.New("boom"))
+	InfoContext(context.Background(), "No correlation")
+
+	entries := logs.All()
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 log entries, got %d", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	if fields["correlation_id"] != "req-123" || fields["auction_id"] != "auction-1" {
+		t.Errorf("Expected correlation and auction ids in fields, got %v", fields)
+	}
+
+	fields = entries[1].ContextMap()
+	if fields["correlation_id"] != "req-123" || fields["error"] != "boom" {
+		t.Errorf("Expected correlation id and error in fields, got %v", fields)
+	}
+
+	if _, ok := entries[2].ContextMap()["correlation_id"]; ok {
+		t.Error("Expected no correlation id without one in the context")
+	}
+}
+
+func TestActingUser(t *testing.T) {
+	if actor := ActingUser(context.Background()); actor != SystemActor {
+		t.Errorf("Expected %q without a user in the context, got %q", SystemActor, actor)
+	}
+
+	ctx := WithActingUser(context.Background(), "admin-1")
+	if actor := ActingUser(ctx); actor != "admin-1" {
+		t.Errorf("Expected the acting user from the context, got %q", actor)
+	}
+
+	fields := map[string]string{}
+	for _, field := range ContextFields(ctx) {
+		fields[field.Key] = field.String
+	}
+	if fields["acting_user"] != "admin-1" {
+		t.Errorf("Expected acting_user in the log fields, got %v", fields)
+	}
+	if len(ContextFields(context.Background())) != 0 {
+		t.Error("Expected no acting_user field without a user in the context")
+	}
+}
@@ -26,6 +26,33 @@ func init() {
 	log, _ = logConfiguration.Build()
 }
 
+// Logger permite que componentes recebam um logger por instância em vez de
+// usar o logger global do pacote
+type Logger interface {
+	Info(message string, tags ...zap.Field)
+	Error(message string, err error, tags ...zap.Field)
+	Warn(message string, tags ...zap.Field)
+}
+
+type globalLogger struct{}
+
+// Default retorna um Logger que encaminha para o logger global do pacote
+func Default() Logger {
+	return globalLogger{}
+}
+
+func (globalLogger) Info(message string, tags ...zap.Field) {
+	Info(message, tags...)
+}
+
+func (globalLogger) Error(message string, err error, tags ...zap.Field) {
+	Error(message, err, tags...)
+}
+
+func (globalLogger) Warn(message string, tags ...zap.Field) {
+	Warn(message, tags...)
+}
+
 func Info(message string, tags ...zap.Field) {
 	log.Info(message, tags...)
 	log.Sync()
@@ -36,3 +63,8 @@ func Error(message string, err error, tags ...zap.Field) {
 	log.Error(message, tags...)
 	log.Sync()
 }
+
+func Warn(message string, tags ...zap.Field) {
+	log.Warn(message, tags...)
+	log.Sync()
+}
@@ -22,11 +22,26 @@ func (r *RestErr) Error() string {
 }
 
 func ConvertError(internalError *internal_error.InternalError) *RestErr {
-	switch internalError.Err {
-	case "bad_request":
-		return NewBadRequestError(internalError.Error())
-	case "not_found":
+	switch internalError.Code() {
+	case internal_error.BadRequest:
+		var causes []Causes
+		for _, field := range internalError.Fields {
+			causes = append(causes, Causes{Field: field.Field, Message: field.Message})
+		}
+
+		return NewBadRequestError(internalError.Message, causes...)
+	case internal_error.NotFound:
 		return NewNotFoundError(internalError.Error())
+	case internal_error.Conflict:
+		return NewConflictError(internalError.Error())
+	case internal_error.TooManyRequests:
+		return NewTooManyRequestsError(internalError.Error())
+	case internal_error.Timeout:
+		return NewGatewayTimeoutError(internalError.Error())
+	case internal_error.LimitExceeded:
+		return NewLimitExceededError(internalError.Error())
+	case internal_error.Forbidden:
+		return NewForbiddenError(internalError.Error())
 	default:
 		return NewInternalServerError(internalError.Error())
 	}
@@ -58,3 +73,48 @@ func NewNotFoundError(message string) *RestErr {
 		Causes:  nil,
 	}
 }
+
+func NewTooManyRequestsError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "too_many_requests",
+		Code:    http.StatusTooManyRequests,
+		Causes:  nil,
+	}
+}
+
+func NewGatewayTimeoutError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "timeout",
+		Code:    http.StatusGatewayTimeout,
+		Causes:  nil,
+	}
+}
+
+func NewConflictError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "conflict",
+		Code:    http.StatusConflict,
+		Causes:  nil,
+	}
+}
+
+func NewLimitExceededError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "limit_exceeded",
+		Code:    http.StatusUnprocessableEntity,
+		Causes:  nil,
+	}
+}
+
+func NewForbiddenError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "forbidden",
+		Code:    http.StatusForbidden,
+		Causes:  nil,
+	}
+}
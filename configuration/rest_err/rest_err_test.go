@@ -0,0 +1,50 @@
+package rest_err
+
+import (
+	"encoding/json"
+	"fullcycle-auction_go/internal/internal_error"
+	"net/http"
+	"testing"
+)
+
+func TestConvertValidationError(t *testing.T) {
+	restErr := ConvertError(internal_error.NewValidationError("invalid auction object",
+		[]internal_error.FieldError{
+			{Field: "product_name", Message: "product name must have more than 1 character"},
+			{Field: "category", Message: "category must have more than 2 characters"},
+		}))
+
+	if restErr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", restErr.Code)
+	}
+
+	body, _ := json.Marshal(restErr)
+	var decoded struct {
+		Message string   `json:"message"`
+		Causes  []Causes `json:"causes"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("Failed to decode error: %v", err)
+	}
+
+	if decoded.Message != "invalid auction object" || len(decoded.Causes) != 2 ||
+		decoded.Causes[0].Field != "product_name" || decoded.Causes[1].Field != "category" {
+		t.Errorf("Expected both invalid fields in the response, got %s", body)
+	}
+}
+
+func TestConvertLimitExceededError(t *testing.T) {
+	restErr := ConvertError(internal_error.NewLimitExceededError("too many active auctions"))
+
+	if restErr.Code != http.StatusUnprocessableEntity || restErr.Err != "limit_exceeded" {
+		t.Errorf("Expected 422 limit_exceeded, got %d %s", restErr.Code, restErr.Err)
+	}
+}
+
+func TestConvertForbiddenError(t *testing.T) {
+	restErr := ConvertError(internal_error.NewForbiddenError("user is not invited"))
+
+	if restErr.Code != http.StatusForbidden || restErr.Err != "forbidden" {
+		t.Errorf("Expected 403 forbidden, got %d %s", restErr.Code, restErr.Err)
+	}
+}
@@ -0,0 +1,107 @@
+package auction_entity
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+func (s AuctionStatus) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if _, ok := auctionStatusNames[s]; !ok {
+		return bson.MarshalValue(int64(s))
+	}
+
+	return bson.MarshalValue(strings.ToLower(s.String()))
+}
+
+func (s *AuctionStatus) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	value, err := unmarshalEnumValue(t, data)
+	if err != nil {
+		return err
+	}
+
+	if number, ok := value.(int64); ok {
+		*s = AuctionStatus(number)
+		return nil
+	}
+
+	status, parseErr := ParseAuctionStatus(value.(string))
+	if parseErr != nil {
+		return parseErr
+	}
+
+	*s = status
+	return nil
+}
+
+func (c ProductCondition) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if _, ok := productConditionNames[c]; !ok {
+		return bson.MarshalValue(int64(c))
+	}
+
+	return bson.MarshalValue(strings.ToLower(c.String()))
+}
+
+func (c *ProductCondition) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	value, err := unmarshalEnumValue(t, data)
+	if err != nil {
+		return err
+	}
+
+	if number, ok := value.(int64); ok {
+		*c = ProductCondition(number)
+		return nil
+	}
+
+	condition, parseErr := ParseProductCondition(value.(string))
+	if parseErr != nil {
+		return parseErr
+	}
+
+	*c = condition
+	return nil
+}
+
+func (m Money) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(int64(m))
+}
+
+// UnmarshalBSONValue lê os centavos gravados como inteiro e também os valores antigos,
+// gravados em float64 na unidade da moeda
+func (m *Money) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+
+	if value, ok := raw.DoubleOK(); ok {
+		*m = moneyFromFloat(value)
+		return nil
+	}
+
+	if value, ok := raw.AsInt64OK(); ok {
+		*m = Money(value)
+		return nil
+	}
+
+	if t == bsontype.Null {
+		*m = 0
+		return nil
+	}
+
+	return fmt.Errorf("cannot decode money from bson type %s", t)
+}
+
+// unmarshalEnumValue aceita tanto a representação em string quanto a numérica antiga
+func unmarshalEnumValue(t bsontype.Type, data []byte) (interface{}, error) {
+	raw := bson.RawValue{Type: t, Value: data}
+
+	if value, ok := raw.StringValueOK(); ok {
+		return value, nil
+	}
+
+	if value, ok := raw.AsInt64OK(); ok {
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("cannot decode enum from bson type %s", t)
+}
@@ -2,14 +2,56 @@ package auction_entity
 
 import (
 	"context"
+	"fmt"
 	"fullcycle-auction_go/internal/internal_error"
 	"github.com/google/uuid"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
+type AuctionOption func(*Auction)
+
+func WithDuration(duration time.Duration) AuctionOption {
+	return func(au *Auction) {
+		au.Duration = duration
+	}
+}
+
+// WithStartsAt agenda o início do leilão. Um início no futuro cria o leilão como
+// Scheduled, e a sua duração passa a contar a partir da ativação
+func WithStartsAt(startsAt time.Time) AuctionOption {
+	return func(au *Auction) {
+		au.StartsAt = startsAt
+	}
+}
+
+// WithCurrency define a moeda (ISO 4217) do leilão; sem ela, usa DefaultCurrency
+func WithCurrency(currency string) AuctionOption {
+	return func(au *Auction) {
+		au.Currency = NormalizeCurrency(currency)
+	}
+}
+
+// WithStartingBid define o lance mínimo do leilão; lances abaixo dele são recusados
+func WithStartingBid(startingBid Money) AuctionOption {
+	return func(au *Auction) {
+		au.StartingBid = startingBid
+	}
+}
+
+// WithOwnerId define o usuário dono (vendedor) do leilão
+func WithOwnerId(ownerId string) AuctionOption {
+	return func(au *Auction) {
+		au.OwnerId = strings.TrimSpace(ownerId)
+	}
+}
+
 func CreateAuction(
 	productName, category, description string,
-	condition ProductCondition) (*Auction, *internal_error.InternalError) {
+	condition ProductCondition,
+	opts ...AuctionOption) (*Auction, *internal_error.InternalError) {
 	auction := &Auction{
 		Id:          uuid.New().String(),
 		ProductName: productName,
@@ -17,7 +59,24 @@ func CreateAuction(
 		Description: description,
 		Condition:   condition,
 		Status:      Active,
-		Timestamp:   time.Now(),
+		Timestamp:   time.Now().UTC(),
+	}
+
+	for _, opt := range opts {
+		opt(auction)
+	}
+
+	auction.StartsAt = auction.StartsAt.UTC()
+	if auction.StartsAt.After(auction.Timestamp) {
+		auction.Status = Scheduled
+	}
+
+	auction.TrimTextFields()
+	if auction.Currency == "" {
+		auction.Currency = DefaultCurrency()
+	}
+	if auction.Visibility == "" {
+		auction.Visibility = Public
 	}
 
 	if err := auction.Validate(); err != nil {
@@ -27,26 +86,107 @@ func CreateAuction(
 	return auction, nil
 }
 
+// Validate verifica todos os campos do leilão e retorna os inválidos em um único erro
 func (au *Auction) Validate() *internal_error.InternalError {
-	if len(au.ProductName) <= 1 ||
-		len(au.Category) <= 2 ||
-		len(au.Description) <= 10 && (au.Condition != New &&
-			au.Condition != Refurbished &&
-			au.Condition != Used) {
-		return internal_error.NewBadRequestError("invalid auction object")
+	var fields []internal_error.FieldError
+	invalid := func(field, message string) {
+		fields = append(fields, internal_error.FieldError{Field: field, Message: message})
+	}
+
+	if len(au.ProductName) <= 1 {
+		invalid("product_name", "product name must have more than 1 character")
+	}
+
+	if len(au.Category) <= 2 {
+		invalid("category", "category must have more than 2 characters")
+	}
+
+	if len(au.Description) <= 10 {
+		invalid("description", "description must have more than 10 characters")
+	} else if maxLength := MaxDescriptionLength(); utf8.RuneCountInString(au.Description) > maxLength {
+		invalid("description", fmt.Sprintf("description must have at most %d characters", maxLength))
+	}
+
+	if au.Condition != Unspecified && !au.Condition.IsValid() {
+		invalid("condition", fmt.Sprintf("invalid product condition: %d", au.Condition))
+	}
+
+	if au.Duration < 0 {
+		invalid("duration_seconds", "auction duration must not be negative")
+	} else if maxDuration := MaxDuration(); au.Duration > maxDuration {
+		invalid("duration_seconds", fmt.Sprintf("auction duration must be at most %s", maxDuration))
+	}
+
+	if au.OwnerId != "" && uuid.Validate(au.OwnerId) != nil {
+		invalid("owner_id", "owner id must be a valid UUID")
+	}
+
+	if au.Visibility != "" && !au.Visibility.IsValid() {
+		invalid("visibility", fmt.Sprintf("invalid auction visibility: %s", au.Visibility))
+	}
+
+	if invalidAllowedUserIds(au.AllowedUserIds) {
+		invalid("allowed_user_ids", "allowed user ids must be valid UUIDs")
+	}
+
+	if maxImages := MaxImages(); len(au.ImageURLs) > maxImages {
+		invalid("image_urls", fmt.Sprintf("an auction must have at most %d images", maxImages))
+	} else if invalidImageURLs(au.ImageURLs) {
+		invalid("image_urls", "image urls must be absolute http or https URLs")
+	}
+
+	if au.StartingBid < 0 {
+		invalid("starting_bid", "starting bid must not be negative")
+	}
+
+	if !IsValidCurrency(au.Currency) {
+		invalid("currency", fmt.Sprintf("invalid auction currency: %s", au.Currency))
+	} else if !IsSupportedCurrency(au.Currency) {
+		invalid("currency", fmt.Sprintf(
+			"unsupported auction currency: %s, only currencies with two decimal places are accepted",
+			au.Currency))
+	}
+
+	if len(fields) > 0 {
+		return internal_error.NewValidationError("invalid auction object", fields)
 	}
 
 	return nil
 }
 
+// Auction mantém todos os instantes em UTC. No MongoDB eles são gravados como
+// segundos Unix, então a expiração não depende do fuso horário do servidor
 type Auction struct {
-	Id          string
-	ProductName string
-	Category    string
-	Description string
-	Condition   ProductCondition
-	Status      AuctionStatus
-	Timestamp   time.Time
+	Id             string
+	OwnerId        string
+	ProductName    string
+	Category       string
+	Description    string
+	Condition      ProductCondition
+	Status         AuctionStatus
+	Timestamp      time.Time
+	StartsAt       time.Time
+	Duration       time.Duration
+	EndsAt         time.Time
+	BidCount       int64
+	CurrentHighBid Money
+	StartingBid    Money
+	Currency       string
+	Visibility     Visibility
+	AllowedUserIds []string
+	ImageURLs      []string
+	ActivatedAt    time.Time
+	CompletedAt    time.Time
+	Version        int64
+}
+
+// AuctionStatusView é um recorte leve do leilão, com apenas o necessário para
+// clientes que consultam o andamento do leilão com frequência
+type AuctionStatusView struct {
+	Status         AuctionStatus
+	CurrentHighBid Money
+	EndsAt         time.Time
+	BidCount       int64
 }
 
 type ProductCondition int
@@ -55,24 +195,158 @@ type AuctionStatus int
 const (
 	Active AuctionStatus = iota
 	Completed
+	// Scheduled é o status de um leilão criado com início no futuro; ele passa a
+	// Active quando o início chega
+	Scheduled
 )
 
+// AnyStatus corresponde a qualquer status nas consultas do repositório
+const AnyStatus AuctionStatus = -1
+
+// Unspecified indica que a condição do produto não foi informada. O repositório a
+// substitui pela condição padrão configurada, quando houver uma
+const Unspecified ProductCondition = 0
+
 const (
 	New ProductCondition = iota + 1
 	Used
 	Refurbished
 )
 
+var auctionStatusNames = map[AuctionStatus]string{
+	Active:    "Active",
+	Completed: "Completed",
+	Scheduled: "Scheduled",
+}
+
+var productConditionNames = map[ProductCondition]string{
+	New:         "New",
+	Used:        "Used",
+	Refurbished: "Refurbished",
+}
+
+func (s AuctionStatus) String() string {
+	if name, ok := auctionStatusNames[s]; ok {
+		return name
+	}
+
+	return "Unknown"
+}
+
+func ParseAuctionStatus(value string) (AuctionStatus, *internal_error.InternalError) {
+	for status, name := range auctionStatusNames {
+		if strings.EqualFold(name, strings.TrimSpace(value)) {
+			return status, nil
+		}
+	}
+
+	return 0, internal_error.NewBadRequestError(
+		fmt.Sprintf("invalid auction status: %s", value))
+}
+
+func (c ProductCondition) String() string {
+	if name, ok := productConditionNames[c]; ok {
+		return name
+	}
+
+	return "Unknown"
+}
+
+func (c ProductCondition) IsValid() bool {
+	_, ok := productConditionNames[c]
+	return ok
+}
+
+// ParseProductCondition aceita tanto o nome da condição ("New") quanto o seu número ("1")
+func ParseProductCondition(value string) (ProductCondition, *internal_error.InternalError) {
+	value = strings.TrimSpace(value)
+
+	if number, err := strconv.Atoi(value); err == nil {
+		if condition := ProductCondition(number); condition.IsValid() {
+			return condition, nil
+		}
+	}
+
+	for condition, name := range productConditionNames {
+		if strings.EqualFold(name, value) {
+			return condition, nil
+		}
+	}
+
+	return 0, internal_error.NewBadRequestError(
+		fmt.Sprintf("invalid product condition: %s", value))
+}
+
+// AuctionSortOrder define a ordenação da listagem de leilões
+type AuctionSortOrder int
+
+const (
+	DefaultSortOrder AuctionSortOrder = iota
+	HighBidAscending
+	HighBidDescending
+	NewestFirst
+)
+
+var auctionSortOrders = map[string]AuctionSortOrder{
+	"":                  DefaultSortOrder,
+	"current_high_bid":  HighBidAscending,
+	"-current_high_bid": HighBidDescending,
+	"-timestamp":        NewestFirst,
+}
+
+// ParseAuctionSortOrder converte o parâmetro de ordenação da API; o prefixo "-"
+// indica ordem decrescente
+func ParseAuctionSortOrder(value string) (AuctionSortOrder, *internal_error.InternalError) {
+	if order, ok := auctionSortOrders[strings.TrimSpace(value)]; ok {
+		return order, nil
+	}
+
+	return 0, internal_error.NewBadRequestError(
+		fmt.Sprintf("invalid auction sort order: %s", value))
+}
+
 type AuctionRepositoryInterface interface {
 	CreateAuction(
 		ctx context.Context,
 		auctionEntity *Auction) *internal_error.InternalError
 
+	// FindAuctions e FindAuctionsPage omitem os leilões privados que viewerId não
+	// pode ver; com viewerId vazio, retornam apenas os públicos
 	FindAuctions(
 		ctx context.Context,
 		status AuctionStatus,
-		category, productName string) ([]Auction, *internal_error.InternalError)
+		category, productName string,
+		sortOrder AuctionSortOrder,
+		viewerId string) ([]Auction, *internal_error.InternalError)
+
+	FindAuctionsPage(
+		ctx context.Context,
+		status AuctionStatus,
+		category, productName string,
+		offset, limit int,
+		viewerId string) ([]Auction, int64, *internal_error.InternalError)
 
 	FindAuctionById(
 		ctx context.Context, id string) (*Auction, *internal_error.InternalError)
+
+	// FindAuctionByIdUncached ignora caches de leitura, para decisões sobre lances
+	FindAuctionByIdUncached(
+		ctx context.Context, id string) (*Auction, *internal_error.InternalError)
+
+	CloseAuction(
+		ctx context.Context, id string) *internal_error.InternalError
+
+	FindAuctionStatus(
+		ctx context.Context, id string) (*AuctionStatusView, *internal_error.InternalError)
+
+	// RemainingTime retorna quanto falta para o fim do leilão, zero se já terminou
+	RemainingTime(
+		ctx context.Context, id string) (time.Duration, *internal_error.InternalError)
+
+	ListCategories(ctx context.Context) ([]string, *internal_error.InternalError)
+
+	CountAuctions(ctx context.Context, status AuctionStatus) (int64, *internal_error.InternalError)
+
+	CountAuctionsByOwner(
+		ctx context.Context, ownerId string, status AuctionStatus) (int64, *internal_error.InternalError)
 }
@@ -0,0 +1,364 @@
+package auction_entity
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestAuctionStatusStringRoundTrip(t *testing.T) {
+	for _, status := range []AuctionStatus{Active, Completed} {
+		parsed, err := ParseAuctionStatus(status.String())
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", status.String(), err)
+		}
+		if parsed != status {
+			t.Errorf("Expected %d, got %d", status, parsed)
+		}
+	}
+
+	if Active.String() != "Active" || Completed.String() != "Completed" {
+		t.Errorf("Unexpected status names: %s, %s", Active, Completed)
+	}
+
+	if status, err := ParseAuctionStatus("completed"); err != nil || status != Completed {
+		t.Errorf("Expected case-insensitive parse to return Completed, got %d (%v)", status, err)
+	}
+
+	if _, err := ParseAuctionStatus("closed"); err == nil {
+		t.Error("Expected an error parsing an unknown status")
+	}
+}
+
+func TestProductConditionStringRoundTrip(t *testing.T) {
+	for _, condition := range []ProductCondition{New, Used, Refurbished} {
+		parsed, err := ParseProductCondition(condition.String())
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", condition.String(), err)
+		}
+		if parsed != condition {
+			t.Errorf("Expected %d, got %d", condition, parsed)
+		}
+	}
+
+	if ProductCondition(42).String() != "Unknown" {
+		t.Errorf("Expected unknown condition name, got %s", ProductCondition(42))
+	}
+
+	if _, err := ParseProductCondition("broken"); err == nil {
+		t.Error("Expected an error parsing an unknown condition")
+	}
+}
+
+func TestEnumsBSONRoundTrip(t *testing.T) {
+	type document struct {
+		Status    AuctionStatus    `bson:"status"`
+		Condition ProductCondition `bson:"condition"`
+	}
+
+	data, err := bson.Marshal(document{Status: Completed, Condition: Refurbished})
+	if err != nil {
+		t.Fatalf("Failed to marshal document: %v", err)
+	}
+
+	var raw bson.M
+	bson.Unmarshal(data, &raw)
+	if raw["status"] != "completed" || raw["condition"] != "refurbished" {
+		t.Errorf("Expected enums stored as strings, got %v", raw)
+	}
+
+	var decoded document
+	if err := bson.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal document: %v", err)
+	}
+	if decoded.Status != Completed || decoded.Condition != Refurbished {
+		t.Errorf("Unexpected decoded enums: %+v", decoded)
+	}
+}
+
+func TestEnumsBSONAcceptLegacyIntegers(t *testing.T) {
+	data, _ := bson.Marshal(bson.M{"status": int32(1), "condition": int64(2)})
+
+	var decoded struct {
+		Status    AuctionStatus    `bson:"status"`
+		Condition ProductCondition `bson:"condition"`
+	}
+	if err := bson.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal legacy document: %v", err)
+	}
+	if decoded.Status != Completed || decoded.Condition != Used {
+		t.Errorf("Unexpected decoded enums: %+v", decoded)
+	}
+}
+
+func TestProductConditionIsValid(t *testing.T) {
+	for _, condition := range []ProductCondition{New, Used, Refurbished} {
+		if !condition.IsValid() {
+			t.Errorf("Expected %s to be valid", condition)
+		}
+	}
+
+	for _, condition := range []ProductCondition{0, 4, -1} {
+		if condition.IsValid() {
+			t.Errorf("Expected %d to be invalid", condition)
+		}
+	}
+}
+
+func TestCreateAuctionValidatesCondition(t *testing.T) {
+	auction, err := CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", Used)
+	if err != nil {
+		t.Fatalf("Expected valid auction, got %v", err)
+	}
+	if auction.Condition != Used {
+		t.Errorf("Expected condition Used, got %s", auction.Condition)
+	}
+
+	_, err = CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", ProductCondition(9))
+	if err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for an out-of-range condition, got %v", err)
+	}
+}
+
+func TestParseProductCondition(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected ProductCondition
+		valid    bool
+	}{
+		{value: "New", expected: New, valid: true},
+		{value: " used ", expected: Used, valid: true},
+		{value: "3", expected: Refurbished, valid: true},
+		{value: "0", valid: false},
+		{value: "7", valid: false},
+		{value: "mint", valid: false},
+		{value: "", valid: false},
+	}
+
+	for _, tt := range tests {
+		condition, err := ParseProductCondition(tt.value)
+		if tt.valid && (err != nil || condition != tt.expected) {
+			t.Errorf("Expected %q to parse as %s, got %s (%v)", tt.value, tt.expected, condition, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("Expected %q to be rejected, got %s", tt.value, condition)
+		}
+	}
+}
+
+func TestParseAuctionSortOrder(t *testing.T) {
+	expected := map[string]AuctionSortOrder{
+		"":                  DefaultSortOrder,
+		"current_high_bid":  HighBidAscending,
+		"-current_high_bid": HighBidDescending,
+		"-timestamp":        NewestFirst,
+	}
+	for value, want := range expected {
+		order, err := ParseAuctionSortOrder(value)
+		if err != nil || order != want {
+			t.Errorf("Expected %q to parse as %d, got %d (%v)", value, want, order, err)
+		}
+	}
+
+	if _, err := ParseAuctionSortOrder("price"); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for an unknown sort order, got %v", err)
+	}
+}
+
+func TestCreateAuctionCurrency(t *testing.T) {
+	os.Setenv("AUCTION_DEFAULT_CURRENCY", "usd")
+	defer os.Unsetenv("AUCTION_DEFAULT_CURRENCY")
+
+	auction, err := CreateAuction("Notebook", "Electronics", "A brand new notebook", New)
+	if err != nil || auction.Currency != "USD" {
+		t.Errorf("Expected the configured default currency USD, got %+v (%v)", auction, err)
+	}
+
+	auction, err = CreateAuction("Notebook", "Electronics", "A brand new notebook", New,
+		WithCurrency(" eur "))
+	if err != nil || auction.Currency != "EUR" {
+		t.Errorf("Expected currency EUR, got %+v (%v)", auction, err)
+	}
+
+	if _, err := CreateAuction("Notebook", "Electronics", "A brand new notebook", New,
+		WithCurrency("EURO")); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for an invalid currency, got %v", err)
+	}
+}
+
+func TestCreateAuctionStartingBid(t *testing.T) {
+	auction, err := CreateAuction("Notebook", "Electronics", "A brand new notebook", New,
+		WithStartingBid(MoneyFromCents(5000)))
+	if err != nil || auction.StartingBid != MoneyFromCents(5000) {
+		t.Errorf("Expected starting bid 50.00, got %+v (%v)", auction, err)
+	}
+
+	_, err = CreateAuction("Notebook", "Electronics", "A brand new notebook", New,
+		WithStartingBid(MoneyFromCents(-1)))
+	if err == nil || len(err.Fields) != 1 || err.Fields[0].Field != "starting_bid" {
+		t.Errorf("Expected starting_bid to be invalid, got %+v", err)
+	}
+}
+
+func TestCreateAuctionOwnerId(t *testing.T) {
+	ownerId := uuid.New().String()
+	auction, err := CreateAuction("Notebook", "Electronics", "A brand new notebook", New,
+		WithOwnerId(" "+ownerId+" "))
+	if err != nil || auction.OwnerId != ownerId {
+		t.Errorf("Expected owner %s, got %+v (%v)", ownerId, auction, err)
+	}
+
+	_, err = CreateAuction("Notebook", "Electronics", "A brand new notebook", New,
+		WithOwnerId("seller-1"))
+	if err == nil || len(err.Fields) != 1 || err.Fields[0].Field != "owner_id" {
+		t.Errorf("Expected owner_id to be invalid, got %+v", err)
+	}
+}
+
+func TestCreateAuctionRejectsCurrenciesWithoutTwoDecimals(t *testing.T) {
+	for _, currency := range []string{"JPY", "KWD", "BHD"} {
+		_, err := CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", New,
+			WithCurrency(currency))
+		if err == nil || err.Err != "bad_request" {
+			t.Errorf("Expected %s to be rejected, got %v", currency, err)
+		}
+	}
+
+	t.Setenv("AUCTION_DEFAULT_CURRENCY", "JPY")
+	if currency := DefaultCurrency(); currency != "BRL" {
+		t.Errorf("Expected an unsupported default currency to fall back to BRL, got %s", currency)
+	}
+}
+
+func TestDefaultCurrencyFallsBackToBRL(t *testing.T) {
+	os.Setenv("AUCTION_DEFAULT_CURRENCY", "reais")
+	defer os.Unsetenv("AUCTION_DEFAULT_CURRENCY")
+
+	if currency := DefaultCurrency(); currency != "BRL" {
+		t.Errorf("Expected BRL for an invalid configured currency, got %s", currency)
+	}
+}
+
+func TestValidateReportsAllInvalidFields(t *testing.T) {
+	_, err := CreateAuction("N", "Electronics", "short", New)
+	if err == nil || err.Err != "bad_request" {
+		t.Fatalf("Expected bad_request, got %v", err)
+	}
+
+	fields := map[string]bool{}
+	for _, field := range err.Fields {
+		fields[field.Field] = true
+	}
+	if len(err.Fields) != 2 || !fields["product_name"] || !fields["description"] {
+		t.Errorf("Expected product_name and description to be invalid, got %+v", err.Fields)
+	}
+}
+
+func TestCreateAuctionRejectsTooLongDescription(t *testing.T) {
+	os.Setenv("AUCTION_DESCRIPTION_MAX_LENGTH", "20")
+	defer os.Unsetenv("AUCTION_DESCRIPTION_MAX_LENGTH")
+
+	_, err := CreateAuction("Notebook", "Electronics", strings.Repeat("a", 21), New)
+	if err == nil {
+		t.Fatal("Expected an error for a description over the limit")
+	}
+	if len(err.Fields) != 1 || err.Fields[0].Field != "description" {
+		t.Errorf("Expected only description to be invalid, got %+v", err.Fields)
+	}
+
+	if _, err := CreateAuction("Notebook", "Electronics", strings.Repeat("ã", 20), New); err != nil {
+		t.Errorf("Expected the limit to count characters, not bytes, got %v", err)
+	}
+}
+
+func TestCreateAuctionTrimsTextFields(t *testing.T) {
+	auction, err := CreateAuction("  Notebook ", "\tElectronics ", " A test product for auction \n", New)
+	if err != nil {
+		t.Fatalf("Expected a valid auction, got %v", err)
+	}
+
+	if auction.ProductName != "Notebook" ||
+		auction.Category != "Electronics" ||
+		auction.Description != "A test product for auction" {
+		t.Errorf("Expected trimmed fields, got %q, %q, %q",
+			auction.ProductName, auction.Category, auction.Description)
+	}
+}
+
+func TestCreateAuctionAcceptsUnspecifiedCondition(t *testing.T) {
+	auction, err := CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", Unspecified)
+	if err != nil {
+		t.Fatalf("Expected an auction without condition to be valid, got %v", err)
+	}
+	if auction.Condition != Unspecified {
+		t.Errorf("Expected the condition to stay unspecified, got %s", auction.Condition)
+	}
+}
+
+func TestCreateAuctionWithFutureStartIsScheduled(t *testing.T) {
+	startsAt := time.Now().Add(time.Hour)
+	auction, err := CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", Used,
+		WithStartsAt(startsAt))
+	if err != nil {
+		t.Fatalf("Expected a valid auction, got %v", err)
+	}
+	if auction.Status != Scheduled || !auction.StartsAt.Equal(startsAt) {
+		t.Errorf("Expected a Scheduled auction starting at %v, got %s at %v",
+			startsAt, auction.Status, auction.StartsAt)
+	}
+
+	auction, _ = CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", Used,
+		WithStartsAt(time.Now().Add(-time.Minute)))
+	if auction.Status != Active {
+		t.Errorf("Expected an auction starting in the past to be Active, got %s", auction.Status)
+	}
+}
+
+func TestCreateAuctionNormalizesTimestampsToUTC(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	startsAt := time.Now().Add(time.Hour).In(tokyo)
+
+	auction, err := CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", New,
+		WithStartsAt(startsAt))
+	if err != nil {
+		t.Fatalf("Expected a valid auction, got %v", err)
+	}
+
+	if auction.Timestamp.Location() != time.UTC || auction.StartsAt.Location() != time.UTC {
+		t.Errorf("Expected UTC timestamps, got %v and %v", auction.Timestamp, auction.StartsAt)
+	}
+	if !auction.StartsAt.Equal(startsAt) {
+		t.Errorf("Expected the same instant %v, got %v", startsAt, auction.StartsAt)
+	}
+}
+
+func TestCreateAuctionRejectsDurationAboveMaximum(t *testing.T) {
+	os.Setenv("AUCTION_MAX_DURATION", "168h")
+	defer os.Unsetenv("AUCTION_MAX_DURATION")
+
+	_, err := CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", New,
+		WithDuration(8760*time.Hour))
+	if err == nil || err.Err != "bad_request" || len(err.Fields) != 1 ||
+		err.Fields[0].Field != "duration_seconds" {
+		t.Errorf("Expected duration_seconds to be invalid, got %+v", err)
+	}
+
+	auction, err := CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", New,
+		WithDuration(168*time.Hour))
+	if err != nil || auction.Duration != 168*time.Hour {
+		t.Errorf("Expected a duration at the maximum to be accepted, got %+v (%v)", auction, err)
+	}
+}
+
+func TestMaxDurationDefaultsToThirtyDays(t *testing.T) {
+	os.Setenv("AUCTION_MAX_DURATION", "forever")
+	defer os.Unsetenv("AUCTION_MAX_DURATION")
+
+	if maxDuration := MaxDuration(); maxDuration != 30*24*time.Hour {
+		t.Errorf("Expected 720h for an invalid configured maximum, got %v", maxDuration)
+	}
+}
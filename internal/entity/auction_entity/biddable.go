@@ -0,0 +1,36 @@
+package auction_entity
+
+import "time"
+
+// Motivos pelos quais um leilão não aceita lances
+const (
+	NotBiddableNotStarted = "not started"
+	NotBiddableCompleted  = "completed"
+	NotBiddableEnded      = "ended"
+)
+
+// Biddable informa se o leilão aceita lances em now e, quando não aceita, o motivo.
+// Um leilão ativo cujo fim já passou não aceita lances mesmo antes de ser fechado pela
+// varredura. Sem EndsAt, o fim não é verificado
+func (au *Auction) Biddable(now time.Time) (bool, string) {
+	switch {
+	case au.Status == Completed:
+		return false, NotBiddableCompleted
+	case au.Status == Scheduled || now.Before(au.StartsAt):
+		return false, NotBiddableNotStarted
+	case !au.EndsAt.IsZero() && !now.Before(au.EndsAt):
+		return false, NotBiddableEnded
+	}
+
+	return true, ""
+}
+
+// RemainingTime retorna quanto falta para o fim do leilão em now, nunca negativo.
+// Leilões encerrados, vencidos ou sem EndsAt retornam zero
+func (au *Auction) RemainingTime(now time.Time) time.Duration {
+	if au.Status == Completed || au.EndsAt.IsZero() || !now.Before(au.EndsAt) {
+		return 0
+	}
+
+	return au.EndsAt.Sub(now)
+}
@@ -0,0 +1,51 @@
+package auction_entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAuctionBiddable(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		auction  Auction
+		biddable bool
+		reason   string
+	}{
+		{"active", Auction{Status: Active, EndsAt: now.Add(time.Minute)}, true, ""},
+		{"legacy without end", Auction{Status: Active}, true, ""},
+		{"scheduled", Auction{Status: Scheduled, StartsAt: now.Add(time.Minute)}, false, NotBiddableNotStarted},
+		{"start not reached", Auction{Status: Active, StartsAt: now.Add(time.Minute)}, false, NotBiddableNotStarted},
+		{"completed", Auction{Status: Completed}, false, NotBiddableCompleted},
+		{"ended", Auction{Status: Active, EndsAt: now}, false, NotBiddableEnded},
+	}
+	for _, tt := range tests {
+		biddable, reason := tt.auction.Biddable(now)
+		if biddable != tt.biddable || reason != tt.reason {
+			t.Errorf("%s: expected (%t, %q), got (%t, %q)", tt.name, tt.biddable, tt.reason, biddable, reason)
+		}
+	}
+}
+
+func TestAuctionRemainingTime(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		auction   Auction
+		remaining time.Duration
+	}{
+		{"active", Auction{Status: Active, EndsAt: now.Add(time.Minute)}, time.Minute},
+		{"scheduled", Auction{Status: Scheduled, EndsAt: now.Add(time.Hour)}, time.Hour},
+		{"ended", Auction{Status: Active, EndsAt: now.Add(-time.Minute)}, 0},
+		{"completed", Auction{Status: Completed, EndsAt: now.Add(time.Minute)}, 0},
+		{"legacy without end", Auction{Status: Active}, 0},
+	}
+	for _, tt := range tests {
+		if remaining := tt.auction.RemainingTime(now); remaining != tt.remaining {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.remaining, remaining)
+		}
+	}
+}
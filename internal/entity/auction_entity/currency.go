@@ -0,0 +1,57 @@
+package auction_entity
+
+import (
+	"os"
+	"strings"
+)
+
+const fallbackCurrency = "BRL"
+
+// nonCentesimalCurrencies são as moedas ISO 4217 cuja unidade menor não é o centésimo,
+// como JPY (sem casas decimais) e KWD (três casas). Money guarda sempre centésimos,
+// então valores nessas moedas seriam gravados e exibidos na escala errada
+var nonCentesimalCurrencies = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "ISK": true, "JPY": true,
+	"KMF": true, "KRW": true, "PYG": true, "RWF": true, "UGX": true, "UYI": true,
+	"VND": true, "VUV": true, "XAF": true, "XOF": true, "XPF": true,
+	"BHD": true, "IQD": true, "JOD": true, "KWD": true, "LYD": true, "OMR": true,
+	"TND": true, "CLF": true, "UYW": true,
+}
+
+// DefaultCurrency retorna a moeda padrão (ISO 4217) dos leilões baseada em
+// AUCTION_DEFAULT_CURRENCY. Se não estiver definida ou não for suportada, retorna BRL
+func DefaultCurrency() string {
+	currency := NormalizeCurrency(os.Getenv("AUCTION_DEFAULT_CURRENCY"))
+	if !IsSupportedCurrency(currency) {
+		return fallbackCurrency
+	}
+
+	return currency
+}
+
+// NormalizeCurrency padroniza um código de moeda para comparação ("usd " vira "USD")
+func NormalizeCurrency(value string) string {
+	return strings.ToUpper(strings.TrimSpace(value))
+}
+
+// IsValidCurrency verifica se o valor tem o formato de um código ISO 4217:
+// exatamente três letras maiúsculas
+func IsValidCurrency(value string) bool {
+	if len(value) != 3 {
+		return false
+	}
+
+	for _, letter := range value {
+		if letter < 'A' || letter > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSupportedCurrency verifica se o valor é um código ISO 4217 de uma moeda com duas
+// casas decimais, a única escala que Money representa
+func IsSupportedCurrency(value string) bool {
+	return IsValidCurrency(value) && !nonCentesimalCurrencies[value]
+}
@@ -0,0 +1,28 @@
+package auction_entity
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+const defaultMaxDescriptionLength = 2000
+
+// MaxDescriptionLength retorna o tamanho máximo da descrição, em caracteres, baseado
+// em AUCTION_DESCRIPTION_MAX_LENGTH. O padrão é 2000
+func MaxDescriptionLength() int {
+	length, err := strconv.Atoi(os.Getenv("AUCTION_DESCRIPTION_MAX_LENGTH"))
+	if err != nil || length <= 0 {
+		return defaultMaxDescriptionLength
+	}
+
+	return length
+}
+
+// TrimTextFields remove os espaços nas pontas do nome, da categoria e da descrição,
+// para que não sejam gravados nem contem na validação de tamanho
+func (au *Auction) TrimTextFields() {
+	au.ProductName = strings.TrimSpace(au.ProductName)
+	au.Category = strings.TrimSpace(au.Category)
+	au.Description = strings.TrimSpace(au.Description)
+}
@@ -0,0 +1,19 @@
+package auction_entity
+
+import (
+	"os"
+	"time"
+)
+
+const defaultMaxDuration = 30 * 24 * time.Hour
+
+// MaxDuration retorna a maior duração aceita para um leilão, baseada em
+// AUCTION_MAX_DURATION. O padrão é 30 dias
+func MaxDuration() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("AUCTION_MAX_DURATION"))
+	if err != nil || duration <= 0 {
+		return defaultMaxDuration
+	}
+
+	return duration
+}
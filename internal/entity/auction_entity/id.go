@@ -0,0 +1,13 @@
+package auction_entity
+
+import (
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// IsValidAuctionId aceita os dois formatos de ID de leilão: o UUID, padrão, e o
+// hexadecimal de um ObjectID, usado quando o repositório grava o _id como ObjectID.
+// O repositório ainda rejeita o formato que não corresponde ao seu modo
+func IsValidAuctionId(id string) bool {
+	return uuid.Validate(id) == nil || primitive.IsValidObjectID(id)
+}
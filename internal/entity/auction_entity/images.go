@@ -0,0 +1,44 @@
+package auction_entity
+
+import (
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const defaultMaxImages = 10
+
+// MaxImages retorna o número máximo de imagens de um leilão, baseado em
+// AUCTION_MAX_IMAGES. O padrão é 10
+func MaxImages() int {
+	maxImages, err := strconv.Atoi(os.Getenv("AUCTION_MAX_IMAGES"))
+	if err != nil || maxImages < 0 {
+		return defaultMaxImages
+	}
+
+	return maxImages
+}
+
+// WithImageURLs define as URLs das imagens do leilão. As imagens ficam em um
+// armazenamento externo; o leilão guarda apenas as referências
+func WithImageURLs(urls ...string) AuctionOption {
+	return func(au *Auction) {
+		au.ImageURLs = nil
+		for _, imageURL := range urls {
+			au.ImageURLs = append(au.ImageURLs, strings.TrimSpace(imageURL))
+		}
+	}
+}
+
+// invalidImageURLs indica se alguma das URLs está vazia ou não é uma URL http(s) absoluta
+func invalidImageURLs(urls []string) bool {
+	for _, imageURL := range urls {
+		parsed, err := url.ParseRequestURI(imageURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return true
+		}
+	}
+
+	return false
+}
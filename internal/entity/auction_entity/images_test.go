@@ -0,0 +1,48 @@
+package auction_entity
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestCreateAuctionWithImageURLs(t *testing.T) {
+	auction, err := CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", New,
+		WithImageURLs(" https://cdn.example.com/a.jpg ", "http://cdn.example.com/b.png"))
+	if err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+
+	if len(auction.ImageURLs) != 2 || auction.ImageURLs[0] != "https://cdn.example.com/a.jpg" {
+		t.Errorf("Expected the two trimmed image urls, got %v", auction.ImageURLs)
+	}
+
+	for _, imageURL := range []string{"", "  ", "cdn.example.com/a.jpg", "ftp://cdn.example.com/a.jpg"} {
+		_, err := CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", New,
+			WithImageURLs(imageURL))
+		if err == nil || err.Fields[0].Field != "image_urls" {
+			t.Errorf("Expected image url %q to be rejected, got %v", imageURL, err)
+		}
+	}
+}
+
+func TestCreateAuctionRejectsTooManyImages(t *testing.T) {
+	os.Setenv("AUCTION_MAX_IMAGES", "2")
+	defer os.Unsetenv("AUCTION_MAX_IMAGES")
+
+	var urls []string
+	for i := 0; i < 3; i++ {
+		urls = append(urls, fmt.Sprintf("https://cdn.example.com/%d.jpg", i))
+	}
+
+	_, err := CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", New,
+		WithImageURLs(urls...))
+	if err == nil || len(err.Fields) != 1 || err.Fields[0].Field != "image_urls" {
+		t.Fatalf("Expected too many images to be rejected, got %v", err)
+	}
+
+	if _, err := CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", New,
+		WithImageURLs(urls[:2]...)); err != nil {
+		t.Errorf("Expected images up to the cap to be accepted, got %v", err)
+	}
+}
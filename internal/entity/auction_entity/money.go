@@ -0,0 +1,103 @@
+package auction_entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// moneyScale é o número de unidades menores (centavos) em uma unidade da moeda
+const moneyScale = 100
+
+// Money é um valor monetário em unidades menores (centavos). Por ser inteiro, somas
+// e comparações são exatas, ao contrário de float64 (0.1 + 0.2 != 0.3)
+type Money int64
+
+// MoneyFromCents cria um valor a partir da quantidade de centavos
+func MoneyFromCents(cents int64) Money {
+	return Money(cents)
+}
+
+// ParseMoney converte a representação decimal da API ("10", "10.5", "10.50") em
+// Money. Valores com mais de duas casas decimais são rejeitados em vez de arredondados
+func ParseMoney(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+
+	negative := strings.HasPrefix(value, "-")
+	digits := strings.TrimPrefix(value, "-")
+
+	units, fraction, hasFraction := strings.Cut(digits, ".")
+	if units == "" || (hasFraction && fraction == "") || len(fraction) > 2 {
+		return 0, fmt.Errorf("invalid monetary value %q", value)
+	}
+	for _, digit := range units + fraction {
+		if digit < '0' || digit > '9' {
+			return 0, fmt.Errorf("invalid monetary value %q", value)
+		}
+	}
+
+	fraction += strings.Repeat("0", 2-len(fraction))
+	cents, err := strconv.ParseInt(units+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid monetary value %q", value)
+	}
+
+	if negative {
+		cents = -cents
+	}
+
+	return Money(cents), nil
+}
+
+// moneyFromFloat converte valores antigos gravados como float64, arredondando para o
+// centavo mais próximo
+func moneyFromFloat(value float64) Money {
+	return Money(math.Round(value * moneyScale))
+}
+
+// Cents retorna o valor em unidades menores
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+// String formata o valor com duas casas decimais ("10.50")
+func (m Money) String() string {
+	cents := int64(m)
+
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, cents/moneyScale, cents%moneyScale)
+}
+
+// MarshalJSON escreve o valor como número decimal exato (10.50), mantendo o formato
+// numérico que a API já usava
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON aceita o valor como número (10.5) ou string ("10.50"). O texto é
+// convertido diretamente, sem passar por float64
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("invalid monetary value %s", data)
+		}
+		text = number.String()
+	}
+
+	money, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+
+	*m = money
+	return nil
+}
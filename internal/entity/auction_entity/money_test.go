@@ -0,0 +1,107 @@
+package auction_entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMoneySumsAreExact(t *testing.T) {
+	x, y := 0.1, 0.2
+	if x+y == 0.3 {
+		t.Fatal("Expected float64 to misbehave on 0.1 + 0.2")
+	}
+
+	a, _ := ParseMoney("0.1")
+	b, _ := ParseMoney("0.2")
+	c, _ := ParseMoney("0.3")
+	if a+b != c {
+		t.Errorf("Expected 0.1 + 0.2 == 0.3, got %s", a+b)
+	}
+
+	high, _ := ParseMoney("100.30")
+	bid, _ := ParseMoney("100.1")
+	if bid+b != high || bid+b > high || bid+b < high {
+		t.Errorf("Expected 100.1 + 0.2 to compare equal to 100.30, got %s", bid+b)
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	valid := map[string]int64{
+		"10":     1000,
+		"10.5":   1050,
+		"10.50":  1050,
+		"0.01":   1,
+		" 7.25 ": 725,
+		"-3.10":  -310,
+	}
+	for value, cents := range valid {
+		money, err := ParseMoney(value)
+		if err != nil || money.Cents() != cents {
+			t.Errorf("ParseMoney(%q) = %d, %v; expected %d", value, money.Cents(), err, cents)
+		}
+	}
+
+	for _, value := range []string{"", "abc", "1.234", "1.", ".5", "1e3", "--1", "1.-5"} {
+		if _, err := ParseMoney(value); err == nil {
+			t.Errorf("Expected ParseMoney(%q) to fail", value)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	for cents, expected := range map[int64]string{0: "0.00", 5: "0.05", 1050: "10.50", -310: "-3.10"} {
+		if got := MoneyFromCents(cents).String(); got != expected {
+			t.Errorf("Expected %d cents to format as %s, got %s", cents, expected, got)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var input struct {
+		Number Money `json:"number"`
+		Text   Money `json:"text"`
+	}
+	if err := json.Unmarshal([]byte(`{"number": 0.3, "text": "12.05"}`), &input); err != nil {
+		t.Fatalf("Failed to decode money: %v", err)
+	}
+	if input.Number != 30 || input.Text != 1205 {
+		t.Errorf("Unexpected decoded values: %+v", input)
+	}
+
+	if err := json.Unmarshal([]byte(`{"number": 0.001}`), &input); err == nil {
+		t.Error("Expected sub-cent amounts to be rejected")
+	}
+
+	output, _ := json.Marshal(map[string]Money{"amount": 1050})
+	if string(output) != `{"amount":10.50}` {
+		t.Errorf("Expected a decimal JSON number, got %s", output)
+	}
+}
+
+func TestMoneyBSON(t *testing.T) {
+	data, err := bson.Marshal(bson.M{"amount": Money(1050)})
+	if err != nil {
+		t.Fatalf("Failed to encode money: %v", err)
+	}
+	if bson.Raw(data).Lookup("amount").Type != bson.TypeInt64 {
+		t.Errorf("Expected money to be stored as int64 cents")
+	}
+
+	var decoded struct {
+		Amount Money `bson:"amount"`
+	}
+	bson.Unmarshal(data, &decoded)
+	if decoded.Amount != 1050 {
+		t.Errorf("Expected 1050 cents, got %d", decoded.Amount)
+	}
+
+	// Documentos antigos guardam o valor em float64 na unidade da moeda
+	x, y := 0.1, 0.2
+	legacy, _ := bson.Marshal(bson.M{"amount": x + y})
+	bson.Unmarshal(legacy, &decoded)
+	if decoded.Amount != 30 {
+		t.Errorf("Expected a legacy 0.3 to decode as 30 cents, got %d", decoded.Amount)
+	}
+}
@@ -0,0 +1,72 @@
+package auction_entity
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Visibility define quem pode ver e dar lances em um leilão
+type Visibility string
+
+const (
+	Public  Visibility = "public"
+	Private Visibility = "private"
+)
+
+func (v Visibility) IsValid() bool {
+	return v == Public || v == Private
+}
+
+// WithVisibility define a visibilidade do leilão; sem ela, o leilão é público
+func WithVisibility(visibility Visibility) AuctionOption {
+	return func(au *Auction) {
+		au.Visibility = Visibility(strings.ToLower(strings.TrimSpace(string(visibility))))
+	}
+}
+
+// WithAllowedUserIds define os usuários convidados de um leilão privado
+func WithAllowedUserIds(userIds ...string) AuctionOption {
+	return func(au *Auction) {
+		au.AllowedUserIds = nil
+		for _, userId := range userIds {
+			if userId = strings.TrimSpace(userId); userId != "" {
+				au.AllowedUserIds = append(au.AllowedUserIds, userId)
+			}
+		}
+	}
+}
+
+// VisibleTo informa se o usuário pode ver e dar lances no leilão. Leilões privados
+// ficam restritos ao dono e aos convidados; sem usuário, só os públicos são visíveis
+func (au *Auction) VisibleTo(userId string) bool {
+	if au.Visibility != Private {
+		return true
+	}
+
+	if userId == "" {
+		return false
+	}
+
+	if au.OwnerId == userId {
+		return true
+	}
+
+	for _, allowedUserId := range au.AllowedUserIds {
+		if allowedUserId == userId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func invalidAllowedUserIds(userIds []string) bool {
+	for _, userId := range userIds {
+		if uuid.Validate(userId) != nil {
+			return true
+		}
+	}
+
+	return false
+}
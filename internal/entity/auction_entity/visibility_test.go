@@ -0,0 +1,45 @@
+package auction_entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPrivateAuctionVisibleOnlyToOwnerAndInvitedUsers(t *testing.T) {
+	owner, invited, stranger := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	auction, err := CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", New,
+		WithOwnerId(owner),
+		WithVisibility(" PRIVATE "),
+		WithAllowedUserIds(invited, " "))
+	if err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+
+	if auction.Visibility != Private || len(auction.AllowedUserIds) != 1 {
+		t.Fatalf("Expected a private auction with one invited user, got %s %v",
+			auction.Visibility, auction.AllowedUserIds)
+	}
+
+	for userId, expected := range map[string]bool{owner: true, invited: true, stranger: false, "": false} {
+		if visible := auction.VisibleTo(userId); visible != expected {
+			t.Errorf("Expected VisibleTo(%q) to be %t, got %t", userId, expected, visible)
+		}
+	}
+}
+
+func TestCreateAuctionVisibility(t *testing.T) {
+	auction, err := CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", New)
+	if err != nil || auction.Visibility != Public || !auction.VisibleTo("") {
+		t.Fatalf("Expected auctions to be public by default, got %+v (%v)", auction, err)
+	}
+
+	_, err = CreateAuction("Notebook", "Electronics", "Notebook Dell Inspiron 15", New,
+		WithVisibility("secret"), WithAllowedUserIds("not-a-uuid"))
+	if err == nil || len(err.Fields) != 2 {
+		t.Fatalf("Expected invalid visibility and allowed_user_ids, got %+v", err)
+	}
+	if err.Fields[0].Field != "visibility" || err.Fields[1].Field != "allowed_user_ids" {
+		t.Errorf("Expected visibility and allowed_user_ids to be invalid, got %+v", err.Fields)
+	}
+}
@@ -2,8 +2,11 @@ package bid_entity
 
 import (
 	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"github.com/google/uuid"
+	"strings"
 	"time"
 )
 
@@ -11,17 +14,48 @@ type Bid struct {
 	Id        string
 	UserId    string
 	AuctionId string
-	Amount    float64
+	Amount    auction_entity.Money
+	Currency  string
 	Timestamp time.Time
+
+	// RequestId é o identificador enviado pelo cliente para que a repetição de um
+	// mesmo lance não seja gravada duas vezes
+	RequestId string
+}
+
+type BidOption func(*Bid)
+
+const maxRequestIdLength = 128
+
+// WithCurrency define a moeda (ISO 4217) do lance; sem ela, o lance assume a moeda
+// do leilão em ResolveCurrency
+func WithCurrency(currency string) BidOption {
+	return func(b *Bid) {
+		b.Currency = auction_entity.NormalizeCurrency(currency)
+	}
 }
 
-func CreateBid(userId, auctionId string, amount float64) (*Bid, *internal_error.InternalError) {
+// WithRequestId associa ao lance o identificador de requisição do cliente
+func WithRequestId(requestId string) BidOption {
+	return func(b *Bid) {
+		b.RequestId = strings.TrimSpace(requestId)
+	}
+}
+
+func CreateBid(
+	userId, auctionId string,
+	amount auction_entity.Money,
+	opts ...BidOption) (*Bid, *internal_error.InternalError) {
 	bid := &Bid{
 		Id:        uuid.New().String(),
 		UserId:    userId,
 		AuctionId: auctionId,
 		Amount:    amount,
-		Timestamp: time.Now(),
+		Timestamp: time.Now().UTC(),
+	}
+
+	for _, opt := range opts {
+		opt(bid)
 	}
 
 	if err := bid.Validate(); err != nil {
@@ -31,18 +65,112 @@ func CreateBid(userId, auctionId string, amount float64) (*Bid, *internal_error.
 	return bid, nil
 }
 
+// Validate verifica todos os campos do lance e retorna os inválidos em um único erro
 func (b *Bid) Validate() *internal_error.InternalError {
+	var fields []internal_error.FieldError
+	invalid := func(field, message string) {
+		fields = append(fields, internal_error.FieldError{Field: field, Message: message})
+	}
+
 	if err := uuid.Validate(b.UserId); err != nil {
-		return internal_error.NewBadRequestError("UserId is not a valid id")
-	} else if err := uuid.Validate(b.AuctionId); err != nil {
-		return internal_error.NewBadRequestError("AuctionId is not a valid id")
-	} else if b.Amount <= 0 {
-		return internal_error.NewBadRequestError("Amount is not a valid value")
+		invalid("user_id", "UserId is not a valid id")
+	}
+
+	if !auction_entity.IsValidAuctionId(b.AuctionId) {
+		invalid("auction_id", "AuctionId is not a valid id")
+	}
+
+	if b.Amount <= 0 {
+		invalid("amount", "Amount is not a valid value")
+	}
+
+	if len(b.RequestId) > maxRequestIdLength {
+		invalid("request_id", fmt.Sprintf("RequestId must have at most %d characters", maxRequestIdLength))
+	}
+
+	if b.Currency != "" && !auction_entity.IsValidCurrency(b.Currency) {
+		invalid("currency", "Currency is not a valid ISO 4217 code")
+	} else if b.Currency != "" && !auction_entity.IsSupportedCurrency(b.Currency) {
+		invalid("currency", "Currency must have two decimal places")
+	}
+
+	if len(fields) > 0 {
+		return internal_error.NewValidationError("invalid bid object", fields)
+	}
+
+	return nil
+}
+
+// ResolveCurrency associa o lance à moeda do leilão. Um lance sem moeda assume a do
+// leilão, e um lance em outra moeda é rejeitado, já que valores só são comparáveis
+// dentro da mesma moeda
+func (b *Bid) ResolveCurrency(auctionCurrency string) *internal_error.InternalError {
+	if b.Currency == "" {
+		b.Currency = auctionCurrency
+		return nil
+	}
+
+	if b.Currency != auctionCurrency {
+		return internal_error.NewBadRequestError(fmt.Sprintf(
+			"Bid currency %s does not match auction currency %s", b.Currency, auctionCurrency))
+	}
+
+	return nil
+}
+
+// CheckStartingBid rejeita lances abaixo do lance mínimo do leilão
+func (b *Bid) CheckStartingBid(startingBid auction_entity.Money) *internal_error.InternalError {
+	if b.Amount < startingBid {
+		return internal_error.NewBadRequestError(fmt.Sprintf(
+			"Bid amount %s is below the auction starting bid %s", b.Amount, startingBid))
 	}
 
 	return nil
 }
 
+// CheckMaxAmount rejeita lances acima do valor máximo permitido, para barrar erros de
+// digitação como zeros a mais. Zero desliga o limite
+func (b *Bid) CheckMaxAmount(maxAmount auction_entity.Money) *internal_error.InternalError {
+	if maxAmount > 0 && b.Amount > maxAmount {
+		return internal_error.NewBadRequestError(fmt.Sprintf(
+			"Bid amount %s is above the maximum allowed bid %s", b.Amount, maxAmount))
+	}
+
+	return nil
+}
+
+// CheckHighBidMultiple rejeita lances maiores que multiple vezes o maior lance atual do
+// leilão. O primeiro lance, sem maior lance, não é limitado, e multiple zero desliga o limite
+func (b *Bid) CheckHighBidMultiple(
+	highBid auction_entity.Money, multiple float64) *internal_error.InternalError {
+	if multiple <= 0 || highBid <= 0 {
+		return nil
+	}
+
+	limit := auction_entity.MoneyFromCents(int64(float64(highBid.Cents()) * multiple))
+	if b.Amount > limit {
+		return internal_error.NewBadRequestError(fmt.Sprintf(
+			"Bid amount %s is more than %gx the current high bid %s", b.Amount, multiple, highBid))
+	}
+
+	return nil
+}
+
+// TopBidsQuery reúne as opções da busca dos maiores lances de um leilão
+type TopBidsQuery struct {
+	DistinctUsers bool
+}
+
+type TopBidsOption func(*TopBidsQuery)
+
+// WithDistinctUsers mantém apenas o maior lance de cada usuário, para listar
+// vencedor e segundos colocados sem repetir quem deu vários lances
+func WithDistinctUsers() TopBidsOption {
+	return func(q *TopBidsQuery) {
+		q.DistinctUsers = true
+	}
+}
+
 type BidEntityRepository interface {
 	CreateBid(
 		ctx context.Context,
@@ -53,4 +181,19 @@ type BidEntityRepository interface {
 
 	FindWinningBidByAuctionId(
 		ctx context.Context, auctionId string) (*Bid, *internal_error.InternalError)
+
+	FindTopBids(
+		ctx context.Context,
+		auctionId string,
+		n int,
+		opts ...TopBidsOption) ([]Bid, *internal_error.InternalError)
+
+	FindBidsPageByAuctionId(
+		ctx context.Context,
+		auctionId string,
+		offset, limit int) ([]Bid, int64, *internal_error.InternalError)
+
+	// WithdrawBid retorna o lance retirado
+	WithdrawBid(
+		ctx context.Context, bidId, userId string) (*Bid, *internal_error.InternalError)
 }
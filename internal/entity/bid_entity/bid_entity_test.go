@@ -0,0 +1,133 @@
+package bid_entity
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateBid(t *testing.T) {
+	userId, auctionId := uuid.New().String(), uuid.New().String()
+
+	bid, err := CreateBid(userId, auctionId, auction_entity.MoneyFromCents(15050))
+	if err != nil {
+		t.Fatalf("Expected a valid bid, got %v", err)
+	}
+
+	if uuid.Validate(bid.Id) != nil {
+		t.Errorf("Expected a generated UUID, got %q", bid.Id)
+	}
+	if bid.UserId != userId || bid.AuctionId != auctionId || bid.Amount.String() != "150.50" {
+		t.Errorf("Unexpected bid fields: %+v", bid)
+	}
+	if bid.Timestamp.IsZero() {
+		t.Error("Expected the bid timestamp to be set")
+	}
+}
+
+func TestCreateBidRejectsInvalidFields(t *testing.T) {
+	userId, auctionId := uuid.New().String(), uuid.New().String()
+
+	testCases := []struct {
+		name      string
+		userId    string
+		auctionId string
+		amount    auction_entity.Money
+		field     string
+	}{
+		{"empty user", "", auctionId, 10, "user_id"},
+		{"empty auction", userId, "", 10, "auction_id"},
+		{"zero amount", userId, auctionId, 0, "amount"},
+		{"negative amount", userId, auctionId, -5, "amount"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bid, err := CreateBid(tc.userId, tc.auctionId, tc.amount)
+			if err == nil {
+				t.Fatalf("Expected an error, got bid %+v", bid)
+			}
+			if err.Err != "bad_request" {
+				t.Errorf("Expected bad_request, got %s", err.Err)
+			}
+			if len(err.Fields) != 1 || err.Fields[0].Field != tc.field {
+				t.Errorf("Expected only %s to be invalid, got %+v", tc.field, err.Fields)
+			}
+		})
+	}
+}
+
+func TestCreateBidWithRequestId(t *testing.T) {
+	userId, auctionId := uuid.New().String(), uuid.New().String()
+
+	bid, err := CreateBid(userId, auctionId, 100, WithRequestId(" req-1 "))
+	if err != nil || bid.RequestId != "req-1" {
+		t.Fatalf("Expected request id req-1, got %+v (%v)", bid, err)
+	}
+
+	_, err = CreateBid(userId, auctionId, 100, WithRequestId(strings.Repeat("x", maxRequestIdLength+1)))
+	if err == nil || len(err.Fields) != 1 || err.Fields[0].Field != "request_id" {
+		t.Errorf("Expected an oversized request id to be rejected, got %v", err)
+	}
+}
+
+func TestCheckStartingBid(t *testing.T) {
+	startingBid := auction_entity.MoneyFromCents(5000)
+
+	below := &Bid{Amount: auction_entity.MoneyFromCents(4999)}
+	if err := below.CheckStartingBid(startingBid); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for a bid below the starting bid, got %v", err)
+	}
+
+	for _, amount := range []auction_entity.Money{startingBid, startingBid + 1} {
+		bid := &Bid{Amount: amount}
+		if err := bid.CheckStartingBid(startingBid); err != nil {
+			t.Errorf("Expected a bid of %s to be accepted, got %v", amount, err)
+		}
+	}
+}
+
+func TestCheckMaxAmount(t *testing.T) {
+	maxAmount := auction_entity.MoneyFromCents(100000)
+
+	above := &Bid{Amount: maxAmount + 1}
+	if err := above.CheckMaxAmount(maxAmount); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for a bid above the maximum, got %v", err)
+	}
+
+	atMax := &Bid{Amount: maxAmount}
+	if err := atMax.CheckMaxAmount(maxAmount); err != nil {
+		t.Errorf("Expected a bid at the maximum to be accepted, got %v", err)
+	}
+
+	if err := above.CheckMaxAmount(0); err != nil {
+		t.Errorf("Expected no limit with a zero maximum, got %v", err)
+	}
+}
+
+func TestCheckHighBidMultiple(t *testing.T) {
+	highBid := auction_entity.MoneyFromCents(1000)
+
+	tests := []struct {
+		name     string
+		amount   auction_entity.Money
+		highBid  auction_entity.Money
+		multiple float64
+		rejected bool
+	}{
+		{"within the multiple", 10000, highBid, 10, false},
+		{"above the multiple", 10001, highBid, 10, true},
+		{"fractional multiple", 2501, highBid, 2.5, true},
+		{"first bid", 1000000, 0, 10, false},
+		{"disabled", 1000000, highBid, 0, false},
+	}
+	for _, tt := range tests {
+		bid := &Bid{Amount: tt.amount}
+		err := bid.CheckHighBidMultiple(tt.highBid, tt.multiple)
+		if rejected := err != nil; rejected != tt.rejected {
+			t.Errorf("%s: expected rejected %t, got %v", tt.name, tt.rejected, err)
+		}
+	}
+}
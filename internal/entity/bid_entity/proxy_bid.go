@@ -0,0 +1,200 @@
+package bid_entity
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProxyBid é o lance máximo que um usuário autoriza o sistema a dar por ele. O
+// máximo fica oculto: os lances visíveis sobem só o necessário para mantê-lo na frente
+type ProxyBid struct {
+	UserId    string
+	MaxAmount auction_entity.Money
+	Timestamp time.Time
+}
+
+// ProxyBook guarda os lances máximos de um leilão, o preço visível e quem lidera.
+// Não é seguro para uso concorrente: quem o usa deve serializar as chamadas a Place
+type ProxyBook struct {
+	AuctionId string
+	Price     auction_entity.Money
+	LeaderId  string
+
+	proxies map[string]ProxyBid
+}
+
+// NewProxyBook cria o livro de um leilão cujo maior lance atual é price
+func NewProxyBook(auctionId string, price auction_entity.Money) *ProxyBook {
+	return &ProxyBook{
+		AuctionId: auctionId,
+		Price:     price,
+		proxies:   make(map[string]ProxyBid),
+	}
+}
+
+// RestoreProxyBook recria um livro gravado, com o preço, o líder e os máximos dele
+func RestoreProxyBook(
+	auctionId string,
+	price auction_entity.Money,
+	leaderId string,
+	proxies []ProxyBid) *ProxyBook {
+	book := NewProxyBook(auctionId, price)
+	book.LeaderId = leaderId
+	for _, proxy := range proxies {
+		book.proxies[proxy.UserId] = proxy
+	}
+
+	return book
+}
+
+// MaxAmount retorna o lance máximo registrado pelo usuário, ou zero
+func (pb *ProxyBook) MaxAmount(userId string) auction_entity.Money {
+	return pb.proxies[userId].MaxAmount
+}
+
+// Proxies retorna os máximos registrados no livro, em qualquer ordem
+func (pb *ProxyBook) Proxies() []ProxyBid {
+	proxies := make([]ProxyBid, 0, len(pb.proxies))
+	for _, proxy := range pb.proxies {
+		proxies = append(proxies, proxy)
+	}
+
+	return proxies
+}
+
+// Withdraw descarta o máximo de quem retirou um lance, para que o sistema não volte a
+// dar lances por ele. Se ele liderava, o livro passa ao preço e ao líder recalculados
+// a partir dos lances que restaram
+func (pb *ProxyBook) Withdraw(userId string, price auction_entity.Money, leaderId string) {
+	delete(pb.proxies, userId)
+	if pb.LeaderId == userId {
+		pb.Price = price
+		pb.LeaderId = leaderId
+	}
+}
+
+// Place registra o lance e, se maxAmount for maior que zero, o máximo do usuário
+// (um máximo só pode subir). Retorna os lances a gravar:
+//   - o do usuário, no valor informado, ou no novo preço visível se ele passar a liderar;
+//   - o automático do líder, no menor valor que o mantém à frente (o segundo maior
+//     máximo mais increment, limitado ao seu máximo);
+//   - o do líder anterior no seu máximo, quando ele é superado.
+//
+// Em empates no máximo vence quem o registrou primeiro, por isso os lances automáticos
+// levam o instante em que o máximo foi registrado. Um lance que não muda o preço nem
+// o líder, como o líder apenas subindo o seu máximo, não gera lances
+func (pb *ProxyBook) Place(bid Bid, maxAmount, increment auction_entity.Money) []Bid {
+	if maxAmount > pb.proxies[bid.UserId].MaxAmount {
+		pb.proxies[bid.UserId] = ProxyBid{UserId: bid.UserId, MaxAmount: maxAmount, Timestamp: bid.Timestamp}
+	}
+
+	// Cada usuário concorre com o maior entre o seu máximo, o lance que o mantém na
+	// frente (sem instante, pois é anterior a qualquer lance novo) e o lance informado
+	best := make(map[string]ProxyBid, len(pb.proxies)+2)
+	consider := func(candidate ProxyBid) {
+		if current, ok := best[candidate.UserId]; !ok || candidate.MaxAmount > current.MaxAmount {
+			best[candidate.UserId] = candidate
+		}
+	}
+	for _, proxy := range pb.proxies {
+		consider(proxy)
+	}
+	if pb.Price > 0 {
+		consider(ProxyBid{UserId: pb.LeaderId, MaxAmount: pb.Price})
+	}
+	consider(ProxyBid{UserId: bid.UserId, MaxAmount: bid.Amount, Timestamp: bid.Timestamp})
+
+	leader, runnerUp := rankProxies(best)
+	leaderChanged := leader.UserId != pb.LeaderId
+
+	var price auction_entity.Money
+	if runnerUp.MaxAmount > 0 {
+		price = runnerUp.MaxAmount + increment
+	}
+	if !leaderChanged && pb.Price > price {
+		price = pb.Price
+	}
+	if leader.UserId == bid.UserId && bid.Amount > price {
+		price = bid.Amount
+	}
+	if price > leader.MaxAmount {
+		price = leader.MaxAmount
+	}
+
+	var bids []Bid
+	if leader.UserId != bid.UserId {
+		bids = append(bids, bid)
+	}
+	if previous, ok := pb.proxies[pb.LeaderId]; ok && leaderChanged &&
+		previous.UserId != bid.UserId && previous.MaxAmount > pb.Price {
+		bids = append(bids, pb.automaticBid(bid, previous, previous.MaxAmount))
+	}
+	switch {
+	case leader.UserId == bid.UserId && (leaderChanged || price > pb.Price):
+		placed := bid
+		placed.Amount = price
+		bids = append(bids, placed)
+	case leader.UserId != bid.UserId && price > pb.Price:
+		bids = append(bids, pb.automaticBid(bid, leader, price))
+	}
+
+	pb.Price = price
+	pb.LeaderId = leader.UserId
+
+	return bids
+}
+
+// rankProxies retorna o maior máximo e o segundo maior, desempatando pelo mais antigo
+func rankProxies(proxies map[string]ProxyBid) (leader, runnerUp ProxyBid) {
+	for _, proxy := range proxies {
+		if proxy.MaxAmount > leader.MaxAmount ||
+			(proxy.MaxAmount == leader.MaxAmount && proxy.Timestamp.Before(leader.Timestamp)) {
+			leader, runnerUp = proxy, leader
+			continue
+		}
+		if proxy.MaxAmount > runnerUp.MaxAmount {
+			runnerUp = proxy
+		}
+	}
+
+	return leader, runnerUp
+}
+
+// automaticBid cria o lance dado pelo sistema em nome do dono do máximo
+func (pb *ProxyBook) automaticBid(trigger Bid, proxy ProxyBid, amount auction_entity.Money) Bid {
+	return Bid{
+		Id:        uuid.New().String(),
+		UserId:    proxy.UserId,
+		AuctionId: pb.AuctionId,
+		Amount:    amount,
+		Currency:  trigger.Currency,
+		Timestamp: proxy.Timestamp,
+	}
+}
+
+// ProxyBookRepository guarda os livros de máximos fora do processo, para que sobrevivam
+// a reinícios e sejam os mesmos em todas as réplicas
+type ProxyBookRepository interface {
+	// FindProxyBook retorna o livro do leilão, ou nil quando ninguém registrou um máximo
+	FindProxyBook(
+		ctx context.Context, auctionId string) (*ProxyBook, *internal_error.InternalError)
+
+	// UpdateProxyBook aplica update ao livro do leilão, serializando as atualizações de
+	// um mesmo leilão. update recebe nil quando o leilão não tem livro, retorna o livro
+	// a gravar (ou nil para não gravar nada) e pode ser chamada mais de uma vez
+	UpdateProxyBook(
+		ctx context.Context,
+		auctionId string,
+		update func(book *ProxyBook) *ProxyBook) *internal_error.InternalError
+
+	DeleteProxyBook(
+		ctx context.Context, auctionId string) *internal_error.InternalError
+
+	// FindProxyBookAuctionIds lista os leilões que têm livro, para o descarte periódico
+	FindProxyBookAuctionIds(
+		ctx context.Context) ([]string, *internal_error.InternalError)
+}
@@ -0,0 +1,122 @@
+package bid_entity
+
+import (
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+)
+
+// describeBids resume os lances como "usuário@valor" para comparação
+func describeBids(bids []Bid) []string {
+	described := make([]string, 0, len(bids))
+	for _, bid := range bids {
+		described = append(described, fmt.Sprintf("%s@%d", bid.UserId, bid.Amount))
+	}
+	return described
+}
+
+func expectBids(t *testing.T, step string, bids []Bid, expected ...string) {
+	t.Helper()
+
+	described := describeBids(bids)
+	if fmt.Sprint(described) != fmt.Sprint(expected) {
+		t.Errorf("%s: expected bids %v, got %v", step, expected, described)
+	}
+}
+
+func TestProxyBookDuel(t *testing.T) {
+	book := NewProxyBook("auction-1", 0)
+	now := time.Now()
+	bidAt := func(userId string, amount auction_entity.Money, offset time.Duration) Bid {
+		return Bid{UserId: userId, AuctionId: "auction-1", Amount: amount, Timestamp: now.Add(offset)}
+	}
+
+	expectBids(t, "first proxy", book.Place(bidAt("alice", 10, 0), 100, 1), "alice@10")
+	if book.Price != 10 || book.LeaderId != "alice" {
+		t.Errorf("Expected alice leading at 10, got %s at %d", book.LeaderId, book.Price)
+	}
+
+	expectBids(t, "plain bid below the max", book.Place(bidAt("bob", 50, time.Second), 0, 1),
+		"bob@50", "alice@51")
+
+	expectBids(t, "higher proxy", book.Place(bidAt("bob", 60, 2*time.Second), 120, 1),
+		"alice@100", "bob@101")
+	if book.Price != 101 || book.LeaderId != "bob" {
+		t.Errorf("Expected bob leading at 101, got %s at %d", book.LeaderId, book.Price)
+	}
+
+	expectBids(t, "raised proxy", book.Place(bidAt("alice", 110, 3*time.Second), 150, 1),
+		"bob@120", "alice@121")
+
+	// O máximo continua oculto: o preço visível só sobe o necessário
+	if book.Price != 121 || book.MaxAmount("alice") != 150 || book.MaxAmount("bob") != 120 {
+		t.Errorf("Expected visible price 121 with maxes 150/120, got %d (%d/%d)",
+			book.Price, book.MaxAmount("alice"), book.MaxAmount("bob"))
+	}
+
+	expectBids(t, "leader raising its max", book.Place(bidAt("alice", 50, 4*time.Second), 200, 1))
+	if book.Price != 121 || book.MaxAmount("alice") != 200 {
+		t.Errorf("Expected the price to stay at 121, got %d", book.Price)
+	}
+}
+
+func TestProxyBookTieAtMaxFavorsEarliest(t *testing.T) {
+	book := NewProxyBook("auction-1", 0)
+	now := time.Now()
+
+	book.Place(Bid{UserId: "alice", Amount: 10, Timestamp: now}, 100, 1)
+	bids := book.Place(Bid{UserId: "bob", Amount: 20, Timestamp: now.Add(time.Second)}, 100, 1)
+
+	expectBids(t, "tie", bids, "bob@20", "alice@100")
+	if book.LeaderId != "alice" || book.Price != 100 {
+		t.Errorf("Expected alice leading at 100, got %s at %d", book.LeaderId, book.Price)
+	}
+	if !bids[1].Timestamp.Equal(now) {
+		t.Errorf("Expected the automatic bid to keep the max registration time, got %s", bids[1].Timestamp)
+	}
+
+	bids = book.Place(Bid{UserId: "carol", Amount: 100, Timestamp: now.Add(2 * time.Second)}, 0, 1)
+	expectBids(t, "plain bid at the max", bids, "carol@100")
+	if book.LeaderId != "alice" {
+		t.Errorf("Expected alice to keep leading, got %s", book.LeaderId)
+	}
+}
+
+func TestProxyBookStartsFromCurrentPrice(t *testing.T) {
+	book := NewProxyBook("auction-1", 50)
+	now := time.Now()
+
+	bids := book.Place(Bid{UserId: "alice", Amount: 10, Timestamp: now}, 40, 1)
+	expectBids(t, "max below the price", bids, "alice@10")
+	if book.Price != 50 || book.LeaderId != "" {
+		t.Errorf("Expected the existing bid to keep leading at 50, got %s at %d", book.LeaderId, book.Price)
+	}
+
+	bids = book.Place(Bid{UserId: "alice", Amount: 10, Timestamp: now.Add(time.Second)}, 100, 1)
+	expectBids(t, "max above the price", bids, "alice@51")
+}
+
+func TestProxyBookRestoreAndWithdraw(t *testing.T) {
+	now := time.Now()
+	book := NewProxyBook("auction-1", 0)
+	book.Place(Bid{UserId: "alice", Amount: 10, Timestamp: now}, 100, 1)
+	book.Place(Bid{UserId: "bob", Amount: 20, Timestamp: now.Add(time.Second)}, 50, 1)
+
+	restored := RestoreProxyBook(book.AuctionId, book.Price, book.LeaderId, book.Proxies())
+	if restored.Price != 51 || restored.LeaderId != "alice" || restored.MaxAmount("alice") != 100 {
+		t.Fatalf("Expected the restored book to match, got %s at %d", restored.LeaderId, restored.Price)
+	}
+
+	// Quem não lidera só perde o máximo
+	restored.Withdraw("bob", 10, "alice")
+	if restored.MaxAmount("bob") != 0 || restored.Price != 51 || restored.LeaderId != "alice" {
+		t.Errorf("Expected only bob's max to be dropped, got %s at %d", restored.LeaderId, restored.Price)
+	}
+
+	// O líder que retira passa a liderança ao maior lance que restou
+	restored.Withdraw("alice", 20, "bob")
+	if restored.MaxAmount("alice") != 0 || restored.Price != 20 || restored.LeaderId != "bob" {
+		t.Errorf("Expected bob leading at 20, got %s at %d", restored.LeaderId, restored.Price)
+	}
+}
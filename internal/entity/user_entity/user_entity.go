@@ -3,11 +3,71 @@ package user_entity
 import (
 	"context"
 	"fullcycle-auction_go/internal/internal_error"
+	"net/mail"
+	"strings"
+
+	"github.com/google/uuid"
 )
 
 type User struct {
-	Id   string
-	Name string
+	Id    string
+	Name  string
+	Email string
+}
+
+type UserOption func(*User)
+
+// WithEmail define o e-mail do usuário, usado para notificações. É opcional, mas
+// quando informado precisa ser um endereço válido
+func WithEmail(email string) UserOption {
+	return func(u *User) {
+		u.Email = strings.TrimSpace(email)
+	}
+}
+
+func CreateUser(name string, opts ...UserOption) (*User, *internal_error.InternalError) {
+	user := &User{
+		Id:   uuid.New().String(),
+		Name: strings.TrimSpace(name),
+	}
+
+	for _, opt := range opts {
+		opt(user)
+	}
+
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Validate verifica todos os campos do usuário e retorna os inválidos em um único erro
+func (u *User) Validate() *internal_error.InternalError {
+	var fields []internal_error.FieldError
+	invalid := func(field, message string) {
+		fields = append(fields, internal_error.FieldError{Field: field, Message: message})
+	}
+
+	if u.Name == "" {
+		invalid("name", "Name must not be empty")
+	}
+
+	if u.Email != "" && !isValidEmail(u.Email) {
+		invalid("email", "Email is not a valid address")
+	}
+
+	if len(fields) > 0 {
+		return internal_error.NewValidationError("invalid user object", fields)
+	}
+
+	return nil
+}
+
+// isValidEmail aceita apenas o endereço puro (sem nome de exibição, como em "Ana <ana@x.com>")
+func isValidEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
 }
 
 type UserRepositoryInterface interface {
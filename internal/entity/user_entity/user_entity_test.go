@@ -0,0 +1,48 @@
+package user_entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateUser(t *testing.T) {
+	user, err := CreateUser("Ana", WithEmail("ana@example.com"))
+	if err != nil {
+		t.Fatalf("Expected a valid user, got %v", err)
+	}
+
+	if uuid.Validate(user.Id) != nil {
+		t.Errorf("Expected a generated UUID, got %q", user.Id)
+	}
+	if user.Name != "Ana" || user.Email != "ana@example.com" {
+		t.Errorf("Unexpected user fields: %+v", user)
+	}
+
+	if _, err := CreateUser("Bruno"); err != nil {
+		t.Errorf("Expected email to be optional, got %v", err)
+	}
+}
+
+func TestCreateUserRejectsEmptyName(t *testing.T) {
+	_, err := CreateUser("   ")
+	if err == nil {
+		t.Fatal("Expected an error for an empty name")
+	}
+	if len(err.Fields) != 1 || err.Fields[0].Field != "name" {
+		t.Errorf("Expected only name to be invalid, got %+v", err.Fields)
+	}
+}
+
+func TestCreateUserRejectsInvalidEmail(t *testing.T) {
+	for _, email := range []string{"ana", "ana@", "Ana <ana@example.com>"} {
+		_, err := CreateUser("Ana", WithEmail(email))
+		if err == nil {
+			t.Errorf("Expected an error for email %q", email)
+			continue
+		}
+		if len(err.Fields) != 1 || err.Fields[0].Field != "email" {
+			t.Errorf("Expected only email to be invalid for %q, got %+v", email, err.Fields)
+		}
+	}
+}
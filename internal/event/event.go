@@ -0,0 +1,32 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event é um fato do domínio que já aconteceu e pode interessar a outros sistemas.
+// Headers carrega metadados de transporte, como o traceparent do W3C
+type Event struct {
+	Id         string            `json:"id"`
+	Name       string            `json:"name"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Payload    interface{}       `json:"payload"`
+}
+
+func NewEvent(name string, payload interface{}) Event {
+	return Event{
+		Id:         uuid.New().String(),
+		Name:       name,
+		OccurredAt: time.Now().UTC(),
+		Payload:    payload,
+	}
+}
+
+// Publisher entrega eventos para fora da aplicação (fila, broker, log...)
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
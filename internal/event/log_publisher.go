@@ -0,0 +1,25 @@
+package event
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+
+	"go.uber.org/zap"
+)
+
+// LogPublisher apenas registra os eventos no log. É o publisher padrão enquanto
+// não houver um broker configurado
+type LogPublisher struct{}
+
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, event Event) error {
+	logger.InfoContext(ctx, "Event published",
+		zap.String("event_id", event.Id),
+		zap.String("event_name", event.Name),
+		zap.Any("headers", event.Headers),
+		zap.Any("payload", event.Payload))
+	return nil
+}
@@ -0,0 +1,120 @@
+package event
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RetryingPublisher tenta de novo as publicações que falham, esperando entre as
+// tentativas um intervalo exponencial com jitter, para que uma instabilidade passageira
+// do broker não descarte o evento. Esgotadas as tentativas, o último erro é devolvido
+// para quem publicou, que decide entre o outbox e o log
+type RetryingPublisher struct {
+	next      Publisher
+	attempts  int
+	baseDelay time.Duration
+}
+
+type RetryingPublisherOption func(*RetryingPublisher)
+
+// WithRetryAttempts define quantas vezes, no total, o evento é enviado
+func WithRetryAttempts(attempts int) RetryingPublisherOption {
+	return func(p *RetryingPublisher) {
+		p.attempts = attempts
+	}
+}
+
+// WithRetryBaseDelay define a espera antes da segunda tentativa. Ela dobra a cada
+// nova tentativa
+func WithRetryBaseDelay(baseDelay time.Duration) RetryingPublisherOption {
+	return func(p *RetryingPublisher) {
+		p.baseDelay = baseDelay
+	}
+}
+
+func NewRetryingPublisher(next Publisher, opts ...RetryingPublisherOption) *RetryingPublisher {
+	publisher := &RetryingPublisher{
+		next:      next,
+		attempts:  getPublishAttempts(),
+		baseDelay: getPublishRetryBaseDelay(),
+	}
+
+	for _, opt := range opts {
+		opt(publisher)
+	}
+
+	if publisher.attempts < 1 {
+		publisher.attempts = 1
+	}
+
+	return publisher
+}
+
+// getPublishAttempts lê EVENT_PUBLISH_ATTEMPTS. O padrão é 3 tentativas
+func getPublishAttempts() int {
+	attempts, err := strconv.Atoi(os.Getenv("EVENT_PUBLISH_ATTEMPTS"))
+	if err != nil || attempts < 1 {
+		return 3
+	}
+
+	return attempts
+}
+
+// getPublishRetryBaseDelay lê EVENT_PUBLISH_RETRY_BASE_DELAY. O padrão é 100ms
+func getPublishRetryBaseDelay() time.Duration {
+	baseDelay, err := time.ParseDuration(os.Getenv("EVENT_PUBLISH_RETRY_BASE_DELAY"))
+	if err != nil || baseDelay <= 0 {
+		return 100 * time.Millisecond
+	}
+
+	return baseDelay
+}
+
+func (p *RetryingPublisher) Publish(ctx context.Context, event Event) error {
+	var err error
+	for attempt := 1; attempt <= p.attempts; attempt++ {
+		if err = p.next.Publish(ctx, event); err == nil {
+			return nil
+		}
+		if attempt == p.attempts {
+			break
+		}
+
+		delay := p.backoff(attempt)
+		logger.Warn("Retrying event publish",
+			logger.ContextFields(ctx,
+				zap.String("event_id", event.Id),
+				zap.String("event_name", event.Name),
+				zap.Int("attempt", attempt),
+				zap.Duration("delay", delay),
+				zap.Error(err))...)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+
+	return err
+}
+
+// backoff retorna a espera após a tentativa: o intervalo base dobrado a cada
+// tentativa, sorteado entre a metade e o valor cheio para espalhar as instâncias
+func (p *RetryingPublisher) backoff(attempt int) time.Duration {
+	delay := p.baseDelay << (attempt - 1)
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
@@ -0,0 +1,75 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// flakyPublisher falha as primeiras failures publicações
+type flakyPublisher struct {
+	failures int
+	calls    int
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, event Event) error {
+	p.calls++
+	if p.calls <= p.failures {
+		return errors.New("broker unavailable")
+	}
+	return nil
+}
+
+func TestRetryingPublisherEventuallyPublishes(t *testing.T) {
+	flaky := &flakyPublisher{failures: 2}
+	publisher := NewRetryingPublisher(flaky,
+		WithRetryAttempts(3), WithRetryBaseDelay(time.Millisecond))
+
+	if err := publisher.Publish(context.Background(), NewEvent("auction.closed", nil)); err != nil {
+		t.Fatalf("Expected the event to be published, got %v", err)
+	}
+	if flaky.calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", flaky.calls)
+	}
+}
+
+func TestRetryingPublisherGivesUpAfterAttempts(t *testing.T) {
+	flaky := &flakyPublisher{failures: 5}
+	publisher := NewRetryingPublisher(flaky,
+		WithRetryAttempts(3), WithRetryBaseDelay(time.Millisecond))
+
+	if err := publisher.Publish(context.Background(), NewEvent("auction.closed", nil)); err == nil {
+		t.Fatal("Expected the last error after exhausting the attempts")
+	}
+	if flaky.calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", flaky.calls)
+	}
+}
+
+func TestRetryingPublisherStopsWhenContextIsDone(t *testing.T) {
+	flaky := &flakyPublisher{failures: 5}
+	publisher := NewRetryingPublisher(flaky,
+		WithRetryAttempts(3), WithRetryBaseDelay(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := publisher.Publish(ctx, NewEvent("auction.closed", nil)); err == nil {
+		t.Fatal("Expected an error when the context is done")
+	}
+	if flaky.calls != 1 {
+		t.Errorf("Expected no retry after the context is done, got %d attempts", flaky.calls)
+	}
+}
+
+func TestRetryingPublisherBackoffGrowsWithJitter(t *testing.T) {
+	publisher := NewRetryingPublisher(&flakyPublisher{}, WithRetryBaseDelay(100*time.Millisecond))
+
+	for attempt, full := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond} {
+		delay := publisher.backoff(attempt)
+		if delay < full/2 || delay > full {
+			t.Errorf("Expected attempt %d to wait between %s and %s, got %s", attempt, full/2, full, delay)
+		}
+	}
+}
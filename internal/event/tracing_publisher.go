@@ -0,0 +1,59 @@
+package event
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// TracingPublisher injeta o contexto de rastreamento (W3C traceparent) do ctx nos
+// headers do evento antes de repassá-lo, para que os consumidores continuem o mesmo trace
+type TracingPublisher struct {
+	next       Publisher
+	propagator propagation.TextMapPropagator
+}
+
+type TracingPublisherOption func(*TracingPublisher)
+
+// WithPropagator substitui o propagator global do OpenTelemetry
+func WithPropagator(propagator propagation.TextMapPropagator) TracingPublisherOption {
+	return func(p *TracingPublisher) {
+		p.propagator = propagator
+	}
+}
+
+func NewTracingPublisher(next Publisher, opts ...TracingPublisherOption) *TracingPublisher {
+	publisher := &TracingPublisher{
+		next:       next,
+		propagator: otel.GetTextMapPropagator(),
+	}
+
+	for _, opt := range opts {
+		opt(publisher)
+	}
+
+	return publisher
+}
+
+func (p *TracingPublisher) Publish(ctx context.Context, event Event) error {
+	return p.next.Publish(ctx, WithTraceContext(ctx, event, p.propagator))
+}
+
+// WithTraceContext retorna uma cópia do evento com o contexto de rastreamento do ctx
+// nos headers. Headers já presentes, como os de um evento reenviado a partir do
+// outbox, são preservados quando o ctx não carrega um span
+func WithTraceContext(
+	ctx context.Context, event Event, propagator propagation.TextMapPropagator) Event {
+	headers := make(map[string]string, len(event.Headers))
+	for key, value := range event.Headers {
+		headers[key] = value
+	}
+
+	propagator.Inject(ctx, propagation.MapCarrier(headers))
+	if len(headers) > 0 {
+		event.Headers = headers
+	}
+
+	return event
+}
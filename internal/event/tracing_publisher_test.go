@@ -0,0 +1,64 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type recordingPublisher struct {
+	events []Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event Event) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestTracingPublisherInjectsTraceparent(t *testing.T) {
+	traceId, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanId, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceId,
+		SpanID:     spanId,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	recording := &recordingPublisher{}
+	publisher := NewTracingPublisher(recording, WithPropagator(propagation.TraceContext{}))
+
+	if err := publisher.Publish(ctx, NewEvent("auction.closed", nil)); err != nil {
+		t.Fatalf("Failed to publish event: %v", err)
+	}
+
+	if len(recording.events) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(recording.events))
+	}
+	expected := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	if traceparent := recording.events[0].Headers["traceparent"]; traceparent != expected {
+		t.Errorf("Expected traceparent %s, got %q", expected, traceparent)
+	}
+}
+
+func TestTracingPublisherKeepsHeadersWithoutSpan(t *testing.T) {
+	recording := &recordingPublisher{}
+	publisher := NewTracingPublisher(recording, WithPropagator(propagation.TraceContext{}))
+
+	stored := NewEvent("auction.closed", nil)
+	stored.Headers = map[string]string{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}
+	publisher.Publish(context.Background(), stored)
+
+	if recording.events[0].Headers["traceparent"] != stored.Headers["traceparent"] {
+		t.Errorf("Expected the stored traceparent to be kept, got %v", recording.events[0].Headers)
+	}
+
+	publisher.Publish(context.Background(), NewEvent("auction.closed", nil))
+	if recording.events[1].Headers != nil {
+		t.Errorf("Expected no headers without a span, got %v", recording.events[1].Headers)
+	}
+}
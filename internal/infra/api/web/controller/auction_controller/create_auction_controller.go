@@ -1,8 +1,8 @@
 package auction_controller
 
 import (
-	"context"
 	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
 	"fullcycle-auction_go/internal/infra/api/web/validation"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
 	"github.com/gin-gonic/gin"
@@ -28,8 +28,9 @@ func (u *AuctionController) CreateAuction(c *gin.Context) {
 		c.JSON(restErr.Code, restErr)
 		return
 	}
+	auctionInputDTO.OwnerId = c.GetHeader(middleware.ActingUserHeader)
 
-	err := u.auctionUseCase.CreateAuction(context.Background(), auctionInputDTO)
+	err := u.auctionUseCase.CreateAuction(c.Request.Context(), auctionInputDTO)
 	if err != nil {
 		restErr := rest_err.ConvertError(err)
 
@@ -1,11 +1,12 @@
 package auction_controller
 
 import (
-	"context"
+	"fmt"
 	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
 	"fullcycle-auction_go/internal/usecase/auction_usecase"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"net/http"
 	"strconv"
 )
@@ -13,17 +14,18 @@ import (
 func (u *AuctionController) FindAuctionById(c *gin.Context) {
 	auctionId := c.Param("auctionId")
 
-	if err := uuid.Validate(auctionId); err != nil {
+	if !auction_entity.IsValidAuctionId(auctionId) {
 		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
 			Field:   "auctionId",
-			Message: "Invalid UUID value",
+			Message: "Invalid auction id value",
 		})
 
 		c.JSON(errRest.Code, errRest)
 		return
 	}
 
-	auctionData, err := u.auctionUseCase.FindAuctionById(context.Background(), auctionId)
+	auctionData, err := u.auctionUseCase.FindAuctionById(
+		c.Request.Context(), auctionId, c.GetHeader(middleware.ActingUserHeader))
 	if err != nil {
 		errRest := rest_err.ConvertError(err)
 		c.JSON(errRest.Code, errRest)
@@ -33,10 +35,61 @@ func (u *AuctionController) FindAuctionById(c *gin.Context) {
 	c.JSON(http.StatusOK, auctionData)
 }
 
+// FindAuctionStatus responde apenas o andamento do leilão, para polling frequente
+func (u *AuctionController) FindAuctionStatus(c *gin.Context) {
+	auctionId := c.Param("auctionId")
+
+	if !auction_entity.IsValidAuctionId(auctionId) {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid auction id value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	auctionStatus, err := u.auctionUseCase.FindAuctionStatus(
+		c.Request.Context(), auctionId, c.GetHeader(middleware.ActingUserHeader))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, auctionStatus)
+}
+
+// FindRemainingTime responde os segundos que faltam para o fim do leilão
+func (u *AuctionController) FindRemainingTime(c *gin.Context) {
+	auctionId := c.Param("auctionId")
+
+	if !auction_entity.IsValidAuctionId(auctionId) {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid auction id value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	remaining, err := u.auctionUseCase.FindRemainingTime(
+		c.Request.Context(), auctionId, c.GetHeader(middleware.ActingUserHeader))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, remaining)
+}
+
 func (u *AuctionController) FindAuctions(c *gin.Context) {
 	status := c.Query("status")
 	category := c.Query("category")
 	productName := c.Query("productName")
+	sort := c.Query("sort")
 
 	statusNumber, errConv := strconv.Atoi(status)
 	if errConv != nil {
@@ -45,8 +98,40 @@ func (u *AuctionController) FindAuctions(c *gin.Context) {
 		return
 	}
 
-	auctions, err := u.auctionUseCase.FindAuctions(context.Background(),
-		auction_usecase.AuctionStatus(statusNumber), category, productName)
+	auctions, err := u.auctionUseCase.FindAuctions(c.Request.Context(),
+		auction_usecase.AuctionStatus(statusNumber), category, productName, sort,
+		c.GetHeader(middleware.ActingUserHeader))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, auctions)
+}
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+	maxRunnerUps     = 10
+)
+
+func (u *AuctionController) ListAuctions(c *gin.Context) {
+	page, errPage := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, errLimit := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultPageLimit)))
+	if errPage != nil || errLimit != nil || page < 1 || limit < 1 || limit > maxPageLimit {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "page/limit",
+			Message: fmt.Sprintf("page must be at least 1 and limit between 1 and %d", maxPageLimit),
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	auctions, err := u.auctionUseCase.ListAuctions(c.Request.Context(),
+		c.Query("status"), c.Query("category"), c.Query("productName"), page, limit,
+		c.GetHeader(middleware.ActingUserHeader))
 	if err != nil {
 		errRest := rest_err.ConvertError(err)
 		c.JSON(errRest.Code, errRest)
@@ -59,17 +144,29 @@ func (u *AuctionController) FindAuctions(c *gin.Context) {
 func (u *AuctionController) FindWinningBidByAuctionId(c *gin.Context) {
 	auctionId := c.Param("auctionId")
 
-	if err := uuid.Validate(auctionId); err != nil {
+	if !auction_entity.IsValidAuctionId(auctionId) {
 		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
 			Field:   "auctionId",
-			Message: "Invalid UUID value",
+			Message: "Invalid auction id value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	runnerUps, errRunnerUps := strconv.Atoi(c.DefaultQuery("runner_ups", "0"))
+	if errRunnerUps != nil || runnerUps < 0 || runnerUps > maxRunnerUps {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "runner_ups",
+			Message: fmt.Sprintf("runner_ups must be between 0 and %d", maxRunnerUps),
 		})
 
 		c.JSON(errRest.Code, errRest)
 		return
 	}
 
-	auctionData, err := u.auctionUseCase.FindWinningBidByAuctionId(context.Background(), auctionId)
+	auctionData, err := u.auctionUseCase.FindWinningBidByAuctionId(
+		c.Request.Context(), auctionId, runnerUps, c.GetHeader(middleware.ActingUserHeader))
 	if err != nil {
 		errRest := rest_err.ConvertError(err)
 		c.JSON(errRest.Code, errRest)
@@ -78,3 +175,27 @@ func (u *AuctionController) FindWinningBidByAuctionId(c *gin.Context) {
 
 	c.JSON(http.StatusOK, auctionData)
 }
+
+func (u *AuctionController) ListCategories(c *gin.Context) {
+	categories, err := u.auctionUseCase.ListCategories(c.Request.Context())
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+func (u *AuctionController) CountActiveAuctions(c *gin.Context) {
+	count, err := u.auctionUseCase.CountActiveAuctions(c.Request.Context())
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	// O contador tolera alguns segundos de atraso, então proxies e navegadores podem reaproveitá-lo
+	c.Header("Cache-Control", "public, max-age=5")
+	c.JSON(http.StatusOK, count)
+}
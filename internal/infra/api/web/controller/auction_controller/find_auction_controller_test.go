@@ -0,0 +1,220 @@
+package auction_controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/database/memory"
+	"fullcycle-auction_go/internal/usecase/auction_usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func newListAuctionsRouter(auctions ...auction_entity.Auction) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	controller := NewAuctionController(auction_usecase.NewAuctionUseCase(
+		memory.NewAuctionRepository(auctions...), nil))
+
+	router := gin.New()
+	router.GET("/auctions", controller.ListAuctions)
+	return router
+}
+
+func seedAuctions() []auction_entity.Auction {
+	base := time.Now().Add(-time.Hour)
+
+	var auctions []auction_entity.Auction
+	for i := 0; i < 25; i++ {
+		status, category := auction_entity.Active, "Electronics"
+		if i%5 == 0 {
+			status = auction_entity.Completed
+		}
+		if i%2 == 0 {
+			category = "Music"
+		}
+
+		auctions = append(auctions, auction_entity.Auction{
+			Id:          fmt.Sprintf("auction-%02d", i),
+			ProductName: fmt.Sprintf("Product %02d", i),
+			Category:    category,
+			Status:      status,
+			Condition:   auction_entity.New,
+			Timestamp:   base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	return auctions
+}
+
+func getAuctions(t *testing.T, router *gin.Engine, query string) (int, auction_usecase.AuctionListOutputDTO) {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/auctions"+query, nil))
+
+	var body auction_usecase.AuctionListOutputDTO
+	if recorder.Code == http.StatusOK {
+		if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	}
+
+	return recorder.Code, body
+}
+
+func TestListAuctionsDefaultPagination(t *testing.T) {
+	router := newListAuctionsRouter(seedAuctions()...)
+
+	code, body := getAuctions(t, router, "")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+
+	if len(body.Data) != 20 {
+		t.Errorf("Expected a default page of 20 auctions, got %d", len(body.Data))
+	}
+	expected := auction_usecase.PaginationOutputDTO{Page: 1, Limit: 20, Total: 25, TotalPages: 2}
+	if body.Pagination != expected {
+		t.Errorf("Expected pagination %+v, got %+v", expected, body.Pagination)
+	}
+	if body.Data[0].Id != "auction-24" {
+		t.Errorf("Expected the most recent auction first, got %s", body.Data[0].Id)
+	}
+
+	_, second := getAuctions(t, router, "?page=2")
+	if len(second.Data) != 5 {
+		t.Errorf("Expected 5 auctions on the last page, got %d", len(second.Data))
+	}
+}
+
+func TestListAuctionsWithFilters(t *testing.T) {
+	router := newListAuctionsRouter(seedAuctions()...)
+
+	code, body := getAuctions(t, router, "?status=active&category=Electronics&limit=5")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+
+	// Ímpares de 0 a 24, exceto os múltiplos de 5 (Completed): 10 leilões
+	if body.Pagination.Total != 10 || body.Pagination.TotalPages != 2 {
+		t.Errorf("Expected 10 auctions in 2 pages, got %+v", body.Pagination)
+	}
+	for _, auction := range body.Data {
+		if auction.Status != "Active" || auction.Category != "Electronics" {
+			t.Errorf("Unexpected auction in filtered result: %+v", auction)
+		}
+	}
+
+	_, byName := getAuctions(t, router, "?productName=product%2007")
+	if len(byName.Data) != 1 || byName.Data[0].Id != "auction-07" {
+		t.Errorf("Expected only auction-07 when filtering by product name, got %+v", byName.Data)
+	}
+}
+
+func TestListAuctionsRejectsInvalidParams(t *testing.T) {
+	router := newListAuctionsRouter(seedAuctions()...)
+
+	for _, query := range []string{"?status=finished", "?page=0", "?limit=abc", "?limit=1000"} {
+		if code, _ := getAuctions(t, router, query); code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %q, got %d", query, code)
+		}
+	}
+}
+
+func TestFindAuctionStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	endsAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	auction := auction_entity.Auction{
+		Id:             uuid.New().String(),
+		ProductName:    "Notebook",
+		Status:         auction_entity.Active,
+		EndsAt:         endsAt,
+		BidCount:       3,
+		CurrentHighBid: auction_entity.MoneyFromCents(15050),
+	}
+	controller := NewAuctionController(auction_usecase.NewAuctionUseCase(
+		memory.NewAuctionRepository(auction), nil))
+	router := gin.New()
+	router.GET("/auction/:auctionId/status", controller.FindAuctionStatus)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet,
+		"/auction/"+auction.Id+"/status", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", recorder.Code)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	expected := map[string]interface{}{
+		"status":           "Active",
+		"current_high_bid": 150.5,
+		"ends_at":          "2024-01-01T12:00:00Z",
+		"bid_count":        float64(3),
+	}
+	if len(body) != len(expected) {
+		t.Errorf("Expected only %d fields, got %+v", len(expected), body)
+	}
+	for field, value := range expected {
+		if body[field] != value {
+			t.Errorf("Expected %s = %v, got %v", field, value, body[field])
+		}
+	}
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet,
+		"/auction/"+uuid.New().String()+"/status", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for a missing auction, got %d", recorder.Code)
+	}
+}
+
+func TestListCategories(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	controller := NewAuctionController(auction_usecase.NewAuctionUseCase(
+		memory.NewAuctionRepository(seedAuctions()...), nil))
+	router := gin.New()
+	router.GET("/categories", controller.ListCategories)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/categories", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", recorder.Code)
+	}
+
+	var categories []string
+	if err := json.Unmarshal(recorder.Body.Bytes(), &categories); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(categories) != 2 || categories[0] != "Electronics" || categories[1] != "Music" {
+		t.Errorf("Expected [Electronics Music], got %v", categories)
+	}
+}
+
+func TestCountActiveAuctions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	controller := NewAuctionController(auction_usecase.NewAuctionUseCase(
+		memory.NewAuctionRepository(seedAuctions()...), nil))
+	router := gin.New()
+	router.GET("/auctions/active/count", controller.CountActiveAuctions)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/auctions/active/count", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", recorder.Code)
+	}
+
+	// seedAuctions cria 25 leilões, dos quais 5 estão encerrados
+	if body := recorder.Body.String(); body != `{"count":20}` {
+		t.Errorf("Expected {\"count\":20}, got %s", body)
+	}
+}
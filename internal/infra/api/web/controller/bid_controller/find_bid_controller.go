@@ -1,27 +1,30 @@
 package bid_controller
 
 import (
-	"context"
+	"fmt"
 	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"net/http"
+	"strconv"
 )
 
 func (u *BidController) FindBidByAuctionId(c *gin.Context) {
 	auctionId := c.Param("auctionId")
 
-	if err := uuid.Validate(auctionId); err != nil {
+	if !auction_entity.IsValidAuctionId(auctionId) {
 		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
 			Field:   "auctionId",
-			Message: "Invalid UUID value",
+			Message: "Invalid auction id value",
 		})
 
 		c.JSON(errRest.Code, errRest)
 		return
 	}
 
-	bidOutputList, err := u.bidUseCase.FindBidByAuctionId(context.Background(), auctionId)
+	bidOutputList, err := u.bidUseCase.FindBidByAuctionId(
+		c.Request.Context(), auctionId, c.GetHeader(middleware.ActingUserHeader))
 	if err != nil {
 		errRest := rest_err.ConvertError(err)
 		c.JSON(errRest.Code, errRest)
@@ -30,3 +33,70 @@ func (u *BidController) FindBidByAuctionId(c *gin.Context) {
 
 	c.JSON(http.StatusOK, bidOutputList)
 }
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+func (u *BidController) FindBidsPageByAuctionId(c *gin.Context) {
+	auctionId := c.Param("auctionId")
+
+	if !auction_entity.IsValidAuctionId(auctionId) {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid auction id value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	page, errPage := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, errLimit := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultPageLimit)))
+	if errPage != nil || errLimit != nil || page < 1 || limit < 1 || limit > maxPageLimit {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "page/limit",
+			Message: fmt.Sprintf("page must be at least 1 and limit between 1 and %d", maxPageLimit),
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	bids, err := u.bidUseCase.FindBidsPageByAuctionId(
+		c.Request.Context(), auctionId, page, limit, c.GetHeader(middleware.ActingUserHeader))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, bids)
+}
+
+// FindProxyBid responde o preço visível do leilão e, para o usuário do header
+// X-User-Id, se ele lidera e o seu lance máximo
+func (u *BidController) FindProxyBid(c *gin.Context) {
+	auctionId := c.Param("auctionId")
+
+	if !auction_entity.IsValidAuctionId(auctionId) {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "auctionId",
+			Message: "Invalid auction id value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	proxyBid, err := u.bidUseCase.FindProxyBid(
+		c.Request.Context(), auctionId, c.GetHeader(middleware.ActingUserHeader))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.JSON(http.StatusOK, proxyBid)
+}
@@ -0,0 +1,92 @@
+package bid_controller
+
+import (
+	"context"
+	"encoding/json"
+	"fullcycle-auction_go/internal/internal_error"
+	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type stubBidUseCase struct {
+	bid_usecase.BidUseCaseInterface
+
+	page, limit int
+	output      *bid_usecase.BidListOutputDTO
+	err         *internal_error.InternalError
+}
+
+func (s *stubBidUseCase) FindBidsPageByAuctionId(
+	ctx context.Context,
+	auctionId string,
+	page, limit int,
+	viewerId string) (*bid_usecase.BidListOutputDTO, *internal_error.InternalError) {
+	s.page, s.limit = page, limit
+	return s.output, s.err
+}
+
+func getBids(t *testing.T, useCase *stubBidUseCase, path string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/auction/:auctionId/bids", NewBidController(useCase).FindBidsPageByAuctionId)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder
+}
+
+func TestFindBidsPageByAuctionIdDefaults(t *testing.T) {
+	auctionId := uuid.New().String()
+	useCase := &stubBidUseCase{output: &bid_usecase.BidListOutputDTO{
+		Data:       []bid_usecase.BidOutputDTO{{AuctionId: auctionId, Amount: 300}},
+		Pagination: bid_usecase.PaginationOutputDTO{Page: 1, Limit: 20, Total: 1, TotalPages: 1},
+	}}
+
+	recorder := getBids(t, useCase, "/auction/"+auctionId+"/bids")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if useCase.page != 1 || useCase.limit != defaultPageLimit {
+		t.Errorf("Expected page 1 and limit %d, got %d and %d", defaultPageLimit, useCase.page, useCase.limit)
+	}
+
+	var body bid_usecase.BidListOutputDTO
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(body.Data) != 1 || body.Pagination.Total != 1 {
+		t.Errorf("Unexpected response body: %+v", body)
+	}
+}
+
+func TestFindBidsPageByAuctionIdInvalidRequests(t *testing.T) {
+	auctionId := uuid.New().String()
+
+	for _, path := range []string{
+		"/auction/not-a-uuid/bids",
+		"/auction/" + auctionId + "/bids?page=0",
+		"/auction/" + auctionId + "/bids?limit=101",
+		"/auction/" + auctionId + "/bids?limit=abc",
+	} {
+		if recorder := getBids(t, &stubBidUseCase{}, path); recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", path, recorder.Code)
+		}
+	}
+}
+
+func TestFindBidsPageByAuctionIdNotFound(t *testing.T) {
+	useCase := &stubBidUseCase{
+		err: internal_error.NewNotFoundError("Auction not found"),
+	}
+
+	recorder := getBids(t, useCase, "/auction/"+uuid.New().String()+"/bids")
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,34 @@
+package bid_controller
+
+import (
+	"fullcycle-auction_go/configuration/rest_err"
+	"fullcycle-auction_go/internal/infra/api/web/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+// WithdrawBid retira o lance em nome do usuário do header X-User-Id
+func (u *BidController) WithdrawBid(c *gin.Context) {
+	bidId := c.Param("bidId")
+
+	if err := uuid.Validate(bidId); err != nil {
+		errRest := rest_err.NewBadRequestError("Invalid fields", rest_err.Causes{
+			Field:   "bidId",
+			Message: "Invalid UUID value",
+		})
+
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	err := u.bidUseCase.WithdrawBid(
+		c.Request.Context(), bidId, c.GetHeader(middleware.ActingUserHeader))
+	if err != nil {
+		errRest := rest_err.ConvertError(err)
+		c.JSON(errRest.Code, errRest)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
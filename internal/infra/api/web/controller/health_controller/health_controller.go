@@ -0,0 +1,70 @@
+package health_controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// readinessTimeout limita cada verificação de prontidão, para que a sonda do
+// Kubernetes não fique presa esperando um MongoDB que não responde
+const readinessTimeout = 2 * time.Second
+
+const (
+	statusOk          = "ok"
+	statusUnavailable = "unavailable"
+)
+
+// HealthSource é um subsistema verificado pela prontidão, como o AuctionRepository,
+// cujo Health confere o MongoDB e o monitor de leilões expirados
+type HealthSource interface {
+	Health(ctx context.Context) error
+}
+
+// HealthOutputDTO descreve o estado geral e, na prontidão, o de cada subsistema
+type HealthOutputDTO struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+type HealthController struct {
+	sources map[string]HealthSource
+}
+
+// NewHealthController recebe os subsistemas da prontidão, identificados pelo nome
+// usado no corpo da resposta
+func NewHealthController(sources map[string]HealthSource) *HealthController {
+	return &HealthController{
+		sources: sources,
+	}
+}
+
+// Liveness responde 200 enquanto o processo consegue atender requisições
+func (h *HealthController) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthOutputDTO{Status: statusOk})
+}
+
+// Readiness responde 200 quando todos os subsistemas estão saudáveis e 503 quando
+// algum falha, com o motivo da falha no estado do subsistema
+func (h *HealthController) Readiness(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	output := HealthOutputDTO{Status: statusOk, Checks: make(map[string]string, len(h.sources))}
+	for name, source := range h.sources {
+		if err := source.Health(ctx); err != nil {
+			output.Status = statusUnavailable
+			output.Checks[name] = err.Error()
+			continue
+		}
+		output.Checks[name] = statusOk
+	}
+
+	code := http.StatusOK
+	if output.Status != statusOk {
+		code = http.StatusServiceUnavailable
+	}
+
+	c.JSON(code, output)
+}
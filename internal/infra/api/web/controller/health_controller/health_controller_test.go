@@ -0,0 +1,68 @@
+package health_controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeHealthSource struct {
+	err error
+}
+
+func (f *fakeHealthSource) Health(ctx context.Context) error {
+	return f.err
+}
+
+func probe(t *testing.T, controller *HealthController, path string) (int, HealthOutputDTO) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/healthz", controller.Liveness)
+	router.GET("/readyz", controller.Readiness)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var body HealthOutputDTO
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	return recorder.Code, body
+}
+
+func TestReadinessWhenHealthy(t *testing.T) {
+	controller := NewHealthController(map[string]HealthSource{
+		"auctions": &fakeHealthSource{},
+	})
+
+	code, body := probe(t, controller, "/readyz")
+	if code != http.StatusOK || body.Status != "ok" || body.Checks["auctions"] != "ok" {
+		t.Errorf("Expected a ready response, got %d %+v", code, body)
+	}
+}
+
+func TestReadinessWhenMongoIsDown(t *testing.T) {
+	controller := NewHealthController(map[string]HealthSource{
+		"auctions": &fakeHealthSource{err: errors.New("mongodb is unreachable: connection refused")},
+	})
+
+	code, body := probe(t, controller, "/readyz")
+	if code != http.StatusServiceUnavailable || body.Status != "unavailable" {
+		t.Errorf("Expected a 503 unavailable response, got %d %+v", code, body)
+	}
+	if body.Checks["auctions"] != "mongodb is unreachable: connection refused" {
+		t.Errorf("Expected the failure reason in the check, got %+v", body.Checks)
+	}
+
+	// A vivacidade não depende dos subsistemas
+	if code, body := probe(t, controller, "/healthz"); code != http.StatusOK || body.Status != "ok" {
+		t.Errorf("Expected liveness to stay ok, got %d %+v", code, body)
+	}
+}
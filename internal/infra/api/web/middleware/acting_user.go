@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"fullcycle-auction_go/configuration/logger"
+	"github.com/gin-gonic/gin"
+)
+
+const ActingUserHeader = "X-User-Id"
+
+// ActingUser leva para o contexto da requisição o usuário informado no header,
+// para que as operações administrativas sejam atribuídas a ele na auditoria
+func ActingUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userId := c.GetHeader(ActingUserHeader); userId != "" {
+			c.Request = c.Request.WithContext(
+				logger.WithActingUser(c.Request.Context(), userId))
+		}
+
+		c.Next()
+	}
+}
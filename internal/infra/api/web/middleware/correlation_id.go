@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"fullcycle-auction_go/configuration/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const CorrelationIdHeader = "X-Request-Id"
+
+func CorrelationId() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		correlationId := c.GetHeader(CorrelationIdHeader)
+		if correlationId == "" {
+			correlationId = uuid.New().String()
+		}
+
+		c.Request = c.Request.WithContext(
+			logger.WithCorrelationId(c.Request.Context(), correlationId))
+		c.Header(CorrelationIdHeader, correlationId)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,101 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// WithAtomicClose faz a varredura fechar cada leilão expirado com FindOneAndUpdate,
+// em vez de um UpdateMany por lote. É mais lento, mas os efeitos de cada fechamento
+// (histórico, eventos e métricas) só disparam para os leilões que esta instância fechou
+func WithAtomicClose(enabled bool) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.atomicClose = enabled
+	}
+}
+
+// closeBatchAtomically fecha os leilões do lote um a um, disparando os efeitos apenas
+// para os que foram de fato fechados aqui. Um leilão que falha ao fechar tem a falha
+// registrada e não impede os demais; a varredura é então interrompida com erro, e ele
+// volta a ser tentado na próxima
+func (ar *AuctionRepository) closeBatchAtomically(
+	ctx context.Context,
+	expiredAuctions []AuctionEntityMongo,
+	strategy string) ([]string, int64, *internal_error.InternalError) {
+	expiredIds := make([]string, 0, len(expiredAuctions))
+	closedAuctions := make([]AuctionEntityMongo, 0, len(expiredAuctions))
+	closedIds := make([]string, 0, len(expiredAuctions))
+
+	var closeErr *internal_error.InternalError
+	closedAt := ar.clock.Now()
+	for _, auction := range expiredAuctions {
+		expiredIds = append(expiredIds, auction.Id)
+
+		closedAuction, err := ar.closeAuctionAtomically(ctx, auction.Id, closedAt)
+		if err != nil {
+			ar.recordCloseFailure(ctx, auction.Id)
+			closeErr = err
+			continue
+		}
+		if closedAuction == nil {
+			continue
+		}
+
+		closedAuctions = append(closedAuctions, *closedAuction)
+		closedIds = append(closedIds, closedAuction.Id)
+	}
+
+	ar.observeCloseLatency(closedAuctions, closedAt, strategy)
+	ar.recordTransitions(ctx, closedIds,
+		statusPtr(auction_entity.Active), auction_entity.Completed, TransitionExpired)
+	ar.publishClosed(ctx, closedIds, TransitionExpired, closedAt)
+
+	return expiredIds, int64(len(closedIds)), closeErr
+}
+
+// closeAuctionAtomically fecha um leilão ativo e retorna o documento já fechado. O
+// status no filtro garante que apenas uma chamada o feche; as demais recebem nil. É
+// usado pela varredura e pelo fechamento por categoria
+func (ar *AuctionRepository) closeAuctionAtomically(
+	ctx context.Context, id string, closedAt time.Time) (*AuctionEntityMongo, *internal_error.InternalError) {
+	filter := bson.M{
+		"_id":    ar.DocumentId(id),
+		"status": auction_entity.Active,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       auction_entity.Completed,
+			"completed_at": closedAt,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	if ar.beforeAuctionClose != nil {
+		if err := ar.beforeAuctionClose(id); err != nil {
+			ar.logger.Error("Error trying to close expired auction", err, zap.String("auction_id", id))
+			return nil, internal_error.NewInternalServerError("Error trying to close expired auctions")
+		}
+	}
+
+	var closedAuction AuctionEntityMongo
+	err := ar.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&closedAuction)
+	ar.readCache.invalidate(id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		ar.logger.Error("Error trying to close expired auction", err, zap.String("auction_id", id))
+		return nil, internal_error.NewInternalServerError("Error trying to close expired auctions")
+	}
+
+	return &closedAuction, nil
+}
@@ -0,0 +1,138 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/testutil"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAtomicCloseSweepPublishesOnlyClosedAuctions(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	publisher := &recordingPublisher{mutex: &sync.Mutex{}}
+	repo := newTestRepository(t, db,
+		WithMonitorDisabled(true),
+		WithAtomicClose(true),
+		WithPublisher(publisher))
+	ctx := context.Background()
+
+	if _, err := testutil.SeedAuctions(ctx, repo, 3, testutil.MakeExpired); err != nil {
+		t.Fatalf("Failed to seed expired auctions: %v", err)
+	}
+
+	closed, err := repo.ProcessExpiredAuctionsOnce(ctx)
+	if err != nil {
+		t.Fatalf("Failed to process expired auctions: %v", err)
+	}
+	if closed != 3 {
+		t.Errorf("Expected 3 closed auctions, got %d", closed)
+	}
+	if len(publisher.events) != 3 {
+		t.Errorf("Expected 3 closed events, got %d", len(publisher.events))
+	}
+}
+
+func TestCloseAuctionAtomicallyClosesOnlyOnce(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	seeded, err := testutil.SeedAuctions(ctx, repo, 1, testutil.MakeExpired)
+	if err != nil {
+		t.Fatalf("Failed to seed expired auction: %v", err)
+	}
+
+	const workers = 10
+	results := make(chan *AuctionEntityMongo, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			closedAuction, err := repo.closeAuctionAtomically(ctx, seeded[0].Id, time.Now())
+			if err != nil {
+				t.Errorf("Failed to close auction: %v", err)
+			}
+			results <- closedAuction
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	var closedAuctions []*AuctionEntityMongo
+	for closedAuction := range results {
+		if closedAuction != nil {
+			closedAuctions = append(closedAuctions, closedAuction)
+		}
+	}
+
+	if len(closedAuctions) != 1 {
+		t.Fatalf("Expected exactly one close, got %d", len(closedAuctions))
+	}
+	closedAuction := closedAuctions[0]
+	if closedAuction.Status != auction_entity.Completed ||
+		closedAuction.CompletedAt == nil ||
+		closedAuction.Version != 1 {
+		t.Errorf("Expected the returned document to be closed, got %+v", closedAuction)
+	}
+}
+
+func TestCloseBatchSkipsAuctionsClosedElsewhere(t *testing.T) {
+	tests := []struct {
+		name      string
+		publisher *recordingPublisher
+	}{
+		{"without publisher", nil},
+		{"with publisher", &recordingPublisher{mutex: &sync.Mutex{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			opts := []AuctionRepositoryOption{WithMonitorDisabled(true)}
+			if tt.publisher != nil {
+				opts = append(opts, WithPublisher(tt.publisher))
+			}
+			repo := newTestRepository(t, db, opts...)
+			ctx := context.Background()
+
+			seeded, err := testutil.SeedAuctions(ctx, repo, 2, testutil.MakeExpired)
+			if err != nil {
+				t.Fatalf("Failed to seed expired auctions: %v", err)
+			}
+
+			// O lote foi lido antes de um fechamento manual do primeiro leilão
+			batch := []AuctionEntityMongo{{Id: seeded[0].Id}, {Id: seeded[1].Id}}
+			if err := repo.CloseAuction(ctx, seeded[0].Id); err != nil {
+				t.Fatalf("Failed to close auction: %v", err)
+			}
+
+			_, closed, closeErr := repo.closeBatch(ctx, batch, "test")
+			if closeErr != nil {
+				t.Fatalf("Failed to close batch: %v", closeErr)
+			}
+			if closed != 1 {
+				t.Errorf("Expected 1 auction closed by the batch, got %d", closed)
+			}
+
+			// Além da criação, cada leilão tem uma única transição para Completed
+			for _, auction := range seeded {
+				history, _ := repo.GetAuctionHistory(ctx, auction.Id)
+				if len(history) != 2 || history[1].ToStatus != auction_entity.Completed {
+					t.Errorf("Expected a single close transition for %s, got %+v", auction.Id, history)
+				}
+			}
+			if tt.publisher != nil && len(tt.publisher.events) != 1 {
+				t.Errorf("Expected 1 closed event, got %d", len(tt.publisher.events))
+			}
+		})
+	}
+}
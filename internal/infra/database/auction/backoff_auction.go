@@ -0,0 +1,38 @@
+package auction
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxMonitorBackoff limita o atraso entre ticks quando as varreduras falham seguidamente
+const maxMonitorBackoff = 5 * time.Minute
+
+// monitorBackoff dobra o intervalo do monitor a cada varredura que falhou em
+// sequência, até maxMonitorBackoff, para não insistir com um MongoDB indisponível
+func (ar *AuctionRepository) monitorBackoff() time.Duration {
+	backoff := ar.monitorInterval
+	for i := int64(0); i < ar.sweepFailures.Load() && backoff < maxMonitorBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxMonitorBackoff && ar.monitorInterval < maxMonitorBackoff {
+		return maxMonitorBackoff
+	}
+	return backoff
+}
+
+func (ar *AuctionRepository) recordSweepFailure() {
+	failures := ar.sweepFailures.Add(1)
+	ar.logger.Warn("Auction close sweep failed, backing off",
+		zap.Int64("consecutive_failures", failures),
+		zap.Duration("next_delay", ar.monitorBackoff()))
+}
+
+func (ar *AuctionRepository) recordSweepSuccess() {
+	if failures := ar.sweepFailures.Swap(0); failures > 0 {
+		ar.logger.Info("Auction close sweep recovered",
+			zap.Int64("consecutive_failures", failures))
+	}
+}
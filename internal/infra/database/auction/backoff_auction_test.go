@@ -0,0 +1,104 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMonitorBackoff(t *testing.T) {
+	repo := &AuctionRepository{monitorInterval: 10 * time.Second}
+
+	expected := []time.Duration{
+		10 * time.Second,
+		20 * time.Second,
+		40 * time.Second,
+		80 * time.Second,
+		160 * time.Second,
+		maxMonitorBackoff,
+		maxMonitorBackoff,
+	}
+	for failures, want := range expected {
+		repo.sweepFailures.Store(int64(failures))
+		if backoff := repo.monitorBackoff(); backoff != want {
+			t.Errorf("Expected backoff %v after %d failures, got %v", want, failures, backoff)
+		}
+	}
+}
+
+func TestMonitorStallWindowFollowsBackoffAndJitter(t *testing.T) {
+	repo := &AuctionRepository{monitorInterval: 10 * time.Second, monitorJitter: 0.5}
+
+	if window := repo.monitorStallWindow(); window != 45*time.Second {
+		t.Errorf("Expected a 45s window without failures, got %v", window)
+	}
+
+	// Com duas falhas o próximo tick pode demorar até 40s mais 50% de jitter
+	repo.sweepFailures.Store(2)
+	if window := repo.monitorStallWindow(); window != 180*time.Second {
+		t.Errorf("Expected a 180s window after two failures, got %v", window)
+	}
+}
+
+func TestCloseExpiredAuctionsBacksOffWhileMongoIsUnreachable(t *testing.T) {
+	// Um servidor inexistente faz toda operação falhar rapidamente
+	client, err := mongo.Connect(context.Background(), options.Client().
+		ApplyURI("mongodb://127.0.0.1:1").
+		SetServerSelectionTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Failed to create mongo client: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	repo := &AuctionRepository{
+		Collection:      client.Database("auctions_test").Collection("auctions"),
+		monitorInterval: 10 * time.Second,
+		lastTickMutex:   &sync.Mutex{},
+		statsMutex:      &sync.Mutex{},
+		clock:           realClock{},
+		logger:          logger.Default(),
+	}
+
+	for i := 1; i <= 3; i++ {
+		repo.handleTick(context.Background(), time.Minute)
+
+		want := repo.monitorInterval << i
+		if delay := repo.nextTickDelay(); delay != want {
+			t.Errorf("Expected delay %v after %d failed sweeps, got %v", want, i, delay)
+		}
+	}
+}
+
+func TestCloseExpiredAuctionsResetsBackoffOnSuccess(t *testing.T) {
+	// Intervalo longo para que o monitor não rode durante o teste
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+
+	// Um contexto cancelado simula falhas do MongoDB; a varredura é chamada
+	// diretamente porque o tick não varre com o contexto já cancelado
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	repo.closeExpiredAuctions(cancelled, repo.auctionDuration)
+	repo.closeExpiredAuctions(cancelled, repo.auctionDuration)
+	if delay := repo.nextTickDelay(); delay != repo.monitorInterval*4 {
+		t.Fatalf("Expected delay to grow to %v, got %v", repo.monitorInterval*4, delay)
+	}
+
+	repo.handleTick(context.Background(), repo.auctionDuration)
+	if delay := repo.nextTickDelay(); delay != repo.monitorInterval {
+		t.Errorf("Expected delay to reset to %v after a successful sweep, got %v",
+			repo.monitorInterval, delay)
+	}
+}
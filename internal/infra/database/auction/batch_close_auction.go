@@ -0,0 +1,28 @@
+package auction
+
+import (
+	"os"
+	"strconv"
+)
+
+// defaultCloseBatchSize é o número máximo de leilões fechados por UpdateMany
+const defaultCloseBatchSize = 1000
+
+// WithCloseBatchSize define quantos leilões expirados são fechados a cada lote
+func WithCloseBatchSize(size int64) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		if size > 0 {
+			ar.closeBatchSize = size
+		}
+	}
+}
+
+// getCloseBatchSize lê AUCTION_CLOSE_BATCH_SIZE. O padrão é defaultCloseBatchSize
+func getCloseBatchSize() int64 {
+	size, err := strconv.ParseInt(os.Getenv("AUCTION_CLOSE_BATCH_SIZE"), 10, 64)
+	if err != nil || size <= 0 {
+		return defaultCloseBatchSize
+	}
+
+	return size
+}
@@ -0,0 +1,78 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/event"
+	"fullcycle-auction_go/internal/testutil"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestSweepClosesExpiredAuctionsInBatches(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true), WithCloseBatchSize(2))
+	ctx := context.Background()
+
+	if _, err := testutil.SeedAuctions(ctx, repo, 5, testutil.MakeExpired); err != nil {
+		t.Fatalf("Failed to seed expired auctions: %v", err)
+	}
+
+	closed, err := repo.ProcessExpiredAuctionsOnce(ctx)
+	if err != nil {
+		t.Fatalf("Failed to process expired auctions: %v", err)
+	}
+	if closed != 5 {
+		t.Errorf("Expected 5 closed auctions across batches, got %d", closed)
+	}
+
+	active, _ := repo.Collection.CountDocuments(ctx, bson.M{"status": auction_entity.Active})
+	if active != 0 {
+		t.Errorf("Expected no active auctions left, got %d", active)
+	}
+}
+
+// cancellingPublisher cancela o contexto da varredura ao receber o primeiro evento,
+// simulando um encerramento no meio do fechamento em lotes
+type cancellingPublisher struct {
+	cancel context.CancelFunc
+}
+
+func (p cancellingPublisher) Publish(ctx context.Context, e event.Event) error {
+	p.cancel()
+	return nil
+}
+
+func TestSweepStopsBetweenBatchesWhenContextCancelled(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := newTestRepository(t, db,
+		WithMonitorDisabled(true),
+		WithCloseBatchSize(2),
+		WithPublisher(cancellingPublisher{cancel: cancel}))
+
+	if _, err := testutil.SeedAuctions(context.Background(), repo, 5, testutil.MakeExpired); err != nil {
+		t.Fatalf("Failed to seed expired auctions: %v", err)
+	}
+
+	closed, err := repo.ProcessExpiredAuctionsOnce(ctx)
+	if err != nil {
+		t.Fatalf("Expected an interrupted sweep to keep the closed batches, got %v", err)
+	}
+	if closed != 2 {
+		t.Errorf("Expected only the first batch to be closed, got %d", closed)
+	}
+
+	active, _ := repo.Collection.CountDocuments(context.Background(),
+		bson.M{"status": auction_entity.Active})
+	if active != 3 {
+		t.Errorf("Expected 3 auctions left for the next sweep, got %d", active)
+	}
+}
@@ -0,0 +1,59 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+// IsBiddable informa se o leilão aceita lances agora e, quando não aceita, o motivo
+// (auction_entity.NotBiddableNotStarted, NotBiddableCompleted ou NotBiddableEnded).
+// Leilões antigos, sem end_time, terminam pela duração da sua categoria
+func (ar *AuctionRepository) IsBiddable(
+	ctx context.Context, auctionId string) (bool, string, *internal_error.InternalError) {
+	_, biddable, reason, err := ar.BiddableAuction(ctx, auctionId)
+	return biddable, reason, err
+}
+
+// BiddableAuction aplica a mesma regra de IsBiddable e devolve também o leilão lido,
+// com o fim já preenchido, para quem precisa validar o lance contra ele. O leilão
+// retornado é uma cópia e pode ser alterado sem afetar o cache de leitura
+func (ar *AuctionRepository) BiddableAuction(
+	ctx context.Context, auctionId string) (*auction_entity.Auction, bool, string, *internal_error.InternalError) {
+	auction, err := ar.findAuctionWithEnd(ctx, auctionId)
+	if err != nil {
+		return nil, false, "", err
+	}
+
+	biddable, reason := auction.Biddable(ar.clock.Now())
+	return auction, biddable, reason, nil
+}
+
+// RemainingTime retorna quanto falta para o fim do leilão, já considerando as
+// prorrogações. Leilões encerrados ou vencidos retornam zero
+func (ar *AuctionRepository) RemainingTime(
+	ctx context.Context, auctionId string) (time.Duration, *internal_error.InternalError) {
+	auction, err := ar.findAuctionWithEnd(ctx, auctionId)
+	if err != nil {
+		return 0, err
+	}
+
+	return auction.RemainingTime(ar.clock.Now()), nil
+}
+
+// findAuctionWithEnd busca o leilão, sem o cache de leitura, preenchendo o fim dos
+// leilões antigos, sem end_time, com a duração da sua categoria
+func (ar *AuctionRepository) findAuctionWithEnd(
+	ctx context.Context, auctionId string) (*auction_entity.Auction, *internal_error.InternalError) {
+	auction, err := ar.FindAuctionByIdUncached(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.EndsAt.IsZero() {
+		auction.EndsAt = auction.Timestamp.Add(ar.categoryDuration(auction.Category))
+	}
+
+	return auction, nil
+}
@@ -0,0 +1,110 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestIsBiddable(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock), WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	active, _ := auction_entity.CreateAuction(
+		"Test Product", "Electronics", "A test product for auction", auction_entity.New,
+		auction_entity.WithDuration(time.Hour))
+	scheduled, _ := auction_entity.CreateAuction(
+		"Test Product", "Electronics", "A test product for auction", auction_entity.New,
+		auction_entity.WithStartsAt(clock.Now().Add(time.Hour)))
+	completed, _ := auction_entity.CreateAuction(
+		"Test Product", "Electronics", "A test product for auction", auction_entity.New)
+	for _, auction := range []*auction_entity.Auction{active, scheduled, completed} {
+		if err := repo.CreateAuction(ctx, auction); err != nil {
+			t.Fatalf("Failed to create auction: %v", err)
+		}
+	}
+	if err := repo.CloseAuction(ctx, completed.Id); err != nil {
+		t.Fatalf("Failed to close auction: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		auctionId string
+		biddable  bool
+		reason    string
+	}{
+		{"active", active.Id, true, ""},
+		{"not started", scheduled.Id, false, auction_entity.NotBiddableNotStarted},
+		{"completed", completed.Id, false, auction_entity.NotBiddableCompleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			biddable, reason, err := repo.IsBiddable(ctx, tt.auctionId)
+			if err != nil {
+				t.Fatalf("Failed to check auction: %v", err)
+			}
+			if biddable != tt.biddable || reason != tt.reason {
+				t.Errorf("Expected (%t, %q), got (%t, %q)", tt.biddable, tt.reason, biddable, reason)
+			}
+		})
+	}
+
+	clock.Advance(2 * time.Hour)
+	if biddable, reason, _ := repo.IsBiddable(ctx, active.Id); biddable || reason != auction_entity.NotBiddableEnded {
+		t.Errorf("Expected an expired auction not to be biddable, got (%t, %q)", biddable, reason)
+	}
+
+	if _, _, err := repo.IsBiddable(ctx, uuid.NewString()); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for a missing auction, got %v", err)
+	}
+}
+
+func TestRemainingTime(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock), WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	active, _ := auction_entity.CreateAuction(
+		"Test Product", "Electronics", "A test product for auction", auction_entity.New,
+		auction_entity.WithDuration(time.Hour))
+	completed, _ := auction_entity.CreateAuction(
+		"Test Product", "Electronics", "A test product for auction", auction_entity.New,
+		auction_entity.WithDuration(time.Hour))
+	for _, auction := range []*auction_entity.Auction{active, completed} {
+		if err := repo.CreateAuction(ctx, auction); err != nil {
+			t.Fatalf("Failed to create auction: %v", err)
+		}
+	}
+	if err := repo.CloseAuction(ctx, completed.Id); err != nil {
+		t.Fatalf("Failed to close auction: %v", err)
+	}
+
+	clock.Advance(15 * time.Minute)
+	remaining, err := repo.RemainingTime(ctx, active.Id)
+	if err != nil {
+		t.Fatalf("Failed to get remaining time: %v", err)
+	}
+	// end_time é guardado em segundos
+	expected := active.EndsAt.Truncate(time.Second).Sub(clock.Now())
+	if remaining != expected {
+		t.Errorf("Expected %s remaining, got %s", expected, remaining)
+	}
+
+	if remaining, _ := repo.RemainingTime(ctx, completed.Id); remaining != 0 {
+		t.Errorf("Expected a completed auction to have no time left, got %s", remaining)
+	}
+
+	if _, err := repo.RemainingTime(ctx, uuid.NewString()); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for a missing auction, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CategoryStat resume os leilões de uma categoria. AverageHighBid é a média do maior
+// lance entre os leilões que já receberam lances
+type CategoryStat struct {
+	Category       string               `json:"category" bson:"_id"`
+	Count          int64                `json:"count" bson:"count"`
+	ActiveCount    int64                `json:"active_count" bson:"active_count"`
+	AverageHighBid auction_entity.Money `json:"average_high_bid" bson:"-"`
+}
+
+// WithCategoriesCacheTTL define por quanto tempo a lista de categorias fica em cache.
+// 0 desliga o cache
+func WithCategoriesCacheTTL(ttl time.Duration) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.categoriesTTL = ttl
+	}
+}
+
+// getCategoriesCacheTTL lê AUCTION_CATEGORIES_CACHE_TTL. O padrão é 1 minuto
+func getCategoriesCacheTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("AUCTION_CATEGORIES_CACHE_TTL"))
+	if err != nil || ttl < 0 {
+		return time.Minute
+	}
+
+	return ttl
+}
+
+// ListCategories retorna as categorias distintas dos leilões, em ordem alfabética.
+// Como as categorias mudam pouco, o resultado fica em cache por categoriesTTL e é
+// recarregado na primeira consulta depois de expirar
+func (ar *AuctionRepository) ListCategories(ctx context.Context) ([]string, *internal_error.InternalError) {
+	ar.categoriesMutex.Lock()
+	defer ar.categoriesMutex.Unlock()
+
+	now := ar.clock.Now()
+	if ar.categories != nil && now.Sub(ar.categoriesCachedAt) < ar.categoriesTTL {
+		return append([]string{}, ar.categories...), nil
+	}
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	values, err := ar.Collection.Distinct(ctx, "category", bson.M{})
+	if err != nil {
+		ar.logger.Error("Error trying to list auction categories", err)
+		return nil, readError(err, "Error trying to list auction categories")
+	}
+
+	categories := make([]string, 0, len(values))
+	for _, value := range values {
+		if category, ok := value.(string); ok && category != "" {
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+
+	ar.categories = categories
+	ar.categoriesCachedAt = now
+
+	return append([]string{}, categories...), nil
+}
+
+// CategoryStats agrega, por categoria, o total de leilões, os ativos e a média do maior
+// lance, em ordem alfabética. A agregação percorre a coleção inteira; a projeção inicial
+// limita o que é lido de cada documento aos três campos usados no agrupamento
+func (ar *AuctionRepository) CategoryStats(ctx context.Context) ([]CategoryStat, *internal_error.InternalError) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$project", Value: bson.M{"_id": 0, "category": 1, "status": 1, "current_high_bid": 1}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$category",
+			"count": bson.M{"$sum": 1},
+			"active_count": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$eq": bson.A{"$status", auction_entity.Active}}, 1, 0},
+			}},
+			// $avg ignora null, então leilões sem lances ficam fora da média
+			"average_high_bid": bson.M{"$avg": bson.M{
+				"$cond": bson.A{bson.M{"$gt": bson.A{"$current_high_bid", 0}}, "$current_high_bid", nil},
+			}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	cursor, err := ar.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		ar.logger.Error("Error trying to aggregate category stats", err)
+		return nil, readError(err, "Error trying to aggregate category stats")
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		CategoryStat   `bson:",inline"`
+		AverageHighBid *float64 `bson:"average_high_bid"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		ar.logger.Error("Error trying to decode category stats", err)
+		return nil, readError(err, "Error trying to decode category stats")
+	}
+
+	stats := make([]CategoryStat, 0, len(results))
+	for _, result := range results {
+		stat := result.CategoryStat
+		if result.AverageHighBid != nil {
+			stat.AverageHighBid = auction_entity.MoneyFromCents(int64(math.Round(*result.AverageHighBid)))
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
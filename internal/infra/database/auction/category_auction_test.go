@@ -0,0 +1,83 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/testutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestListCategoriesReturnsDistinctSortedCategories(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock), WithMonitorDisabled(true),
+		WithCategoriesCacheTTL(time.Minute))
+	ctx := context.Background()
+
+	for _, category := range []string{"Music", "Electronics", "Music", "Books"} {
+		if _, err := testutil.SeedAuctions(ctx, repo, 1, testutil.WithCategory(category)); err != nil {
+			t.Fatalf("Failed to seed auction: %v", err)
+		}
+	}
+
+	categories, err := repo.ListCategories(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list categories: %v", err)
+	}
+	expected := []string{"Books", "Electronics", "Music"}
+	if !reflect.DeepEqual(categories, expected) {
+		t.Errorf("Expected categories %v, got %v", expected, categories)
+	}
+
+	// Dentro do TTL a lista vem do cache, mesmo com uma categoria nova
+	testutil.SeedAuctions(ctx, repo, 1, testutil.WithCategory("Art"))
+	categories, _ = repo.ListCategories(ctx)
+	if !reflect.DeepEqual(categories, expected) {
+		t.Errorf("Expected cached categories %v, got %v", expected, categories)
+	}
+
+	clock.Advance(time.Minute)
+	categories, _ = repo.ListCategories(ctx)
+	expected = []string{"Art", "Books", "Electronics", "Music"}
+	if !reflect.DeepEqual(categories, expected) {
+		t.Errorf("Expected refreshed categories %v, got %v", expected, categories)
+	}
+}
+
+func TestCategoryStats(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	music, err := testutil.SeedAuctions(ctx, repo, 3, testutil.WithCategory("Music"))
+	if err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+	if _, err := testutil.SeedAuctions(ctx, repo, 2, testutil.WithCategory("Books")); err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	if err := repo.CloseAuction(ctx, music[0].Id); err != nil {
+		t.Fatalf("Failed to close auction: %v", err)
+	}
+	repo.RecordBid(ctx, music[1].Id, 1000)
+	repo.RecordBid(ctx, music[2].Id, 2001)
+
+	stats, statsErr := repo.CategoryStats(ctx)
+	if statsErr != nil {
+		t.Fatalf("Failed to aggregate category stats: %v", statsErr)
+	}
+
+	expected := []CategoryStat{
+		{Category: "Books", Count: 2, ActiveCount: 2},
+		{Category: "Music", Count: 3, ActiveCount: 2, AverageHighBid: 1501},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("Expected stats %+v, got %+v", expected, stats)
+	}
+}
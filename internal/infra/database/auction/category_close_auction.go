@@ -0,0 +1,111 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/event"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const AuctionClosedEvent = "auction.closed"
+
+type AuctionClosedPayload struct {
+	AuctionId   string    `json:"auction_id" bson:"auction_id"`
+	Reason      string    `json:"reason" bson:"reason"`
+	CompletedAt time.Time `json:"completed_at" bson:"completed_at"`
+}
+
+// WithPublisher faz o repositório emitir eventos de fechamento de leilões. Envolva um
+// broker com event.NewRetryingPublisher para que falhas passageiras sejam tentadas de
+// novo antes de o evento ir para o outbox
+func WithPublisher(publisher event.Publisher) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.publisher = publisher
+	}
+}
+
+// CloseAuctionsByCategory encerra todos os leilões ativos de uma categoria, para
+// limpezas administrativas, e retorna quantos foram encerrados
+func (ar *AuctionRepository) CloseAuctionsByCategory(
+	ctx context.Context, category string) (int64, *internal_error.InternalError) {
+	if category == "" {
+		return 0, internal_error.NewBadRequestError("category must not be empty")
+	}
+
+	// Os IDs são buscados antes para que cada leilão encerrado gere seu evento
+	filter := bson.M{"category": category, "status": auction_entity.Active}
+	cursor, err := ar.Collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		ar.logger.Error("Error trying to find auctions by category", err, zap.String("category", category))
+		return 0, internal_error.NewInternalServerError("Error trying to find auctions by category")
+	}
+	defer cursor.Close(ctx)
+
+	var auctions []AuctionEntityMongo
+	if err := cursor.All(ctx, &auctions); err != nil {
+		ar.logger.Error("Error decoding auctions by category", err, zap.String("category", category))
+		return 0, internal_error.NewInternalServerError("Error decoding auctions by category")
+	}
+	if len(auctions) == 0 {
+		return 0, nil
+	}
+
+	// Cada leilão é fechado com FindOneAndUpdate: um leilão que a varredura fechou
+	// depois da busca não recebe uma segunda transição nem um segundo evento
+	completedAt := ar.clock.Now()
+	closedIds := make([]string, 0, len(auctions))
+	var closeErr *internal_error.InternalError
+	for _, auction := range auctions {
+		closedAuction, err := ar.closeAuctionAtomically(ctx, auction.Id, completedAt)
+		if err != nil {
+			closeErr = internal_error.NewInternalServerError("Error trying to close auctions by category")
+			continue
+		}
+		if closedAuction != nil {
+			closedIds = append(closedIds, closedAuction.Id)
+		}
+	}
+
+	ar.recordTransitions(ctx, closedIds,
+		statusPtr(auction_entity.Active), auction_entity.Completed, TransitionCategoryClose)
+	ar.publishClosed(ctx, closedIds, TransitionCategoryClose, completedAt)
+
+	ar.logger.Info("Closed auctions by category", logger.ContextFields(ctx,
+		zap.String("category", category),
+		zap.Int("modified_count", len(closedIds)))...)
+
+	if closeErr != nil {
+		return int64(len(closedIds)), closeErr
+	}
+
+	return int64(len(closedIds)), nil
+}
+
+// publishesEvents informa se o repositório tem para onde emitir eventos
+func (ar *AuctionRepository) publishesEvents() bool {
+	return ar.publisher != nil || ar.eventStoreEnabled
+}
+
+// publishClosed emite um evento por leilão encerrado quando há publisher ou event store.
+// Deve ser chamado após a escrita no MongoDB: falhas de publicação não desfazem o fechamento
+func (ar *AuctionRepository) publishClosed(
+	ctx context.Context, auctionIds []string, reason string, completedAt time.Time) {
+	if !ar.publishesEvents() {
+		return
+	}
+
+	for _, auctionId := range auctionIds {
+		closedEvent := event.NewEvent(AuctionClosedEvent, AuctionClosedPayload{
+			AuctionId:   auctionId,
+			Reason:      reason,
+			CompletedAt: completedAt.UTC(),
+		})
+		ar.publish(ctx, closedEvent)
+	}
+}
@@ -0,0 +1,126 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/event"
+	"sync"
+	"testing"
+)
+
+type recordingPublisher struct {
+	mutex  *sync.Mutex
+	events []event.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, e event.Event) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.events = append(p.events, e)
+	return nil
+}
+
+func TestCloseAuctionsByCategory(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	publisher := &recordingPublisher{mutex: &sync.Mutex{}}
+	repo := newTestRepository(t, db, WithPublisher(publisher))
+	ctx := context.Background()
+
+	categories := map[string]string{}
+	for _, category := range []string{"Electronics", "Electronics", "Music", "Books"} {
+		auction, _ := auction_entity.CreateAuction(
+			"Test Product",
+			category,
+			"A test product for auction",
+			auction_entity.New,
+		)
+		repo.CreateAuction(ctx, auction)
+		categories[auction.Id] = category
+	}
+
+	closed, err := repo.CloseAuctionsByCategory(ctx, "Electronics")
+	if err != nil {
+		t.Fatalf("Failed to close auctions by category: %v", err)
+	}
+	if closed != 2 {
+		t.Errorf("Expected 2 auctions closed, got %d", closed)
+	}
+
+	for id, category := range categories {
+		found, _ := repo.FindAuctionById(ctx, id)
+		expected := auction_entity.Active
+		if category == "Electronics" {
+			expected = auction_entity.Completed
+		}
+		if found.Status != expected {
+			t.Errorf("Expected %s auction to be %s, got %s", category, expected, found.Status)
+		}
+	}
+
+	if len(publisher.events) != 2 {
+		t.Fatalf("Expected 2 close events, got %d", len(publisher.events))
+	}
+	for _, published := range publisher.events {
+		payload := published.Payload.(AuctionClosedPayload)
+		if published.Name != AuctionClosedEvent || categories[payload.AuctionId] != "Electronics" {
+			t.Errorf("Unexpected close event: %+v", published)
+		}
+	}
+}
+
+func TestCloseAuctionsByCategorySkipsAuctionsClosedConcurrently(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	publisher := &recordingPublisher{mutex: &sync.Mutex{}}
+	repo := newTestRepository(t, db, WithMonitorDisabled(true), WithPublisher(publisher))
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		auction, _ := auction_entity.CreateAuction(
+			"Test Product", "Electronics", "A test product for auction", auction_entity.New)
+		repo.CreateAuction(ctx, auction)
+		ids = append(ids, auction.Id)
+	}
+
+	// Outro fechamento encerra o primeiro leilão depois da busca por categoria
+	raced := ids[0]
+	repo.beforeAuctionClose = func(id string) error {
+		if id == raced {
+			repo.CloseAuction(ctx, id)
+		}
+		return nil
+	}
+
+	closed, err := repo.CloseAuctionsByCategory(ctx, "Electronics")
+	if err != nil {
+		t.Fatalf("Failed to close auctions by category: %v", err)
+	}
+	if closed != 1 {
+		t.Errorf("Expected 1 auction closed by category, got %d", closed)
+	}
+
+	if len(publisher.events) != 1 ||
+		publisher.events[0].Payload.(AuctionClosedPayload).AuctionId != ids[1] {
+		t.Errorf("Expected a single close event for %s, got %+v", ids[1], publisher.events)
+	}
+
+	history, _ := repo.GetAuctionHistory(ctx, raced)
+	for _, transition := range history {
+		if transition.Reason == TransitionCategoryClose {
+			t.Errorf("Expected no category close transition for %s, got %+v", raced, history)
+		}
+	}
+}
+
+func TestCloseAuctionsByCategoryRejectsEmptyCategory(t *testing.T) {
+	repo := &AuctionRepository{}
+
+	if _, err := repo.CloseAuctionsByCategory(context.Background(), ""); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for an empty category, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package auction
+
+import (
+	"encoding/json"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// WithCategoryDurations define durações de leilão por categoria, que substituem a
+// duração global para leilões sem duração própria
+func WithCategoryDurations(durations map[string]time.Duration) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.categoryDurations = durations
+	}
+}
+
+// getCategoryDurations lê AUCTION_DURATIONS_BY_CATEGORY, um objeto JSON de categoria
+// para duração (ex.: {"Perishables": "2m"}). Um JSON inválido desativa as durações por
+// categoria, e entradas com duração inválida ou acima de AUCTION_MAX_DURATION são
+// ignoradas individualmente
+func getCategoryDurations() map[string]time.Duration {
+	value := os.Getenv("AUCTION_DURATIONS_BY_CATEGORY")
+	if value == "" {
+		return nil
+	}
+
+	var entries map[string]string
+	if err := json.Unmarshal([]byte(value), &entries); err != nil {
+		logger.Error("Invalid AUCTION_DURATIONS_BY_CATEGORY, using the global auction duration", err)
+		return nil
+	}
+
+	durations := make(map[string]time.Duration, len(entries))
+	for category, rawDuration := range entries {
+		category = strings.TrimSpace(category)
+		duration, err := time.ParseDuration(rawDuration)
+		if category == "" || err != nil || duration <= 0 || duration > auction_entity.MaxDuration() {
+			logger.Warn("Ignoring invalid category auction duration",
+				zap.String("category", category),
+				zap.String("duration", rawDuration))
+			continue
+		}
+
+		durations[category] = duration
+	}
+
+	return durations
+}
+
+// categoryDuration retorna a duração configurada para a categoria, ou a duração global
+func (ar *AuctionRepository) categoryDuration(category string) time.Duration {
+	if duration, ok := ar.categoryDurations[category]; ok {
+		return duration
+	}
+
+	return ar.auctionDuration
+}
+
+// shortestDuration retorna a menor duração entre a global e as por categoria, usada
+// para que o monitor verifique leilões curtos com frequência suficiente
+func shortestDuration(global time.Duration, byCategory map[string]time.Duration) time.Duration {
+	shortest := global
+	for _, duration := range byCategory {
+		shortest = min(shortest, duration)
+	}
+
+	return shortest
+}
@@ -0,0 +1,68 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGetCategoryDurations(t *testing.T) {
+	os.Setenv("AUCTION_DURATIONS_BY_CATEGORY", `{"Perishables": "2m", "Art": "forever", "Music": "-1m", "Land": "8760h"}`)
+	defer os.Unsetenv("AUCTION_DURATIONS_BY_CATEGORY")
+
+	durations := getCategoryDurations()
+	if len(durations) != 1 || durations["Perishables"] != 2*time.Minute {
+		t.Errorf("Expected only the valid Perishables entry, got %v", durations)
+	}
+}
+
+func TestGetCategoryDurationsIgnoresMalformedJSON(t *testing.T) {
+	os.Setenv("AUCTION_DURATIONS_BY_CATEGORY", `{"Perishables": "2m"`)
+	defer os.Unsetenv("AUCTION_DURATIONS_BY_CATEGORY")
+
+	if durations := getCategoryDurations(); len(durations) != 0 {
+		t.Errorf("Expected no category durations for malformed JSON, got %v", durations)
+	}
+
+	repo := &AuctionRepository{auctionDuration: 10 * time.Minute, categoryDurations: getCategoryDurations()}
+	if duration := repo.categoryDuration("Perishables"); duration != 10*time.Minute {
+		t.Errorf("Expected fallback to the global duration, got %v", duration)
+	}
+}
+
+func TestCategoryDurationClosesAuctionBeforeDefault(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock),
+		WithCategoryDurations(map[string]time.Duration{"Perishables": 2 * time.Minute}))
+	ctx := context.Background()
+
+	perishable, _ := auction_entity.CreateAuction(
+		"Fresh Fruit", "Perishables", "A box of fresh fruit for auction", auction_entity.New)
+	electronics, _ := auction_entity.CreateAuction(
+		"Test Product", "Electronics", "A test product for auction", auction_entity.New)
+	for _, auction := range []*auction_entity.Auction{perishable, electronics} {
+		auction.Timestamp = clock.Now()
+		if err := repo.CreateAuction(ctx, auction); err != nil {
+			t.Fatalf("Failed to create auction: %v", err)
+		}
+	}
+
+	clock.Advance(3 * time.Minute)
+	ids := repo.closeExpiredAuctions(ctx, repo.auctionDuration)
+	if len(ids) != 1 || ids[0] != perishable.Id {
+		t.Fatalf("Expected only the perishable auction to close, closed %v", ids)
+	}
+
+	clock.Advance(10 * time.Minute)
+	if ids := repo.closeExpiredAuctions(ctx, repo.auctionDuration); len(ids) != 1 || ids[0] != electronics.Id {
+		t.Errorf("Expected the electronics auction to close with the default duration, closed %v", ids)
+	}
+}
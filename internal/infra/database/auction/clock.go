@@ -0,0 +1,28 @@
+package auction
+
+import "time"
+
+// Clock abstrai a hora atual para que a lógica de expiração possa ser testada
+// de forma determinística. O relógio real retorna sempre UTC
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now().UTC()
+}
+
+// WithClock define o relógio usado pelo repositório (o padrão é o relógio real)
+func WithClock(clock Clock) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.clock = clock
+	}
+}
+
+// Now retorna a hora atual pelo relógio do repositório, para que quem decide sobre
+// os leilões dele, como o repositório de lances, use a mesma hora
+func (ar *AuctionRepository) Now() time.Time {
+	return ar.clock.Now()
+}
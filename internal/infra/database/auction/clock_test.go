@@ -0,0 +1,128 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// fakeClock é um relógio avançado manualmente nos testes
+type fakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (fc *fakeClock) Now() time.Time {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	return fc.now
+}
+
+func (fc *fakeClock) Advance(d time.Duration) {
+	fc.mutex.Lock()
+	fc.now = fc.now.Add(d)
+	fc.mutex.Unlock()
+}
+
+func TestCloseExpiredAuctionsWithFakeClock(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	auction.Timestamp = clock.Now()
+	repo.CreateAuction(ctx, auction)
+
+	// Antes do fim do leilão nada é fechado
+	clock.Advance(9 * time.Minute)
+	repo.handleTick(ctx, 10*time.Minute)
+
+	var auctionMongo AuctionEntityMongo
+	repo.Collection.FindOne(ctx, bson.M{"_id": auction.Id}).Decode(&auctionMongo)
+	if auctionMongo.Status != auction_entity.Active {
+		t.Fatalf("Expected auction to remain Active before expiry, got %d", auctionMongo.Status)
+	}
+
+	// Avança o relógio além do fim do leilão, sem sleep
+	clock.Advance(2 * time.Minute)
+	repo.handleTick(ctx, 10*time.Minute)
+
+	repo.Collection.FindOne(ctx, bson.M{"_id": auction.Id}).Decode(&auctionMongo)
+	if auctionMongo.Status != auction_entity.Completed {
+		t.Errorf("Expected auction to be Completed after expiry, got %d", auctionMongo.Status)
+	}
+
+	if !repo.LastTickAt().Equal(clock.Now()) {
+		t.Errorf("Expected last tick at %v, got %v", clock.Now(), repo.LastTickAt())
+	}
+}
+
+func TestAuctionExpiresAtTheSameInstantRegardlessOfTimeZone(t *testing.T) {
+	// O servidor roda em São Paulo e o leilão é criado com um horário de Tóquio
+	t.Setenv("TZ", "America/Sao_Paulo")
+	local := time.Local
+	time.Local = time.FixedZone("BRT", -3*60*60)
+	defer func() { time.Local = local }()
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	createdAt := time.Date(2024, 6, 1, 8, 30, 0, 0, tokyo)
+	clock := newFakeClock(createdAt.In(time.Local))
+	repo := newTestRepository(t, db, WithClock(clock), WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+		auction_entity.WithDuration(10*time.Minute),
+	)
+	auction.Timestamp = createdAt
+	repo.CreateAuction(ctx, auction)
+
+	expectedEnd := time.Date(2024, 5, 31, 23, 40, 0, 0, time.UTC)
+	found, err := repo.FindAuctionById(ctx, auction.Id)
+	if err != nil {
+		t.Fatalf("Failed to find auction: %v", err)
+	}
+	if !found.EndsAt.Equal(expectedEnd) || found.EndsAt.Location() != time.UTC {
+		t.Errorf("Expected the auction to end at %v, got %v", expectedEnd, found.EndsAt)
+	}
+	if found.Timestamp.Location() != time.UTC || !found.Timestamp.Equal(createdAt) {
+		t.Errorf("Expected the creation instant %v in UTC, got %v", createdAt, found.Timestamp)
+	}
+
+	clock.Advance(10*time.Minute - time.Second)
+	if closed, _ := repo.ProcessExpiredAuctionsOnce(ctx); closed != 0 {
+		t.Fatalf("Expected no auction to close a second before expiry, closed %d", closed)
+	}
+
+	clock.Advance(time.Second)
+	if closed, _ := repo.ProcessExpiredAuctionsOnce(ctx); closed != 1 {
+		t.Errorf("Expected the auction to close at its absolute expiry, closed %d", closed)
+	}
+}
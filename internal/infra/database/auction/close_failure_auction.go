@@ -0,0 +1,61 @@
+package auction
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// WithCloseFailureThreshold define a partir de quantas falhas de fechamento um leilão
+// é reportado como preso. Zero desliga o alerta
+func WithCloseFailureThreshold(threshold int64) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		if threshold >= 0 {
+			ar.closeFailureThreshold = threshold
+		}
+	}
+}
+
+// getCloseFailureThreshold lê AUCTION_CLOSE_FAILURE_THRESHOLD. O padrão é 3
+func getCloseFailureThreshold() int64 {
+	threshold, err := strconv.ParseInt(os.Getenv("AUCTION_CLOSE_FAILURE_THRESHOLD"), 10, 64)
+	if err != nil || threshold < 0 {
+		return 3
+	}
+
+	return threshold
+}
+
+// recordCloseFailure soma uma tentativa falha em failed_close_attempts do leilão, que
+// continua ativo e volta a ser fechado na próxima varredura. Ao atingir o limite, o
+// leilão é reportado como preso. O contador é auxiliar: falhas ao gravá-lo vão para o log
+func (ar *AuctionRepository) recordCloseFailure(ctx context.Context, id string) {
+	closeFailures.Inc()
+
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.M{"failed_close_attempts": 1})
+
+	var auction AuctionEntityMongo
+	err := ar.Collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": ar.DocumentId(id)},
+		bson.M{"$inc": bson.M{"failed_close_attempts": 1}},
+		opts).Decode(&auction)
+	ar.readCache.invalidate(id)
+	if err != nil {
+		ar.logger.Warn("Error trying to record failed close attempt",
+			zap.String("auction_id", id),
+			zap.Error(err))
+		return
+	}
+
+	if ar.closeFailureThreshold > 0 && auction.FailedCloseAttempts >= ar.closeFailureThreshold {
+		ar.logger.Error("Auction keeps failing to close", nil,
+			zap.String("auction_id", id),
+			zap.Int64("failed_close_attempts", auction.FailedCloseAttempts))
+	}
+}
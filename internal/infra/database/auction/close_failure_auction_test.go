@@ -0,0 +1,69 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/testutil"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFailedCloseIsCountedAndRetriedNextSweep(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	capturing := newCapturingLogger()
+	repo := newTestRepository(t, db,
+		WithMonitorDisabled(true),
+		WithAtomicClose(true),
+		WithCloseFailureThreshold(2),
+		WithLogger(capturing))
+	ctx := context.Background()
+
+	seeded, err := testutil.SeedAuctions(ctx, repo, 3, testutil.MakeExpired)
+	if err != nil {
+		t.Fatalf("Failed to seed expired auctions: %v", err)
+	}
+
+	stuckId := seeded[0].Id
+	repo.beforeAuctionClose = func(id string) error {
+		if id == stuckId {
+			return errors.New("connection reset")
+		}
+		return nil
+	}
+
+	for sweep := 1; sweep <= 2; sweep++ {
+		closed, err := repo.ProcessExpiredAuctionsOnce(ctx)
+		if err == nil {
+			t.Errorf("Expected sweep %d to report the failed close", sweep)
+		}
+		if expected := int64(2); sweep == 1 && closed != expected {
+			t.Errorf("Expected the other %d auctions to be closed, got %d", expected, closed)
+		}
+
+		var stuck AuctionEntityMongo
+		repo.Collection.FindOne(ctx, bson.M{"_id": stuckId}).Decode(&stuck)
+		if stuck.Status != auction_entity.Active || stuck.FailedCloseAttempts != int64(sweep) {
+			t.Fatalf("Expected an active auction with %d failed attempts, got %s with %d",
+				sweep, stuck.Status, stuck.FailedCloseAttempts)
+		}
+	}
+
+	if _, ok := capturing.find("Auction keeps failing to close"); !ok {
+		t.Error("Expected the stuck auction to be reported after reaching the threshold")
+	}
+
+	repo.beforeAuctionClose = nil
+	closed, err := repo.ProcessExpiredAuctionsOnce(ctx)
+	if err != nil || closed != 1 {
+		t.Fatalf("Expected the stuck auction to be closed on retry, got %d (%v)", closed, err)
+	}
+
+	retried, _ := repo.FindAuctionById(ctx, stuckId)
+	if retried.Status != auction_entity.Completed {
+		t.Errorf("Expected the retried auction to be Completed, got %s", retried.Status)
+	}
+}
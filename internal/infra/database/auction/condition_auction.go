@@ -0,0 +1,60 @@
+package auction
+
+import (
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+// WithDefaultCondition define a condição aplicada a leilões criados sem condição
+func WithDefaultCondition(condition auction_entity.ProductCondition) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.defaultCondition = condition
+	}
+}
+
+// getDefaultCondition lê AUCTION_DEFAULT_CONDITION (nome ou número da condição).
+// Sem a variável não há padrão e leilões sem condição são rejeitados; um valor
+// inválido é registrado e ignorado na inicialização
+func getDefaultCondition() auction_entity.ProductCondition {
+	value := os.Getenv("AUCTION_DEFAULT_CONDITION")
+	if value == "" {
+		return auction_entity.Unspecified
+	}
+
+	condition, err := auction_entity.ParseProductCondition(value)
+	if err != nil {
+		logger.Error("Invalid AUCTION_DEFAULT_CONDITION, ignoring it", err,
+			zap.String("value", value))
+		return auction_entity.Unspecified
+	}
+
+	return condition
+}
+
+// conditionOrDefault substitui uma condição não informada pela condição padrão
+func (ar *AuctionRepository) conditionOrDefault(
+	condition auction_entity.ProductCondition) auction_entity.ProductCondition {
+	if condition == auction_entity.Unspecified {
+		return ar.defaultCondition
+	}
+
+	return condition
+}
+
+// applyDefaultCondition preenche a condição do leilão com o padrão configurado,
+// rejeitando o leilão quando ela não foi informada e não há padrão
+func (ar *AuctionRepository) applyDefaultCondition(
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	auctionEntity.Condition = ar.conditionOrDefault(auctionEntity.Condition)
+	if auctionEntity.Condition == auction_entity.Unspecified {
+		return internal_error.NewValidationError("invalid auction object", []internal_error.FieldError{
+			{Field: "condition", Message: "product condition is required"},
+		})
+	}
+
+	return nil
+}
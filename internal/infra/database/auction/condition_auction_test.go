@@ -0,0 +1,73 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"testing"
+)
+
+func TestCreateAuctionAppliesDefaultCondition(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db,
+		WithMonitorDisabled(true),
+		WithDefaultCondition(auction_entity.Used))
+	ctx := context.Background()
+
+	unspecified, err := auction_entity.CreateAuction(
+		"Notebook", "Electronics", "Notebook Dell Inspiron 15", auction_entity.Unspecified)
+	if err != nil {
+		t.Fatalf("Expected an auction without condition to be valid, got %v", err)
+	}
+	explicit, _ := auction_entity.CreateAuction(
+		"Guitar", "Music", "Fender Stratocaster 2010", auction_entity.Refurbished)
+
+	for _, auction := range []*auction_entity.Auction{unspecified, explicit} {
+		if err := repo.CreateAuction(ctx, auction); err != nil {
+			t.Fatalf("Failed to create auction: %v", err)
+		}
+	}
+
+	found, _ := repo.FindAuctionById(ctx, unspecified.Id)
+	if found == nil || found.Condition != auction_entity.Used {
+		t.Errorf("Expected the default condition Used, got %+v", found)
+	}
+
+	found, _ = repo.FindAuctionById(ctx, explicit.Id)
+	if found == nil || found.Condition != auction_entity.Refurbished {
+		t.Errorf("Expected the explicit condition to be preserved, got %+v", found)
+	}
+}
+
+func TestCreateAuctionRejectsUnspecifiedConditionWithoutDefault(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+
+	auction, _ := auction_entity.CreateAuction(
+		"Notebook", "Electronics", "Notebook Dell Inspiron 15", auction_entity.Unspecified)
+	err := repo.CreateAuction(context.Background(), auction)
+	if err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request without a default condition, got %v", err)
+	}
+}
+
+func TestGetDefaultCondition(t *testing.T) {
+	defer os.Unsetenv("AUCTION_DEFAULT_CONDITION")
+
+	testCases := map[string]auction_entity.ProductCondition{
+		"":       auction_entity.Unspecified,
+		"used":   auction_entity.Used,
+		"3":      auction_entity.Refurbished,
+		"broken": auction_entity.Unspecified,
+	}
+	for value, expected := range testCases {
+		os.Setenv("AUCTION_DEFAULT_CONDITION", value)
+		if condition := getDefaultCondition(); condition != expected {
+			t.Errorf("Expected %v for %q, got %v", expected, value, condition)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CountAuctions conta os leilões com o status informado, ou todos os leilões
+// quando o status é auction_entity.AnyStatus
+func (ar *AuctionRepository) CountAuctions(
+	ctx context.Context, status auction_entity.AuctionStatus) (int64, *internal_error.InternalError) {
+	filter := bson.M{}
+	if status != auction_entity.AnyStatus {
+		filter["status"] = status
+	}
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	count, err := ar.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		ar.logger.Error("Error trying to count auctions", err)
+		return 0, readError(err, "Error trying to count auctions")
+	}
+
+	return count, nil
+}
+
+// CountAuctionsByOwner conta os leilões do dono com o status informado, ou todos os
+// leilões dele quando o status é auction_entity.AnyStatus
+func (ar *AuctionRepository) CountAuctionsByOwner(
+	ctx context.Context,
+	ownerId string,
+	status auction_entity.AuctionStatus) (int64, *internal_error.InternalError) {
+	filter := bson.M{"owner_id": ownerId}
+	if status != auction_entity.AnyStatus {
+		filter["status"] = status
+	}
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	count, err := ar.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		ar.logger.Error("Error trying to count auctions by owner", err)
+		return 0, readError(err, "Error trying to count auctions by owner")
+	}
+
+	return count, nil
+}
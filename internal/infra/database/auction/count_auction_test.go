@@ -0,0 +1,48 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+)
+
+func TestCountAuctions(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	statuses := []auction_entity.AuctionStatus{
+		auction_entity.Active,
+		auction_entity.Active,
+		auction_entity.Active,
+		auction_entity.Completed,
+		auction_entity.Completed,
+	}
+	for _, status := range statuses {
+		auction, _ := auction_entity.CreateAuction(
+			"Test Product",
+			"Electronics",
+			"A test product for auction",
+			auction_entity.New,
+		)
+		auction.Status = status
+		repo.CreateAuction(ctx, auction)
+	}
+
+	expected := map[auction_entity.AuctionStatus]int64{
+		auction_entity.Active:    3,
+		auction_entity.Completed: 2,
+		auction_entity.AnyStatus: 5,
+	}
+	for status, want := range expected {
+		count, err := repo.CountAuctions(ctx, status)
+		if err != nil {
+			t.Fatalf("Failed to count auctions: %v", err)
+		}
+		if count != want {
+			t.Errorf("Expected %d auctions with status %s, got %d", want, status, count)
+		}
+	}
+}
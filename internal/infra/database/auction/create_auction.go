@@ -1,65 +1,283 @@
 package auction
 
 import (
-"context"
-"fullcycle-auction_go/configuration/logger"
-"fullcycle-auction_go/internal/entity/auction_entity"
-"fullcycle-auction_go/internal/internal_error"
-"os"
-"time"
-
-"go.mongodb.org/mongo-driver/bson"
-"go.mongodb.org/mongo-driver/mongo"
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/event"
+	"fullcycle-auction_go/internal/internal_error"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
 )
 
 type AuctionEntityMongo struct {
-	Id          string                          `bson:"_id"`
-	ProductName string                          `bson:"product_name"`
-	Category    string                          `bson:"category"`
-	Description string                          `bson:"description"`
-	Condition   auction_entity.ProductCondition `bson:"condition"`
-	Status      auction_entity.AuctionStatus    `bson:"status"`
-	Timestamp   int64                           `bson:"timestamp"`
+	Id             string                          `bson:"_id" json:"id"`
+	OwnerId        string                          `bson:"owner_id,omitempty" json:"owner_id,omitempty"`
+	ProductName    string                          `bson:"product_name" json:"product_name"`
+	Category       string                          `bson:"category" json:"category"`
+	Description    string                          `bson:"description" json:"description"`
+	Condition      auction_entity.ProductCondition `bson:"condition" json:"condition"`
+	Status         auction_entity.AuctionStatus    `bson:"status" json:"status"`
+	Timestamp      int64                           `bson:"timestamp" json:"timestamp"`
+	StartsAt       int64                           `bson:"starts_at,omitempty" json:"starts_at,omitempty"`
+	Duration       int64                           `bson:"duration_seconds,omitempty" json:"duration_seconds,omitempty"`
+	EndTime        int64                           `bson:"end_time,omitempty" json:"end_time,omitempty"`
+	Extension      int64                           `bson:"extension_seconds,omitempty" json:"extension_seconds,omitempty"`
+	BidCount       int64                           `bson:"bid_count" json:"bid_count"`
+	CurrentHighBid auction_entity.Money            `bson:"current_high_bid" json:"current_high_bid"`
+	StartingBid    auction_entity.Money            `bson:"starting_bid,omitempty" json:"starting_bid,omitempty"`
+	Currency       string                          `bson:"currency,omitempty" json:"currency,omitempty"`
+	Visibility     auction_entity.Visibility       `bson:"visibility,omitempty" json:"visibility,omitempty"`
+	AllowedUserIds []string                        `bson:"allowed_user_ids,omitempty" json:"allowed_user_ids,omitempty"`
+	ImageURLs      []string                        `bson:"image_urls,omitempty" json:"image_urls,omitempty"`
+	ActivatedAt    *time.Time                      `bson:"activated_at,omitempty" json:"activated_at,omitempty"`
+	CompletedAt    *time.Time                      `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+	Version        int64                           `bson:"version" json:"version"`
+
+	// FailedCloseAttempts conta as varreduras que falharam ao fechar o leilão
+	FailedCloseAttempts int64 `bson:"failed_close_attempts,omitempty" json:"failed_close_attempts,omitempty"`
 }
 
 type AuctionRepository struct {
 	Collection *mongo.Collection
+
+	historyCollection *mongo.Collection
+	outboxCollection  *mongo.Collection
+	eventsCollection  *mongo.Collection
+
+	auctionDuration   time.Duration
+	categoryDurations map[string]time.Duration
+	writeTimeout      time.Duration
+	readTimeout       time.Duration
+	idMode            AuctionIdMode
+	monitorInterval   time.Duration
+	monitorJitter     float64
+	lastTickAt        time.Time
+	lastTickMutex     *sync.Mutex
+	sweepFailures     atomic.Int64
+
+	stats      AuctionCloseStats
+	statsMutex *sync.Mutex
+
+	stopBackground context.CancelFunc
+	background     *sync.WaitGroup
+	shutdownOnce   *sync.Once
+
+	categories         []string
+	categoriesCachedAt time.Time
+	categoriesTTL      time.Duration
+	categoriesMutex    *sync.Mutex
+
+	readCache *auctionReadCache
+
+	leaderElection    bool
+	locker            Locker
+	dryRun            bool
+	defaultCondition  auction_entity.ProductCondition
+	closeBatchSize    int64
+	atomicClose       bool
+	closeGrace        time.Duration
+	monitorDisabled   bool
+	reopenEnabled     bool
+	categoryBrowsing  bool
+	maxExtension      time.Duration
+	retention         time.Duration
+	clock             Clock
+	logger            logger.Logger
+	publisher         event.Publisher
+	outboxEnabled     bool
+	eventStoreEnabled bool
+	drainerDisabled   bool
+	drainInterval     time.Duration
+
+	reconcilerDisabled bool
+	reconcileInterval  time.Duration
+	reconcileThreshold time.Duration
+	closeLatency       prometheus.ObserverVec
+
+	closeFailureThreshold int64
+	// beforeAuctionClose permite que os testes simulem falhas ao fechar um leilão
+	beforeAuctionClose func(id string) error
 }
 
-func NewAuctionRepository(database *mongo.Database) *AuctionRepository {
+// AuctionRepositoryOption permite customizar o AuctionRepository na sua criação
+type AuctionRepositoryOption func(*AuctionRepository)
+
+func NewAuctionRepository(
+	database *mongo.Database, opts ...AuctionRepositoryOption) *AuctionRepository {
+	auctionDuration := getAuctionDuration()
+	categoryDurations := getCategoryDurations()
+	// Valores inválidos deixam a flag desligada; a aplicação os rejeita na subida
+	features, _ := LoadFeatures()
 	repo := &AuctionRepository{
-		Collection: database.Collection("auctions"),
+		Collection:            database.Collection("auctions"),
+		historyCollection:     database.Collection("auction_status_history"),
+		outboxCollection:      database.Collection("event_outbox"),
+		eventsCollection:      database.Collection("auction_events"),
+		auctionDuration:       auctionDuration,
+		categoryDurations:     categoryDurations,
+		writeTimeout:          getWriteTimeout(),
+		readTimeout:           getReadTimeout(),
+		idMode:                getIdMode(),
+		monitorInterval:       getMonitorInterval(shortestDuration(auctionDuration, categoryDurations)),
+		monitorJitter:         getMonitorJitter(),
+		lastTickMutex:         &sync.Mutex{},
+		statsMutex:            &sync.Mutex{},
+		background:            &sync.WaitGroup{},
+		shutdownOnce:          &sync.Once{},
+		categoriesTTL:         getCategoriesCacheTTL(),
+		categoriesMutex:       &sync.Mutex{},
+		readCache:             getReadCache(),
+		leaderElection:        features.LeaderElection,
+		dryRun:                features.CloseDryRun,
+		defaultCondition:      getDefaultCondition(),
+		closeBatchSize:        getCloseBatchSize(),
+		atomicClose:           features.AtomicClose,
+		closeGrace:            getCloseGrace(),
+		monitorDisabled:       features.MonitorDisabled,
+		outboxEnabled:         features.Outbox,
+		eventStoreEnabled:     features.EventStore,
+		drainerDisabled:       features.OutboxDrainerDisabled,
+		drainInterval:         getOutboxDrainInterval(),
+		reconcilerDisabled:    features.ReconcilerDisabled,
+		reconcileInterval:     getReconcileInterval(),
+		reconcileThreshold:    getReconcileThreshold(),
+		closeFailureThreshold: getCloseFailureThreshold(),
+		reopenEnabled:         features.Reopen,
+		categoryBrowsing:      features.CategoryBrowsing,
+		maxExtension:          getMaxExtension(),
+		retention:             getRetention(),
+		clock:                 realClock{},
+		closeLatency:          closeLatencyHistogram,
+		logger:                logger.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(repo)
 	}
 
-	// Inicia a goroutine que monitora leilões expirados
-	go repo.monitorExpiredAuctions(context.Background())
+	if repo.leaderElection && repo.locker == nil {
+		repo.locker = NewMongoLeaseLocker(
+			database.Collection("auction_locks"), monitorLockName, getLeaderLease())
+	}
+
+	repo.lastTickAt = repo.clock.Now()
+	repo.stats.StartedAt = repo.lastTickAt
+
+	// As goroutines de fundo param quando Shutdown cancela este contexto
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	repo.stopBackground = stopBackground
+
+	// Inicia a goroutine que monitora leilões expirados, exceto quando os fechamentos
+	// são disparados externamente via ProcessExpiredAuctionsOnce
+	if !repo.monitorDisabled {
+		repo.runInBackground(backgroundCtx, repo.monitorExpiredAuctions)
+	}
+
+	if repo.shouldDrainOutbox() {
+		repo.runInBackground(backgroundCtx, repo.drainOutbox)
+	}
+
+	// O reconciliador acompanha o monitor: sem ele, a ativação é feita externamente
+	if !repo.monitorDisabled && !repo.reconcilerDisabled {
+		repo.runInBackground(backgroundCtx, repo.reconcileScheduledAuctions)
+	}
 
 	return repo
 }
 
 func (ar *AuctionRepository) CreateAuction(
-ctx context.Context,
-auctionEntity *auction_entity.Auction) *internal_error.InternalError {
-	auctionEntityMongo := &AuctionEntityMongo{
-		Id:          auctionEntity.Id,
-		ProductName: auctionEntity.ProductName,
-		Category:    auctionEntity.Category,
-		Description: auctionEntity.Description,
-		Condition:   auctionEntity.Condition,
-		Status:      auctionEntity.Status,
-		Timestamp:   auctionEntity.Timestamp.Unix(),
-	}
-	_, err := ar.Collection.InsertOne(ctx, auctionEntityMongo)
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	if err := ar.applyDefaultCondition(auctionEntity); err != nil {
+		return err
+	}
+
+	// A entidade nasce com um UUID; no modo ObjectID ele é trocado antes da inserção
+	if ar.idMode == ObjectIdMode {
+		auctionEntity.Id = ar.newAuctionId()
+	}
+
+	auctionEntityMongo := toMongo(auctionEntity)
+	auctionEntityMongo.EndTime = ar.auctionEndTime(auctionEntity).Unix()
+
+	// Leilões agendados guardam o início e a duração efetiva, usados para recalcular o
+	// fim na ativação; os demais começam na criação
+	auctionEntityMongo.StartsAt = 0
+	if auctionEntity.Status == auction_entity.Scheduled {
+		auctionEntityMongo.StartsAt = auctionEntity.StartsAt.Unix()
+		auctionEntityMongo.Duration = int64(ar.effectiveDuration(auctionEntity).Seconds())
+	}
+
+	document, err := ar.auctionDocument(auctionEntityMongo)
+	if err != nil {
+		ar.logger.Error("Error trying to encode auction", err,
+			logger.ContextFields(ctx, zap.String("auction_id", auctionEntity.Id))...)
+		return internal_error.NewInternalServerError("Error trying to insert auction")
+	}
+
+	ctx, cancel := withTimeout(ctx, ar.writeTimeout)
+	defer cancel()
+
+	_, err = ar.Collection.InsertOne(ctx, document)
 	if err != nil {
-		logger.Error("Error trying to insert auction", err)
+		if isTimeout(err) {
+			ar.logger.Error("Timeout trying to insert auction", err,
+				logger.ContextFields(ctx, zap.String("auction_id", auctionEntity.Id))...)
+			return internal_error.NewTimeoutError("Timeout trying to insert auction")
+		}
+
+		ar.logger.Error("Error trying to insert auction", err,
+			logger.ContextFields(ctx, zap.String("auction_id", auctionEntity.Id))...)
 		return internal_error.NewInternalServerError("Error trying to insert auction")
 	}
 
+	ar.recordTransitions(ctx, []string{auctionEntity.Id}, nil, auctionEntity.Status, TransitionCreated)
+
+	ar.logger.Info("Auction created", logger.ContextFields(ctx,
+		zap.String("auction_id", auctionEntity.Id),
+		zap.String("category", auctionEntity.Category),
+		zap.Int64("end_time", auctionEntityMongo.EndTime))...)
+
 	return nil
 }
 
+// auctionEndTime calcula o fim do leilão a partir da sua criação ou, se agendado, do
+// seu início previsto. A ativação recalcula o fim a partir do momento em que ocorre
+func (ar *AuctionRepository) auctionEndTime(auctionEntity *auction_entity.Auction) time.Time {
+	start := auctionEntity.Timestamp
+	if auctionEntity.Status == auction_entity.Scheduled {
+		start = auctionEntity.StartsAt
+	}
+
+	return start.Add(ar.effectiveDuration(auctionEntity))
+}
+
+// effectiveDuration retorna a duração própria do leilão, usando a duração da sua
+// categoria ou a global quando o leilão não define uma
+func (ar *AuctionRepository) effectiveDuration(auctionEntity *auction_entity.Auction) time.Duration {
+	if auctionEntity.Duration > 0 {
+		return auctionEntity.Duration
+	}
+
+	return ar.categoryDuration(auctionEntity.Category)
+}
+
 // getAuctionDuration retorna a duração do leilão baseada na variável de ambiente AUCTION_DURATION
-// Se não estiver definida, retorna 5 minutos como padrão
+// Se não estiver definida ou não for positiva, retorna 5 minutos como padrão. Uma duração
+// acima de AUCTION_MAX_DURATION é reduzida ao máximo, para que um erro de configuração
+// não deixe os leilões abertos indefinidamente
 func getAuctionDuration() time.Duration {
 	auctionDuration := os.Getenv("AUCTION_DURATION")
 	if auctionDuration == "" {
@@ -67,64 +285,283 @@ func getAuctionDuration() time.Duration {
 	}
 
 	duration, err := time.ParseDuration(auctionDuration)
-	if err != nil {
+	if err != nil || duration <= 0 {
 		logger.Info("Using default auction duration of 5 minutes")
 		return time.Minute * 5
 	}
 
+	if maxDuration := auction_entity.MaxDuration(); duration > maxDuration {
+		logger.Warn("AUCTION_DURATION exceeds AUCTION_MAX_DURATION, using the maximum",
+			zap.Duration("duration", duration),
+			zap.Duration("max_duration", maxDuration))
+		return maxDuration
+	}
+
 	return duration
 }
 
+// getMonitorInterval retorna o intervalo entre as verificações do monitor:
+// a cada minuto ou a cada metade da duração do leilão (o que for menor)
+func getMonitorInterval(auctionDuration time.Duration) time.Duration {
+	return min(time.Minute, auctionDuration/2)
+}
+
+// getMonitorJitter retorna a fração do intervalo do monitor (entre 0 e 1) usada como
+// deslocamento aleatório de cada tick, baseada em AUCTION_MONITOR_JITTER.
+// O padrão é 0 (sem jitter)
+func getMonitorJitter() float64 {
+	jitter, err := strconv.ParseFloat(os.Getenv("AUCTION_MONITOR_JITTER"), 64)
+	if err != nil || jitter < 0 {
+		return 0
+	}
+
+	if jitter > 1 {
+		return 1
+	}
+
+	return jitter
+}
+
+// nextTickDelay calcula o atraso até o próximo tick, somando ao intervalo (ou ao
+// backoff, após falhas) uma fração aleatória dele para que várias instâncias não
+// acordem ao mesmo tempo
+func (ar *AuctionRepository) nextTickDelay() time.Duration {
+	delay := ar.monitorBackoff()
+	if ar.monitorJitter == 0 {
+		return delay
+	}
+
+	maxJitter := float64(delay) * ar.monitorJitter
+	return delay + time.Duration(rand.Float64()*maxJitter)
+}
+
 // monitorExpiredAuctions é uma goroutine que verifica periodicamente leilões expirados
 // e os fecha automaticamente
 func (ar *AuctionRepository) monitorExpiredAuctions(ctx context.Context) {
-	auctionDuration := getAuctionDuration()
-	
-	// Verifica a cada minuto ou a cada metade da duração do leilão (o que for menor)
-	ticker := time.NewTicker(min(time.Minute, auctionDuration/2))
-	defer ticker.Stop()
+	auctionDuration := ar.auctionDuration
+
+	// Usa um timer reiniciado a cada tick para que o jitter varie entre os ticks
+	timer := time.NewTimer(ar.nextTickDelay())
+	defer timer.Stop()
 
-	logger.Info("Auction expiration monitor started")
+	ar.logger.Info("Auction expiration monitor started",
+		zap.Duration("auction_duration", auctionDuration),
+		zap.Duration("interval", ar.monitorInterval),
+		zap.Float64("jitter", ar.monitorJitter))
 
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Auction expiration monitor stopped")
+			stats := ar.Stats()
+			ar.logger.Info("Auction expiration monitor stopped",
+				zap.Int64("total_closed", stats.TotalClosed),
+				zap.Int64("total_ticks", stats.TotalTicks),
+				zap.Duration("uptime", ar.clock.Now().Sub(stats.StartedAt)),
+				zap.Time("last_closed_at", stats.LastClosedAt))
 			return
-		case <-ticker.C:
-			ar.closeExpiredAuctions(context.Background(), auctionDuration)
+		case <-timer.C:
+			ar.handleTick(ctx, auctionDuration)
+			timer.Reset(ar.nextTickDelay())
 		}
 	}
 }
 
-// closeExpiredAuctions busca e fecha todos os leilões que já expiraram
-func (ar *AuctionRepository) closeExpiredAuctions(ctx context.Context, auctionDuration time.Duration) {
-	// Calcula o timestamp de expiração (agora - duração do leilão)
-	expirationTime := time.Now().Add(-auctionDuration).Unix()
+// handleTick registra o tick do monitor, ativa os leilões agendados cujo início chegou
+// e executa a varredura de fechamento, caso esta instância seja a líder (ou a eleição
+// de líder esteja desabilitada). A varredura é pulada quando o monitor está sendo
+// encerrado, para não iniciar trabalho que seria abandonado
+func (ar *AuctionRepository) handleTick(ctx context.Context, auctionDuration time.Duration) {
+	ar.recordTick(ar.clock.Now())
+
+	if !hasTimeForSweep(ctx, ar.writeTimeout) {
+		ar.logger.Info("Skipping close sweep, monitor context is done or about to expire")
+		return
+	}
+
+	if !ar.isLeader(ctx) {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, ar.writeTimeout)
+	defer cancel()
+
+	ar.activateScheduledAuctions(ctx)
+	ar.closeExpiredAuctions(ctx, auctionDuration)
+}
+
+// closeExpiredAuctions busca e fecha todos os leilões que já expiraram, retornando
+// os IDs fechados. Em modo dry-run nada é alterado e são retornados os IDs que seriam fechados
+func (ar *AuctionRepository) closeExpiredAuctions(
+	ctx context.Context, auctionDuration time.Duration) []string {
+	expiredIds, _, _ := ar.sweepExpiredAuctions(ctx, auctionDuration, CloseStrategyMonitor)
+	return expiredIds
+}
+
+// sweepExpiredAuctions executa uma varredura de fechamento, retornando os IDs expirados
+// encontrados e quantos deles foram de fato fechados. strategy identifica quem disparou
+// a varredura nas métricas. Os leilões são fechados em lotes de closeBatchSize, para que
+// um grande acúmulo de expirados não vire um único UpdateMany longo
+func (ar *AuctionRepository) sweepExpiredAuctions(
+	ctx context.Context,
+	auctionDuration time.Duration,
+	strategy string) ([]string, int64, *internal_error.InternalError) {
+	startedAt := time.Now()
+
+	if ar.dryRun {
+		return ar.dryRunSweep(ctx, auctionDuration)
+	}
+
+	var expiredIds []string
+	var closed int64
+	batches := 0
+	for {
+		// Interrompe entre lotes quando o contexto é cancelado; os lotes já
+		// fechados permanecem fechados e o restante fica para a próxima varredura
+		if batches > 0 && ctx.Err() != nil {
+			ar.logger.Warn("Close sweep interrupted between batches",
+				zap.Int("batches", batches),
+				zap.Int64("closed", closed),
+				zap.Error(ctx.Err()))
+			break
+		}
+
+		batch, err := ar.findExpiredAuctions(ctx, auctionDuration, ar.closeBatchSize)
+		if err != nil {
+			ar.recordSweepFailure()
+			return expiredIds, closed, err
+		}
+
+		if len(batch) == 0 {
+			break
+		}
+
+		batchIds, modified, err := ar.closeBatch(ctx, batch, strategy)
+		expiredIds = append(expiredIds, batchIds...)
+		closed += modified
+		if err != nil {
+			ar.recordSweepFailure()
+			return expiredIds, closed, err
+		}
+
+		batches++
 
-	// Filtro para buscar leilões ativos que já expiraram
+		if int64(len(batch)) < ar.closeBatchSize {
+			break
+		}
+	}
+
+	ar.recordSweepSuccess()
+	ar.recordCloseResult(closed, ar.clock.Now())
+
+	if closed > 0 {
+		ar.logger.Info("Closed expired auctions",
+			zap.Int64("modified_count", closed),
+			zap.Strings("auction_ids", expiredIds),
+			zap.Int("batches", batches),
+			zap.Duration("elapsed", time.Since(startedAt)))
+	}
+
+	return expiredIds, closed, nil
+}
+
+// closeBatch fecha um lote de leilões expirados e dispara os efeitos de cada
+// fechamento (latência, histórico e eventos), retornando os IDs do lote e quantos
+// foram de fato alterados
+func (ar *AuctionRepository) closeBatch(
+	ctx context.Context,
+	expiredAuctions []AuctionEntityMongo,
+	strategy string) ([]string, int64, *internal_error.InternalError) {
+	// Eventos não podem sair em dobro quando outra chamada fecha o mesmo leilão, então
+	// com publisher ou event store cada leilão é fechado com FindOneAndUpdate
+	if ar.atomicClose || ar.publishesEvents() {
+		return ar.closeBatchAtomically(ctx, expiredAuctions, strategy)
+	}
+
+	expiredIds := make([]string, 0, len(expiredAuctions))
+	for _, auction := range expiredAuctions {
+		expiredIds = append(expiredIds, auction.Id)
+	}
+
+	// Mantém o status no filtro para não reabrir/reescrever leilões alterados após a busca
 	filter := bson.M{
-		"status":    auction_entity.Active,
-		"timestamp": bson.M{"$lte": expirationTime},
+		"_id":    bson.M{"$in": ar.documentIds(expiredIds)},
+		"status": auction_entity.Active,
 	}
 
-	// Update para marcar como completo
+	// Update para marcar como completo. close_token identifica os leilões que esta
+	// chamada fechou, já que outra varredura ou um fechamento manual pode ter fechado
+	// parte do lote entre a busca e o update
+	closedAt := ar.clock.Now()
+	closeToken := uuid.NewString()
 	update := bson.M{
 		"$set": bson.M{
-			"status": auction_entity.Completed,
+			"status":       auction_entity.Completed,
+			"completed_at": closedAt,
+			"close_token":  closeToken,
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
-	// Atualiza todos os leilões que correspondem ao filtro
 	result, err := ar.Collection.UpdateMany(ctx, filter, update)
+	ar.readCache.invalidate(expiredIds...)
 	if err != nil {
-		logger.Error("Error trying to close expired auctions", err)
-		return
+		ar.logger.Error("Error trying to close expired auctions", err,
+			zap.Int("expired_count", len(expiredIds)))
+		return nil, 0, internal_error.NewInternalServerError("Error trying to close expired auctions")
 	}
 
-	if result.ModifiedCount > 0 {
-		logger.Info("Closed expired auctions")
+	closedAuctions, closedIds, findErr := ar.findClosedBy(ctx, expiredAuctions, closeToken)
+	if findErr != nil {
+		return nil, 0, findErr
 	}
+
+	ar.observeCloseLatency(closedAuctions, closedAt, strategy)
+	ar.recordTransitions(ctx, closedIds,
+		statusPtr(auction_entity.Active), auction_entity.Completed, TransitionExpired)
+
+	return expiredIds, result.ModifiedCount, nil
+}
+
+// findClosedBy retorna, dentre os leilões do lote, os que foram fechados pelo update
+// marcado com closeToken
+func (ar *AuctionRepository) findClosedBy(
+	ctx context.Context,
+	expiredAuctions []AuctionEntityMongo,
+	closeToken string) ([]AuctionEntityMongo, []string, *internal_error.InternalError) {
+	expiredIds := make([]string, 0, len(expiredAuctions))
+	for _, auction := range expiredAuctions {
+		expiredIds = append(expiredIds, auction.Id)
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": ar.documentIds(expiredIds)}, "close_token": closeToken}
+	cursor, err := ar.Collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		ar.logger.Error("Error trying to find closed auctions", err)
+		return nil, nil, internal_error.NewInternalServerError("Error trying to find closed auctions")
+	}
+	defer cursor.Close(ctx)
+
+	var closed []AuctionEntityMongo
+	if err := cursor.All(ctx, &closed); err != nil {
+		ar.logger.Error("Error trying to decode closed auctions", err)
+		return nil, nil, internal_error.NewInternalServerError("Error trying to decode closed auctions")
+	}
+
+	closedSet := make(map[string]bool, len(closed))
+	for _, auction := range closed {
+		closedSet[auction.Id] = true
+	}
+
+	closedAuctions := make([]AuctionEntityMongo, 0, len(closed))
+	closedIds := make([]string, 0, len(closed))
+	for _, auction := range expiredAuctions {
+		if closedSet[auction.Id] {
+			closedAuctions = append(closedAuctions, auction)
+			closedIds = append(closedIds, auction.Id)
+		}
+	}
+
+	return closedAuctions, closedIds, nil
 }
 
 // helper function para min
@@ -1,20 +1,28 @@
 package auction
 
 import (
-"context"
-"fullcycle-auction_go/internal/entity/auction_entity"
-"os"
-"testing"
-"time"
-
-"go.mongodb.org/mongo-driver/bson"
-"go.mongodb.org/mongo-driver/mongo"
-"go.mongodb.org/mongo-driver/mongo/options"
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	mongoPingOnce sync.Once
+	mongoPingErr  error
 )
 
 func setupTestDB(t *testing.T) (*mongo.Database, func()) {
 	ctx := context.Background()
-	
+
 	// Conecta ao MongoDB de teste
 	mongoURL := os.Getenv("MONGODB_URL")
 	if mongoURL == "" {
@@ -26,18 +34,44 @@ func setupTestDB(t *testing.T) (*mongo.Database, func()) {
 		t.Fatalf("Failed to connect to MongoDB: %v", err)
 	}
 
+	// Sem um servidor acessível o teste é pulado, em vez de esperar pelos timeouts.
+	// O ping é feito uma vez só, para que os demais testes pulem sem esperar de novo
+	mongoPingOnce.Do(func() {
+		pingCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+		mongoPingErr = client.Ping(pingCtx, nil)
+	})
+	if mongoPingErr != nil {
+		client.Disconnect(ctx)
+		t.Skipf("MongoDB is not reachable: %v", mongoPingErr)
+	}
+
 	dbName := "auctions_test"
 	db := client.Database(dbName)
 
 	// Cleanup function
 	cleanup := func() {
 		db.Collection("auctions").Drop(ctx)
+		db.Collection("auction_status_history").Drop(ctx)
+		db.Collection("event_outbox").Drop(ctx)
 		client.Disconnect(ctx)
 	}
 
 	return db, cleanup
 }
 
+// newTestRepository cria o repositório e o desliga ao fim do teste, para que as
+// goroutines de fundo não sigam rodando depois dele
+func newTestRepository(
+	t *testing.T, database *mongo.Database, opts ...AuctionRepositoryOption) *AuctionRepository {
+	t.Helper()
+
+	repo := NewAuctionRepository(database, opts...)
+	t.Cleanup(func() { repo.Shutdown(context.Background()) })
+
+	return repo
+}
+
 func TestAuctionAutoClose(t *testing.T) {
 	// Define duração curta para o teste (2 segundos)
 	os.Setenv("AUCTION_DURATION", "2s")
@@ -47,15 +81,15 @@ func TestAuctionAutoClose(t *testing.T) {
 	defer cleanup()
 
 	// Cria repositório (que inicia a goroutine de monitoramento)
-	repo := NewAuctionRepository(db)
+	repo := newTestRepository(t, db)
 
 	// Cria um leilão de teste
 	auction, _ := auction_entity.CreateAuction(
-"Test Product",
-"Electronics",
-"A test product for auction",
-auction_entity.New,
-)
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
 
 	ctx := context.Background()
 	err := repo.CreateAuction(ctx, auction)
@@ -113,16 +147,26 @@ func TestGetAuctionDuration(t *testing.T) {
 			envValue: "",
 			expected: 5 * time.Minute,
 		},
+		{
+			name:     "Zero duration falls back to default",
+			envValue: "0s",
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "Duration above the maximum is clamped",
+			envValue: "8760h",
+			expected: 30 * 24 * time.Hour,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-if tt.envValue != "" {
-os.Setenv("AUCTION_DURATION", tt.envValue)
-defer os.Unsetenv("AUCTION_DURATION")
-}
+			if tt.envValue != "" {
+				os.Setenv("AUCTION_DURATION", tt.envValue)
+				defer os.Unsetenv("AUCTION_DURATION")
+			}
 
-duration := getAuctionDuration()
+			duration := getAuctionDuration()
 			if duration != tt.expected {
 				t.Errorf("Expected duration %v, got %v", tt.expected, duration)
 			}
@@ -137,25 +181,27 @@ func TestCloseExpiredAuctions(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
 
-	repo := NewAuctionRepository(db)
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock))
 	ctx := context.Background()
 
 	// Cria 2 leilões: um expirado e um ativo
 	expiredAuction, _ := auction_entity.CreateAuction(
-"Expired Product",
-"Electronics",
-"This auction should expire",
-auction_entity.New,
-)
+		"Expired Product",
+		"Electronics",
+		"This auction should expire",
+		auction_entity.New,
+	)
 	// Modifica o timestamp para ser no passado
-	expiredAuction.Timestamp = time.Now().Add(-2 * time.Second)
+	expiredAuction.Timestamp = clock.Now().Add(-2 * time.Second)
 
 	activeAuction, _ := auction_entity.CreateAuction(
-"Active Product",
-"Electronics",
-"This auction should remain active",
-auction_entity.New,
-)
+		"Active Product",
+		"Electronics",
+		"This auction should remain active",
+		auction_entity.New,
+	)
+	activeAuction.Timestamp = clock.Now()
 
 	repo.CreateAuction(ctx, expiredAuction)
 	repo.CreateAuction(ctx, activeAuction)
@@ -177,3 +223,156 @@ auction_entity.New,
 		t.Errorf("Expected active auction to remain Active, got %d", activeMongo.Status)
 	}
 }
+
+func TestNextTickDelayWithJitter(t *testing.T) {
+	repo := &AuctionRepository{
+		monitorInterval: 10 * time.Second,
+		monitorJitter:   0.5,
+	}
+
+	minDelay := repo.monitorInterval
+	maxDelay := repo.monitorInterval + repo.monitorInterval/2
+
+	delays := make(map[time.Duration]bool)
+	for i := 0; i < 20; i++ {
+		delay := repo.nextTickDelay()
+		if delay < minDelay || delay > maxDelay {
+			t.Fatalf("Expected delay between %v and %v, got %v", minDelay, maxDelay, delay)
+		}
+		delays[delay] = true
+	}
+
+	if len(delays) < 2 {
+		t.Error("Expected tick delays to vary when jitter is enabled")
+	}
+}
+
+func TestNextTickDelayWithoutJitter(t *testing.T) {
+	repo := &AuctionRepository{monitorInterval: 10 * time.Second}
+
+	for i := 0; i < 5; i++ {
+		if delay := repo.nextTickDelay(); delay != repo.monitorInterval {
+			t.Fatalf("Expected fixed delay %v, got %v", repo.monitorInterval, delay)
+		}
+	}
+}
+
+func TestCloseExpiredAuctionsWithPerAuctionDuration(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	// Ambos começaram há 5 minutos, mas só o curto já passou do seu fim
+	shortAuction, _ := auction_entity.CreateAuction(
+		"Short Product",
+		"Electronics",
+		"This auction has a short duration",
+		auction_entity.New,
+		auction_entity.WithDuration(time.Minute),
+	)
+	shortAuction.Timestamp = time.Now().Add(-5 * time.Minute)
+
+	longAuction, _ := auction_entity.CreateAuction(
+		"Long Product",
+		"Electronics",
+		"This auction has a long duration",
+		auction_entity.New,
+		auction_entity.WithDuration(time.Hour),
+	)
+	longAuction.Timestamp = time.Now().Add(-5 * time.Minute)
+
+	// Sem duração própria, usa a duração global de 10 minutos
+	defaultAuction, _ := auction_entity.CreateAuction(
+		"Default Product",
+		"Electronics",
+		"This auction uses the global duration",
+		auction_entity.New,
+	)
+	defaultAuction.Timestamp = time.Now().Add(-5 * time.Minute)
+
+	repo.CreateAuction(ctx, shortAuction)
+	repo.CreateAuction(ctx, longAuction)
+	repo.CreateAuction(ctx, defaultAuction)
+
+	repo.closeExpiredAuctions(ctx, 10*time.Minute)
+
+	expected := map[string]auction_entity.AuctionStatus{
+		shortAuction.Id:   auction_entity.Completed,
+		longAuction.Id:    auction_entity.Active,
+		defaultAuction.Id: auction_entity.Active,
+	}
+	for id, status := range expected {
+		var auctionMongo AuctionEntityMongo
+		repo.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&auctionMongo)
+		if auctionMongo.Status != status {
+			t.Errorf("Expected auction %s to have status %d, got %d", id, status, auctionMongo.Status)
+		}
+	}
+}
+
+func TestCloseAuction(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	repo.CreateAuction(ctx, auction)
+
+	if err := repo.CloseAuction(ctx, auction.Id); err != nil {
+		t.Fatalf("Failed to close auction: %v", err)
+	}
+
+	found, _ := repo.FindAuctionById(ctx, auction.Id)
+	if found.Status != auction_entity.Completed {
+		t.Errorf("Expected auction to be Completed, got %d", found.Status)
+	}
+
+	if err := repo.CloseAuction(ctx, auction.Id); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request closing an already closed auction, got %v", err)
+	}
+
+	if err := repo.CloseAuction(ctx, uuid.NewString()); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found closing a missing auction, got %v", err)
+	}
+}
+
+func TestCreateAuctionPersistsImageURLs(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	imageURLs := []string{"https://cdn.example.com/a.jpg", "https://cdn.example.com/b.jpg"}
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product", "Electronics", "A test product for auction", auction_entity.New,
+		auction_entity.WithImageURLs(imageURLs...))
+	if err := repo.CreateAuction(ctx, auction); err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+
+	found, err := repo.FindAuctionById(ctx, auction.Id)
+	if err != nil {
+		t.Fatalf("Failed to find auction: %v", err)
+	}
+	if !reflect.DeepEqual(found.ImageURLs, imageURLs) {
+		t.Errorf("Expected image urls %v, got %v", imageURLs, found.ImageURLs)
+	}
+
+	auctions, _ := repo.FindAuctions(ctx, auction_entity.AnyStatus, "", "", auction_entity.DefaultSortOrder, "")
+	if len(auctions) != 1 || !reflect.DeepEqual(auctions[0].ImageURLs, imageURLs) {
+		t.Errorf("Expected image urls in the listing, got %+v", auctions)
+	}
+}
@@ -0,0 +1,111 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// auctionDetailBids é quantos dos maiores lances acompanham o leilão em GetAuctionDetail
+const auctionDetailBids = 10
+
+// AuctionDetail é o leilão com os seus maiores lances, do maior para o menor valor, e o
+// lance que lidera, nil enquanto não houver lances
+type AuctionDetail struct {
+	Auction auction_entity.Auction
+	Bids    []bid_entity.Bid
+	Winner  *bid_entity.Bid
+}
+
+// auctionDetailMongo é o leilão com os lances trazidos pelo $lookup
+type auctionDetailMongo struct {
+	AuctionEntityMongo `bson:",inline"`
+	Bids               []auctionDetailBidMongo `bson:"bids"`
+}
+
+type auctionDetailBidMongo struct {
+	Id        string               `bson:"_id"`
+	UserId    string               `bson:"user_id"`
+	AuctionId string               `bson:"auction_id"`
+	Amount    auction_entity.Money `bson:"amount"`
+	Currency  string               `bson:"currency,omitempty"`
+	Timestamp int64                `bson:"timestamp"`
+}
+
+// GetAuctionDetail lê o leilão e os seus maiores lances em uma única agregação, para
+// telas de detalhe. Os lances seguem as regras do vencedor: só contam os na moeda do
+// leilão e a partir do lance mínimo, e empates ficam com o lance mais antigo
+func (ar *AuctionRepository) GetAuctionDetail(
+	ctx context.Context, auctionId string) (*AuctionDetail, *internal_error.InternalError) {
+	if err := ar.checkAuctionId(auctionId); err != nil {
+		return nil, err
+	}
+
+	bidsPipeline := mongo.Pipeline{
+		// O filtro literal em auction_id usa o índice da coleção de lances
+		{{Key: "$match", Value: bson.M{"auction_id": auctionId}}},
+		{{Key: "$match", Value: bson.M{"$expr": bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$currency", "$$currency"}}, "$$currency"}},
+			bson.M{"$gte": bson.A{"$amount", "$$starting_bid"}},
+		}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "amount", Value: -1}, {Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: auctionDetailBids}},
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": ar.DocumentId(auctionId)}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "bids",
+			"let": bson.M{
+				"currency":     bson.M{"$ifNull": bson.A{"$currency", auction_entity.DefaultCurrency()}},
+				"starting_bid": bson.M{"$ifNull": bson.A{"$starting_bid", 0}},
+			},
+			"pipeline": bidsPipeline,
+			"as":       "bids",
+		}}},
+	}
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	cursor, err := ar.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to find detail of auction %s", auctionId), err)
+		return nil, readError(err, "Error trying to find auction detail")
+	}
+	defer cursor.Close(ctx)
+
+	var details []auctionDetailMongo
+	if err := cursor.All(ctx, &details); err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to decode detail of auction %s", auctionId), err)
+		return nil, readError(err, "Error trying to decode auction detail")
+	}
+	if len(details) == 0 {
+		return nil, internal_error.NewNotFoundError(fmt.Sprintf("Auction not found with this id = %s", auctionId))
+	}
+
+	detail := &AuctionDetail{
+		Auction: *fromMongo(&details[0].AuctionEntityMongo),
+		Bids:    make([]bid_entity.Bid, 0, len(details[0].Bids)),
+	}
+	for _, bidMongo := range details[0].Bids {
+		detail.Bids = append(detail.Bids, bid_entity.Bid{
+			Id:        bidMongo.Id,
+			UserId:    bidMongo.UserId,
+			AuctionId: bidMongo.AuctionId,
+			Amount:    bidMongo.Amount,
+			Currency:  currencyOrDefault(bidMongo.Currency),
+			Timestamp: time.Unix(bidMongo.Timestamp, 0).UTC(),
+		})
+	}
+	if len(detail.Bids) > 0 {
+		detail.Winner = &detail.Bids[0]
+	}
+
+	return detail, nil
+}
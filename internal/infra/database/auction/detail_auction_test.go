@@ -0,0 +1,111 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestGetAuctionDetailJoinsTopBids(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	defer db.Collection("bids").Drop(context.Background())
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	if err := repo.CreateAuction(ctx, auction); err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+
+	// O empate em 25.00 fica com o lance mais antigo; a outra moeda e o outro leilão não entram
+	now := time.Now().Unix()
+	bid := func(id, auctionId string, amount auction_entity.Money, currency string, timestamp int64) bson.M {
+		document := bson.M{
+			"_id":        id,
+			"user_id":    uuid.New().String(),
+			"auction_id": auctionId,
+			"amount":     amount,
+			"timestamp":  timestamp,
+		}
+		if currency != "" {
+			document["currency"] = currency
+		}
+		return document
+	}
+	bids := []interface{}{
+		bid("low", auction.Id, 1000, auction.Currency, now),
+		bid("tie-newer", auction.Id, 2500, auction.Currency, now),
+		bid("tie-older", auction.Id, 2500, auction.Currency, now-10),
+		bid("legacy", auction.Id, 1500, "", now),
+		bid("other-currency", auction.Id, 9900, "USD", now),
+		bid("other-auction", uuid.New().String(), 9900, auction.Currency, now),
+	}
+	if _, err := db.Collection("bids").InsertMany(ctx, bids); err != nil {
+		t.Fatalf("Failed to seed bids: %v", err)
+	}
+
+	detail, err := repo.GetAuctionDetail(ctx, auction.Id)
+	if err != nil {
+		t.Fatalf("Failed to get auction detail: %v", err)
+	}
+
+	if detail.Auction.Id != auction.Id || detail.Auction.ProductName != "Test Product" {
+		t.Errorf("Expected the auction fields, got %+v", detail.Auction)
+	}
+
+	expected := []string{"tie-older", "tie-newer", "legacy", "low"}
+	if len(detail.Bids) != len(expected) {
+		t.Fatalf("Expected %d bids, got %+v", len(expected), detail.Bids)
+	}
+	for i, id := range expected {
+		if detail.Bids[i].Id != id {
+			t.Errorf("Expected bid %s at position %d, got %s", id, i, detail.Bids[i].Id)
+		}
+	}
+	if detail.Bids[2].Currency != auction.Currency {
+		t.Errorf("Expected the legacy bid in %s, got %q", auction.Currency, detail.Bids[2].Currency)
+	}
+
+	if detail.Winner == nil || detail.Winner.Id != "tie-older" || detail.Winner.Amount != 2500 {
+		t.Errorf("Expected the older 25.00 bid to win, got %+v", detail.Winner)
+	}
+}
+
+func TestGetAuctionDetailWithoutBids(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	repo.CreateAuction(ctx, auction)
+
+	detail, err := repo.GetAuctionDetail(ctx, auction.Id)
+	if err != nil {
+		t.Fatalf("Failed to get auction detail: %v", err)
+	}
+	if len(detail.Bids) != 0 || detail.Winner != nil {
+		t.Errorf("Expected no bids and no winner, got %+v", detail)
+	}
+
+	if _, err := repo.GetAuctionDetail(ctx, uuid.New().String()); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected a not_found error, got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// WithDryRun liga ou desliga o modo dry-run do fechamento de leilões
+func WithDryRun(dryRun bool) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.dryRun = dryRun
+	}
+}
+
+// dryRunSweep registra os leilões que seriam fechados, sem alterá-los. Como nada é
+// fechado, a busca não é feita em lotes: repeti-la devolveria sempre o mesmo lote
+func (ar *AuctionRepository) dryRunSweep(
+	ctx context.Context, auctionDuration time.Duration) ([]string, int64, *internal_error.InternalError) {
+	expiredAuctions, err := ar.findExpiredAuctions(ctx, auctionDuration, 0)
+	if err != nil {
+		ar.recordSweepFailure()
+		return nil, 0, err
+	}
+
+	ar.recordSweepSuccess()
+	if len(expiredAuctions) == 0 {
+		ar.recordCloseResult(0, ar.clock.Now())
+		return nil, 0, nil
+	}
+
+	expiredIds := make([]string, 0, len(expiredAuctions))
+	for _, auction := range expiredAuctions {
+		expiredIds = append(expiredIds, auction.Id)
+	}
+
+	ar.logger.Info("Dry-run: expired auctions would be closed",
+		zap.Int("count", len(expiredIds)),
+		zap.Strings("auction_ids", expiredIds))
+	return expiredIds, 0, nil
+}
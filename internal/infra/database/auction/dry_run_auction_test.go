@@ -0,0 +1,56 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCloseExpiredAuctionsDryRun(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithDryRun(true))
+	ctx := context.Background()
+
+	expiredAuction, _ := auction_entity.CreateAuction(
+		"Expired Product",
+		"Electronics",
+		"This auction should expire",
+		auction_entity.New,
+	)
+	expiredAuction.Timestamp = time.Now().Add(-20 * time.Minute)
+
+	activeAuction, _ := auction_entity.CreateAuction(
+		"Active Product",
+		"Electronics",
+		"This auction should remain active",
+		auction_entity.New,
+	)
+
+	repo.CreateAuction(ctx, expiredAuction)
+	repo.CreateAuction(ctx, activeAuction)
+
+	ids := repo.closeExpiredAuctions(ctx, 10*time.Minute)
+	if len(ids) != 1 || ids[0] != expiredAuction.Id {
+		t.Errorf("Expected would-close list to be [%s], got %v", expiredAuction.Id, ids)
+	}
+
+	// Em dry-run nenhum leilão é alterado
+	var expiredMongo AuctionEntityMongo
+	repo.Collection.FindOne(ctx, bson.M{"_id": expiredAuction.Id}).Decode(&expiredMongo)
+	if expiredMongo.Status != auction_entity.Active {
+		t.Errorf("Expected expired auction to remain Active in dry-run, got %d", expiredMongo.Status)
+	}
+
+	if repo.Stats().TotalClosed != 0 {
+		t.Errorf("Expected no closes recorded in dry-run, got %d", repo.Stats().TotalClosed)
+	}
+}
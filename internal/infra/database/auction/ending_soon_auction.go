@@ -0,0 +1,59 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindEndingSoon retorna até limit leilões ativos que terminam nos próximos within,
+// dos que terminam primeiro para os últimos. Leilões já expirados, ainda não fechados
+// pelo monitor, e documentos antigos sem end_time ficam de fora
+func (ar *AuctionRepository) FindEndingSoon(
+	ctx context.Context,
+	within time.Duration,
+	limit int) ([]auction_entity.Auction, *internal_error.InternalError) {
+	if within <= 0 || limit <= 0 {
+		return nil, internal_error.NewBadRequestError("within and limit must be greater than zero")
+	}
+
+	now := ar.clock.Now()
+	filter := bson.M{
+		"status": auction_entity.Active,
+		"end_time": bson.M{
+			"$gt":  now.Unix(),
+			"$lte": now.Add(within).Unix(),
+		},
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "end_time", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	cursor, err := ar.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		ar.logger.Error("Error finding auctions ending soon", err)
+		return nil, readError(err, "Error finding auctions ending soon")
+	}
+	defer cursor.Close(ctx)
+
+	var auctionsMongo []AuctionEntityMongo
+	if err := cursor.All(ctx, &auctionsMongo); err != nil {
+		ar.logger.Error("Error decoding auctions ending soon", err)
+		return nil, readError(err, "Error decoding auctions ending soon")
+	}
+
+	auctionsEntity := make([]auction_entity.Auction, 0, len(auctionsMongo))
+	for _, auction := range auctionsMongo {
+		auctionsEntity = append(auctionsEntity, *fromMongo(&auction))
+	}
+
+	return auctionsEntity, nil
+}
@@ -0,0 +1,69 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+)
+
+func TestFindEndingSoonReturnsAuctionsWithinWindowInOrder(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock), WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	ids := map[time.Duration]string{}
+	for _, duration := range []time.Duration{
+		30 * time.Minute, 5 * time.Minute, 2 * time.Hour, 15 * time.Minute, time.Second,
+	} {
+		auction, _ := auction_entity.CreateAuction(
+			"Test Product",
+			"Electronics",
+			"A test product for auction",
+			auction_entity.New,
+			auction_entity.WithDuration(duration),
+		)
+		auction.Timestamp = clock.Now()
+		if err := repo.CreateAuction(ctx, auction); err != nil {
+			t.Fatalf("Failed to create auction: %v", err)
+		}
+		ids[duration] = auction.Id
+	}
+
+	// O leilão de 1 segundo já expirou, mas ainda não foi fechado pelo monitor
+	clock.Advance(time.Minute)
+
+	auctions, err := repo.FindEndingSoon(ctx, time.Hour, 10)
+	if err != nil {
+		t.Fatalf("Failed to find auctions ending soon: %v", err)
+	}
+
+	expected := []string{ids[5*time.Minute], ids[15*time.Minute], ids[30*time.Minute]}
+	if len(auctions) != len(expected) {
+		t.Fatalf("Expected %d auctions ending soon, got %d", len(expected), len(auctions))
+	}
+	for i, id := range expected {
+		if auctions[i].Id != id {
+			t.Errorf("Expected auction %s at position %d, got %s", id, i, auctions[i].Id)
+		}
+	}
+
+	limited, _ := repo.FindEndingSoon(ctx, time.Hour, 1)
+	if len(limited) != 1 || limited[0].Id != ids[5*time.Minute] {
+		t.Errorf("Expected only the soonest-ending auction, got %+v", limited)
+	}
+}
+
+func TestFindEndingSoonValidatesArguments(t *testing.T) {
+	repo := &AuctionRepository{}
+
+	if _, err := repo.FindEndingSoon(context.Background(), 0, 10); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for an empty window, got %v", err)
+	}
+	if _, err := repo.FindEndingSoon(context.Background(), time.Hour, 0); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for a zero limit, got %v", err)
+	}
+}
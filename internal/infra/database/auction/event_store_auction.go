@@ -0,0 +1,130 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/event"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	AuctionBidPlacedEvent = "auction.bid_placed"
+	AuctionExtendedEvent  = "auction.extended"
+)
+
+type AuctionBidPlacedPayload struct {
+	AuctionId string               `json:"auction_id" bson:"auction_id"`
+	Amount    auction_entity.Money `json:"amount" bson:"amount"`
+}
+
+type AuctionExtendedPayload struct {
+	AuctionId string `json:"auction_id" bson:"auction_id"`
+	Seconds   int64  `json:"extended_by_seconds" bson:"extended_by_seconds"`
+}
+
+// StoredEvent é um evento de domínio guardado na coleção auction_events. O _id é um
+// ObjectID para desempatar, na ordem de gravação, eventos do mesmo instante
+type StoredEvent struct {
+	Id         primitive.ObjectID `bson:"_id" json:"-"`
+	EventId    string             `bson:"event_id" json:"event_id"`
+	AuctionId  string             `bson:"auction_id" json:"auction_id"`
+	Name       string             `bson:"name" json:"name"`
+	OccurredAt time.Time          `bson:"occurred_at" json:"occurred_at"`
+	Headers    map[string]string  `bson:"headers,omitempty" json:"headers,omitempty"`
+	Payload    bson.M             `bson:"payload" json:"payload"`
+}
+
+// WithEventStore faz o repositório guardar em auction_events todos os eventos que
+// emite, publicados ou não, para reconstruir o ciclo de vida de um leilão
+func WithEventStore(enabled bool) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.eventStoreEnabled = enabled
+	}
+}
+
+// GetAuctionEvents retorna os eventos registrados para o leilão na ordem em que ocorreram
+func (ar *AuctionRepository) GetAuctionEvents(
+	ctx context.Context, auctionId string) ([]StoredEvent, *internal_error.InternalError) {
+	if err := ar.checkAuctionId(auctionId); err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	cursor, err := ar.eventsCollection.Find(ctx, bson.M{"auction_id": auctionId}, opts)
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to find events of auction %s", auctionId), err)
+		return nil, readError(err, "Error trying to find auction events")
+	}
+	defer cursor.Close(ctx)
+
+	events := []StoredEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to decode events of auction %s", auctionId), err)
+		return nil, readError(err, "Error trying to decode auction events")
+	}
+
+	return events, nil
+}
+
+// recordEvent guarda o evento quando o payload identifica um leilão. Assim como o
+// histórico, o registro é auxiliar: falhas vão para o log e não afetam a operação
+func (ar *AuctionRepository) recordEvent(ctx context.Context, e event.Event) {
+	payload, err := toBsonM(e.Payload)
+	if err != nil {
+		ar.logger.Error("Error trying to encode event for the event store", err,
+			zap.String("event_id", e.Id))
+		return
+	}
+
+	auctionId, _ := payload["auction_id"].(string)
+	if auctionId == "" {
+		return
+	}
+
+	storedEvent := StoredEvent{
+		Id:         primitive.NewObjectID(),
+		EventId:    e.Id,
+		AuctionId:  auctionId,
+		Name:       e.Name,
+		OccurredAt: e.OccurredAt,
+		Headers:    e.Headers,
+		Payload:    payload,
+	}
+	if _, err := ar.eventsCollection.InsertOne(ctx, storedEvent); err != nil {
+		ar.logger.Error("Error trying to store event", err,
+			zap.String("event_id", e.Id),
+			zap.String("auction_id", auctionId))
+	}
+}
+
+// eventStorePublisher registra no event store os eventos emitidos fora do repositório,
+// como o AuctionCreated do caso de uso, antes de repassá-los
+type eventStorePublisher struct {
+	repository *AuctionRepository
+	next       event.Publisher
+}
+
+// RecordingPublisher envolve o publisher para que os eventos também sejam guardados
+// no event store do repositório, quando ele estiver habilitado
+func (ar *AuctionRepository) RecordingPublisher(next event.Publisher) event.Publisher {
+	return &eventStorePublisher{repository: ar, next: next}
+}
+
+func (p *eventStorePublisher) Publish(ctx context.Context, e event.Event) error {
+	if p.repository.eventStoreEnabled {
+		p.repository.recordEvent(ctx, e)
+	}
+
+	return p.next.Publish(ctx, e)
+}
@@ -0,0 +1,95 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/event"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetAuctionEventsReturnsLifecycleInOrder(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithEventStore(true), WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Replay",
+		"A test product for auction",
+		auction_entity.New,
+		auction_entity.WithDuration(time.Hour),
+	)
+	repo.CreateAuction(ctx, auction)
+
+	// O AuctionCreated é emitido pelo caso de uso, fora do repositório
+	next := &recordingPublisher{mutex: &sync.Mutex{}}
+	repo.RecordingPublisher(next).Publish(ctx, event.NewEvent("auction.created", map[string]string{
+		"auction_id": auction.Id,
+	}))
+
+	for _, amount := range []auction_entity.Money{1000, 2500} {
+		if err := repo.RecordBid(ctx, auction.Id, amount); err != nil {
+			t.Fatalf("Failed to record bid: %v", err)
+		}
+	}
+
+	found, _ := repo.FindAuctionById(ctx, auction.Id)
+	if err := repo.ExtendAuction(ctx, auction.Id, time.Minute, found.Version); err != nil {
+		t.Fatalf("Failed to extend auction: %v", err)
+	}
+	if _, err := repo.CloseAuctionsByCategory(ctx, "Replay"); err != nil {
+		t.Fatalf("Failed to close auction: %v", err)
+	}
+
+	other, _ := auction_entity.CreateAuction(
+		"Other Product", "Electronics", "A test product for auction", auction_entity.New)
+	repo.CreateAuction(ctx, other)
+	repo.RecordBid(ctx, other.Id, 500)
+
+	events, err := repo.GetAuctionEvents(ctx, auction.Id)
+	if err != nil {
+		t.Fatalf("Failed to get auction events: %v", err)
+	}
+
+	expected := []string{
+		"auction.created",
+		AuctionBidPlacedEvent,
+		AuctionBidPlacedEvent,
+		AuctionExtendedEvent,
+		AuctionClosedEvent,
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %+v", len(expected), events)
+	}
+	for i, stored := range events {
+		if stored.Name != expected[i] || stored.AuctionId != auction.Id {
+			t.Errorf("Expected event %d to be %s, got %+v", i, expected[i], stored)
+		}
+	}
+
+	if len(next.events) != 1 {
+		t.Errorf("Expected the created event to be forwarded, got %d events", len(next.events))
+	}
+}
+
+func TestGetAuctionEventsIsEmptyWhenStoreDisabled(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product", "Electronics", "A test product for auction", auction_entity.New)
+	repo.CreateAuction(ctx, auction)
+	repo.RecordBid(ctx, auction.Id, 1000)
+
+	events, err := repo.GetAuctionEvents(ctx, auction.Id)
+	if err != nil || len(events) != 0 {
+		t.Errorf("Expected no stored events, got %+v (%v)", events, err)
+	}
+}
@@ -0,0 +1,216 @@
+package auction
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"fullcycle-auction_go/internal/internal_error"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// ExportOption configura uma exportação de leilões
+type ExportOption func(*exportConfig)
+
+type exportConfig struct {
+	fields []string
+	gzip   bool
+}
+
+// WithExportFields restringe cada linha exportada aos campos informados, pelos nomes
+// do JSON exportado (por exemplo "id", "category", "current_high_bid")
+func WithExportFields(fields ...string) ExportOption {
+	return func(config *exportConfig) {
+		config.fields = fields
+	}
+}
+
+// WithExportGzip comprime o NDJSON exportado com gzip
+func WithExportGzip(enabled bool) ExportOption {
+	return func(config *exportConfig) {
+		config.gzip = enabled
+	}
+}
+
+// exportFields mapeia o nome JSON de cada campo exportável para o seu nome no MongoDB
+var exportFields = func() map[string]string {
+	fields := map[string]string{}
+	auctionType := reflect.TypeOf(AuctionEntityMongo{})
+	for i := 0; i < auctionType.NumField(); i++ {
+		field := auctionType.Field(i)
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		bsonName := strings.Split(field.Tag.Get("bson"), ",")[0]
+		if jsonName != "" && jsonName != "-" {
+			fields[jsonName] = bsonName
+		}
+	}
+
+	return fields
+}()
+
+// exportProjection valida os campos pedidos e monta a projeção do MongoDB. Sem campos,
+// todos são exportados
+func exportProjection(fields []string) (bson.M, *internal_error.InternalError) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	projection := bson.M{}
+	var unknown []string
+	for _, field := range fields {
+		bsonName, ok := exportFields[field]
+		if !ok {
+			unknown = append(unknown, field)
+			continue
+		}
+		projection[bsonName] = 1
+	}
+
+	if len(unknown) > 0 {
+		known := make([]string, 0, len(exportFields))
+		for field := range exportFields {
+			known = append(known, field)
+		}
+		sort.Strings(known)
+
+		return nil, internal_error.NewBadRequestError(fmt.Sprintf(
+			"Unknown export fields %s, expected any of %s",
+			strings.Join(unknown, ", "), strings.Join(known, ", ")))
+	}
+
+	return projection, nil
+}
+
+// ExportAuctions escreve todos os leilões em w como JSON delimitado por linha (NDJSON),
+// iterando o cursor sem carregar a coleção inteira em memória. Com WithExportFields,
+// só os campos pedidos são lidos e escritos; com WithExportGzip, a saída é comprimida
+func (ar *AuctionRepository) ExportAuctions(
+	ctx context.Context, w io.Writer, opts ...ExportOption) *internal_error.InternalError {
+	config := &exportConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	projection, projectionErr := exportProjection(config.fields)
+	if projectionErr != nil {
+		return projectionErr
+	}
+
+	findOptions := options.Find()
+	if projection != nil {
+		findOptions.SetProjection(projection)
+	}
+
+	cursor, err := ar.Collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		ar.logger.Error("Error trying to export auctions", err)
+		return internal_error.NewInternalServerError("Error trying to export auctions")
+	}
+	defer cursor.Close(ctx)
+
+	out := w
+	var gzipWriter *gzip.Writer
+	if config.gzip {
+		gzipWriter = gzip.NewWriter(w)
+		defer gzipWriter.Close()
+		out = gzipWriter
+	}
+
+	encoder := json.NewEncoder(out)
+	var exported int64
+	for cursor.Next(ctx) {
+		var auctionMongo AuctionEntityMongo
+		if err := cursor.Decode(&auctionMongo); err != nil {
+			ar.logger.Error("Error decoding auction to export", err)
+			return internal_error.NewInternalServerError("Error decoding auction to export")
+		}
+
+		if err := encoder.Encode(exportLine(auctionMongo, config.fields)); err != nil {
+			ar.logger.Error("Error writing exported auction", err)
+			return internal_error.NewInternalServerError("Error writing exported auction")
+		}
+
+		if err := flush(out); err != nil {
+			ar.logger.Error("Error flushing exported auctions", err)
+			return internal_error.NewInternalServerError("Error flushing exported auctions")
+		}
+		if out != w {
+			if err := flush(w); err != nil {
+				ar.logger.Error("Error flushing exported auctions", err)
+				return internal_error.NewInternalServerError("Error flushing exported auctions")
+			}
+		}
+		exported++
+	}
+
+	if err := cursor.Err(); err != nil {
+		ar.logger.Error("Auction export interrupted", err, zap.Int64("exported", exported))
+		return internal_error.NewInternalServerError("Auction export interrupted")
+	}
+
+	if err := ctx.Err(); err != nil {
+		ar.logger.Error("Auction export interrupted", err, zap.Int64("exported", exported))
+		return internal_error.NewInternalServerError("Auction export interrupted")
+	}
+
+	if gzipWriter != nil {
+		if err := gzipWriter.Close(); err != nil {
+			ar.logger.Error("Error finishing compressed auction export", err)
+			return internal_error.NewInternalServerError("Error finishing compressed auction export")
+		}
+		if err := flush(w); err != nil {
+			ar.logger.Error("Error flushing exported auctions", err)
+			return internal_error.NewInternalServerError("Error flushing exported auctions")
+		}
+	}
+
+	ar.logger.Info("Auctions exported", zap.Int64("exported", exported))
+	return nil
+}
+
+// exportLine devolve o leilão a ser escrito. Com projeção, só os campos pedidos ficam
+// na linha, já que os demais chegam zerados do MongoDB
+func exportLine(auctionMongo AuctionEntityMongo, fields []string) interface{} {
+	if len(fields) == 0 {
+		return auctionMongo
+	}
+
+	encoded, err := json.Marshal(auctionMongo)
+	if err != nil {
+		return auctionMongo
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &all); err != nil {
+		return auctionMongo
+	}
+
+	line := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			line[field] = value
+		}
+	}
+
+	return line
+}
+
+// flush descarrega writers com buffer (bufio.Writer, gzip.Writer, http.Flusher)
+// a cada linha exportada
+func flush(w io.Writer) error {
+	switch flusher := w.(type) {
+	case interface{ Flush() error }:
+		return flusher.Flush()
+	case interface{ Flush() }:
+		flusher.Flush()
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package auction
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+)
+
+func TestExportAuctions(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	seeded := map[string]bool{}
+	for i := 0; i < 5; i++ {
+		auction, _ := auction_entity.CreateAuction(
+			"Test Product",
+			"Electronics",
+			"A test product for auction",
+			auction_entity.New,
+		)
+		repo.CreateAuction(ctx, auction)
+		seeded[auction.Id] = true
+	}
+
+	var buffer bytes.Buffer
+	if err := repo.ExportAuctions(ctx, &buffer); err != nil {
+		t.Fatalf("Failed to export auctions: %v", err)
+	}
+
+	exported := 0
+	scanner := bufio.NewScanner(&buffer)
+	for scanner.Scan() {
+		var auctionMongo AuctionEntityMongo
+		if err := json.Unmarshal(scanner.Bytes(), &auctionMongo); err != nil {
+			t.Fatalf("Failed to parse exported line %q: %v", scanner.Text(), err)
+		}
+		if !seeded[auctionMongo.Id] {
+			t.Errorf("Unexpected auction %s in export", auctionMongo.Id)
+		}
+		exported++
+	}
+
+	if exported != len(seeded) {
+		t.Errorf("Expected %d exported auctions, got %d", len(seeded), exported)
+	}
+}
+
+func TestExportAuctionsStopsOnCancelledContext(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	repo.CreateAuction(context.Background(), auction)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buffer bytes.Buffer
+	if err := repo.ExportAuctions(ctx, &buffer); err == nil {
+		t.Error("Expected export to fail with a cancelled context")
+	}
+}
+
+func TestExportAuctionsWithProjectionAndGzip(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		auction, _ := auction_entity.CreateAuction(
+			"Test Product",
+			"Electronics",
+			"A test product for auction",
+			auction_entity.New,
+		)
+		repo.CreateAuction(ctx, auction)
+	}
+
+	var buffer bytes.Buffer
+	err := repo.ExportAuctions(ctx, &buffer,
+		WithExportFields("id", "category", "current_high_bid"), WithExportGzip(true))
+	if err != nil {
+		t.Fatalf("Failed to export auctions: %v", err)
+	}
+
+	reader, gzipErr := gzip.NewReader(&buffer)
+	if gzipErr != nil {
+		t.Fatalf("Expected a gzip stream: %v", gzipErr)
+	}
+
+	exported := 0
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("Failed to parse exported line %q: %v", scanner.Text(), err)
+		}
+		if len(line) != 3 || line["id"] == nil || line["category"] != "Electronics" ||
+			line["current_high_bid"] == nil {
+			t.Errorf("Expected only id, category and current_high_bid, got %v", line)
+		}
+		exported++
+	}
+
+	if exported != 3 {
+		t.Errorf("Expected 3 exported auctions, got %d", exported)
+	}
+}
+
+func TestExportAuctionsRejectsUnknownFields(t *testing.T) {
+	_, err := exportProjection([]string{"id", "password"})
+	if err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for an unknown field, got %v", err)
+	}
+
+	projection, err := exportProjection([]string{"id", "product_name"})
+	if err != nil || projection["_id"] != 1 || projection["product_name"] != 1 {
+		t.Errorf("Expected the projection to use MongoDB field names, got %v (%v)", projection, err)
+	}
+}
@@ -0,0 +1,133 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/event"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// ExtendAuction adia o fim de um leilão ativo na versão expectedVersion. A soma das
+// extensões de um leilão é limitada por AUCTION_MAX_EXTENSION, e o monitor passa a
+// considerar o novo fim. Um leilão cujo fim já passou não é reaberto, mesmo que o
+// monitor ainda não o tenha fechado
+func (ar *AuctionRepository) ExtendAuction(
+	ctx context.Context,
+	id string,
+	by time.Duration,
+	expectedVersion int64) *internal_error.InternalError {
+	if err := ar.checkAuctionId(id); err != nil {
+		return err
+	}
+
+	seconds := int64(by.Seconds())
+	if seconds <= 0 {
+		return internal_error.NewBadRequestError("Auction extension must be at least one second")
+	}
+
+	maxSeconds := int64(ar.maxExtension.Seconds())
+	if seconds > maxSeconds {
+		return ar.extensionLimitError(id)
+	}
+
+	// O limite e o fim ficam no filtro para que extensões concorrentes não ultrapassem
+	// o limite nem ressuscitem um leilão que expirou entre a leitura e a escrita
+	now := ar.clock.Now()
+	filter := bson.M{
+		"_id":      ar.DocumentId(id),
+		"status":   auction_entity.Active,
+		"end_time": bson.M{"$exists": true, "$gt": now.Unix()},
+		"version":  versionMatch(expectedVersion),
+		"$or": bson.A{
+			bson.M{"extension_seconds": bson.M{"$lte": maxSeconds - seconds}},
+			bson.M{"extension_seconds": bson.M{"$exists": false}},
+		},
+	}
+	update := bson.M{"$inc": bson.M{
+		"end_time":          seconds,
+		"extension_seconds": seconds,
+		"version":           1,
+	}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	ar.readCache.invalidate(id)
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to extend auction %s", id), err)
+		return internal_error.NewInternalServerError("Error trying to extend auction")
+	}
+
+	if result.MatchedCount == 0 {
+		return ar.unextendableAuctionError(ctx, id, expectedVersion, now)
+	}
+
+	ar.logger.Info("Auction extended", logger.ContextFields(ctx,
+		zap.String("auction_id", id),
+		zap.Duration("by", by))...)
+	ar.publish(ctx, event.NewEvent(AuctionExtendedEvent, AuctionExtendedPayload{
+		AuctionId: id,
+		Seconds:   seconds,
+	}))
+	return nil
+}
+
+// unextendableAuctionError explica por que a extensão não encontrou o leilão
+func (ar *AuctionRepository) unextendableAuctionError(
+	ctx context.Context,
+	id string,
+	expectedVersion int64,
+	now time.Time) *internal_error.InternalError {
+	var auctionEntityMongo AuctionEntityMongo
+	err := ar.Collection.FindOne(ctx, bson.M{"_id": ar.DocumentId(id)}).Decode(&auctionEntityMongo)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return internal_error.NewNotFoundError(fmt.Sprintf("Auction not found with this id = %s", id))
+	}
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to find auction by id = %s", id), err)
+		return internal_error.NewInternalServerError("Error trying to find auction by id")
+	}
+
+	if auctionEntityMongo.Status != auction_entity.Active {
+		return internal_error.NewBadRequestError(fmt.Sprintf("Auction %s is not active", id))
+	}
+
+	if auctionEntityMongo.EndTime == 0 {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("Auction %s has no end time and cannot be extended", id))
+	}
+
+	if auctionEntityMongo.EndTime <= now.Unix() {
+		return internal_error.NewConflictError(
+			fmt.Sprintf("Auction %s has already expired and cannot be extended", id))
+	}
+
+	if auctionEntityMongo.Version != expectedVersion {
+		return versionConflictError(id, expectedVersion, auctionEntityMongo.Version)
+	}
+
+	return ar.extensionLimitError(id)
+}
+
+func (ar *AuctionRepository) extensionLimitError(id string) *internal_error.InternalError {
+	return internal_error.NewBadRequestError(fmt.Sprintf(
+		"Auction %s cannot be extended beyond %s in total", id, ar.maxExtension))
+}
+
+// getMaxExtension retorna o total de extensão permitido por leilão baseado em
+// AUCTION_MAX_EXTENSION. Se não estiver definido, retorna 1 hora como padrão
+func getMaxExtension() time.Duration {
+	maxExtension, err := time.ParseDuration(os.Getenv("AUCTION_MAX_EXTENSION"))
+	if err != nil || maxExtension < 0 {
+		return time.Hour
+	}
+
+	return maxExtension
+}
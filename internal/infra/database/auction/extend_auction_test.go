@@ -0,0 +1,146 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestExtendAuctionKeepsAuctionOpenPastOriginalExpiry(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+		auction_entity.WithDuration(time.Minute),
+	)
+	auction.Timestamp = clock.Now()
+	repo.CreateAuction(ctx, auction)
+
+	if err := repo.ExtendAuction(ctx, auction.Id, 30*time.Minute, 0); err != nil {
+		t.Fatalf("Failed to extend auction: %v", err)
+	}
+
+	clock.Advance(5 * time.Minute)
+	if ids := repo.closeExpiredAuctions(ctx, repo.auctionDuration); len(ids) != 0 {
+		t.Errorf("Expected extended auction to stay open, closed %v", ids)
+	}
+
+	clock.Advance(30 * time.Minute)
+	if ids := repo.closeExpiredAuctions(ctx, repo.auctionDuration); len(ids) != 1 {
+		t.Errorf("Expected extended auction to close after its new end, closed %v", ids)
+	}
+}
+
+func TestExtendAuctionRejectsCompletedAuction(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	auction.Status = auction_entity.Completed
+	repo.CreateAuction(ctx, auction)
+
+	err := repo.ExtendAuction(ctx, auction.Id, time.Minute, 0)
+	if err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request when extending a completed auction, got %v", err)
+	}
+
+	if err := repo.ExtendAuction(ctx, uuid.NewString(), time.Minute, 0); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for a missing auction, got %v", err)
+	}
+}
+
+func TestExtendAuctionRejectsExpiredAuctionNotYetClosed(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+		auction_entity.WithDuration(time.Minute),
+	)
+	auction.Timestamp = clock.Now()
+	repo.CreateAuction(ctx, auction)
+
+	// O fim passou, mas nenhuma varredura fechou o leilão ainda
+	clock.Advance(2 * time.Minute)
+	err := repo.ExtendAuction(ctx, auction.Id, 30*time.Minute, 0)
+	if err == nil || err.Err != "conflict" {
+		t.Fatalf("Expected conflict when extending an expired auction, got %v", err)
+	}
+
+	if ids := repo.closeExpiredAuctions(ctx, repo.auctionDuration); len(ids) != 1 {
+		t.Errorf("Expected the expired auction to still close, closed %v", ids)
+	}
+}
+
+func TestExtendAuctionCapsTotalExtension(t *testing.T) {
+	os.Setenv("AUCTION_MAX_EXTENSION", "1h")
+	defer os.Unsetenv("AUCTION_MAX_EXTENSION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	repo.CreateAuction(ctx, auction)
+
+	if err := repo.ExtendAuction(ctx, auction.Id, 40*time.Minute, 0); err != nil {
+		t.Fatalf("Failed to extend auction: %v", err)
+	}
+
+	err := repo.ExtendAuction(ctx, auction.Id, 30*time.Minute, 1)
+	if err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request when exceeding the extension cap, got %v", err)
+	}
+
+	if err := repo.ExtendAuction(ctx, auction.Id, 20*time.Minute, 1); err != nil {
+		t.Errorf("Expected extension up to the cap to succeed, got %v", err)
+	}
+}
+
+func TestExtendAuctionValidatesDuration(t *testing.T) {
+	repo := &AuctionRepository{maxExtension: time.Hour}
+
+	for _, by := range []time.Duration{0, -time.Minute, 2 * time.Hour} {
+		err := repo.ExtendAuction(context.Background(), "auction-id", by, 0)
+		if err == nil || err.Err != "bad_request" {
+			t.Errorf("Expected bad_request when extending by %v, got %v", by, err)
+		}
+	}
+}
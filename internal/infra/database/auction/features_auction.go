@@ -0,0 +1,113 @@
+package auction
+
+import (
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// Features concentra as flags que ligam ou desligam comportamentos do repositório.
+// Todas ficam desligadas por padrão
+type Features struct {
+	// LeaderElection faz só uma instância fechar leilões por vez (AUCTION_LEADER_ELECTION)
+	LeaderElection bool
+	// CloseDryRun faz o monitor apenas registrar o que fecharia (AUCTION_CLOSE_DRY_RUN)
+	CloseDryRun bool
+	// AtomicClose fecha os leilões expirados um a um (AUCTION_ATOMIC_CLOSE)
+	AtomicClose bool
+	// MonitorDisabled desliga o monitor interno (AUCTION_MONITOR_DISABLED)
+	MonitorDisabled bool
+	// Outbox grava os eventos no outbox em vez de publicá-los (AUCTION_EVENT_OUTBOX_ENABLED)
+	Outbox bool
+	// OutboxDrainerDisabled desliga o drainer do outbox (AUCTION_OUTBOX_DRAINER_DISABLED)
+	OutboxDrainerDisabled bool
+	// EventStore guarda os eventos emitidos em auction_events (AUCTION_EVENT_STORE_ENABLED)
+	EventStore bool
+	// ReconcilerDisabled desliga o reconciliador de agendados (AUCTION_RECONCILER_DISABLED)
+	ReconcilerDisabled bool
+	// Reopen libera a reabertura de leilões encerrados (AUCTION_REOPEN_ENABLED)
+	Reopen bool
+	// CategoryBrowsing cria o índice da navegação por categoria (AUCTION_CATEGORY_BROWSING_ENABLED)
+	CategoryBrowsing bool
+}
+
+// LoadFeatures lê as flags das variáveis de ambiente. Um valor que não é booleano
+// deixa a flag desligada e é reportado no erro, para que a aplicação falhe na subida
+func LoadFeatures() (Features, *internal_error.InternalError) {
+	var features Features
+	var invalidFields []internal_error.FieldError
+
+	flags := []struct {
+		env   string
+		value *bool
+	}{
+		{"AUCTION_LEADER_ELECTION", &features.LeaderElection},
+		{"AUCTION_CLOSE_DRY_RUN", &features.CloseDryRun},
+		{"AUCTION_ATOMIC_CLOSE", &features.AtomicClose},
+		{"AUCTION_MONITOR_DISABLED", &features.MonitorDisabled},
+		{"AUCTION_EVENT_OUTBOX_ENABLED", &features.Outbox},
+		{"AUCTION_OUTBOX_DRAINER_DISABLED", &features.OutboxDrainerDisabled},
+		{"AUCTION_EVENT_STORE_ENABLED", &features.EventStore},
+		{"AUCTION_RECONCILER_DISABLED", &features.ReconcilerDisabled},
+		{"AUCTION_REOPEN_ENABLED", &features.Reopen},
+		{"AUCTION_CATEGORY_BROWSING_ENABLED", &features.CategoryBrowsing},
+	}
+
+	for _, flag := range flags {
+		raw := os.Getenv(flag.env)
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			invalidFields = append(invalidFields, internal_error.FieldError{
+				Field:   flag.env,
+				Message: "must be a boolean",
+			})
+			continue
+		}
+		*flag.value = value
+	}
+
+	if len(invalidFields) > 0 {
+		return features, internal_error.NewValidationError("Invalid feature flags", invalidFields)
+	}
+
+	return features, nil
+}
+
+// Fields descreve as flags para o log de inicialização
+func (f Features) Fields() []zap.Field {
+	return []zap.Field{
+		zap.Bool("leader_election", f.LeaderElection),
+		zap.Bool("close_dry_run", f.CloseDryRun),
+		zap.Bool("atomic_close", f.AtomicClose),
+		zap.Bool("monitor_disabled", f.MonitorDisabled),
+		zap.Bool("outbox", f.Outbox),
+		zap.Bool("outbox_drainer_disabled", f.OutboxDrainerDisabled),
+		zap.Bool("event_store", f.EventStore),
+		zap.Bool("reconciler_disabled", f.ReconcilerDisabled),
+		zap.Bool("reopen", f.Reopen),
+		zap.Bool("category_browsing", f.CategoryBrowsing),
+	}
+}
+
+// WithFeatures aplica as flags ao repositório. Opções específicas passadas depois,
+// como WithDryRun, continuam podendo sobrescrevê-las
+func WithFeatures(features Features) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.leaderElection = features.LeaderElection
+		ar.dryRun = features.CloseDryRun
+		ar.atomicClose = features.AtomicClose
+		ar.monitorDisabled = features.MonitorDisabled
+		ar.outboxEnabled = features.Outbox
+		ar.drainerDisabled = features.OutboxDrainerDisabled
+		ar.eventStoreEnabled = features.EventStore
+		ar.reconcilerDisabled = features.ReconcilerDisabled
+		ar.reopenEnabled = features.Reopen
+		ar.categoryBrowsing = features.CategoryBrowsing
+	}
+}
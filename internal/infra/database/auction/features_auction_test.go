@@ -0,0 +1,113 @@
+package auction
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var featureEnvs = []string{
+	"AUCTION_LEADER_ELECTION",
+	"AUCTION_CLOSE_DRY_RUN",
+	"AUCTION_ATOMIC_CLOSE",
+	"AUCTION_MONITOR_DISABLED",
+	"AUCTION_EVENT_OUTBOX_ENABLED",
+	"AUCTION_OUTBOX_DRAINER_DISABLED",
+	"AUCTION_EVENT_STORE_ENABLED",
+	"AUCTION_RECONCILER_DISABLED",
+	"AUCTION_REOPEN_ENABLED",
+	"AUCTION_CATEGORY_BROWSING_ENABLED",
+}
+
+func clearFeatureEnvs(t *testing.T) {
+	for _, env := range featureEnvs {
+		t.Setenv(env, "")
+	}
+}
+
+func TestLoadFeaturesDefaultsOff(t *testing.T) {
+	clearFeatureEnvs(t)
+
+	features, err := LoadFeatures()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if features != (Features{}) {
+		t.Errorf("Expected every feature to be off, got %+v", features)
+	}
+}
+
+func TestLoadFeaturesParsesEnv(t *testing.T) {
+	clearFeatureEnvs(t)
+	t.Setenv("AUCTION_LEADER_ELECTION", "true")
+	t.Setenv("AUCTION_CLOSE_DRY_RUN", "1")
+	t.Setenv("AUCTION_EVENT_OUTBOX_ENABLED", "TRUE")
+	t.Setenv("AUCTION_REOPEN_ENABLED", "false")
+
+	features, err := LoadFeatures()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := Features{LeaderElection: true, CloseDryRun: true, Outbox: true}
+	if features != expected {
+		t.Errorf("Expected %+v, got %+v", expected, features)
+	}
+}
+
+func TestLoadFeaturesRejectsInvalidValues(t *testing.T) {
+	clearFeatureEnvs(t)
+	t.Setenv("AUCTION_ATOMIC_CLOSE", "yes")
+	t.Setenv("AUCTION_EVENT_STORE_ENABLED", "true")
+
+	features, err := LoadFeatures()
+	if err == nil || err.Err != "bad_request" {
+		t.Fatalf("Expected a bad_request error, got %v", err)
+	}
+	if len(err.Fields) != 1 || err.Fields[0].Field != "AUCTION_ATOMIC_CLOSE" {
+		t.Errorf("Expected AUCTION_ATOMIC_CLOSE to be reported, got %+v", err.Fields)
+	}
+	if features.AtomicClose || !features.EventStore {
+		t.Errorf("Expected the invalid flag off and the valid one parsed, got %+v", features)
+	}
+}
+
+func TestNewAuctionRepositoryAppliesEveryFeature(t *testing.T) {
+	for _, env := range featureEnvs {
+		t.Setenv(env, "true")
+	}
+
+	// O cliente só conecta na primeira operação, e com o monitor desligado o
+	// repositório não inicia nenhuma goroutine que a faça
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:1"))
+	if err != nil {
+		t.Fatalf("Failed to create mongo client: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	repo := NewAuctionRepository(client.Database("auctions_test"))
+	defer repo.Shutdown(context.Background())
+
+	applied := Features{
+		LeaderElection:        repo.leaderElection,
+		CloseDryRun:           repo.dryRun,
+		AtomicClose:           repo.atomicClose,
+		MonitorDisabled:       repo.monitorDisabled,
+		Outbox:                repo.outboxEnabled,
+		OutboxDrainerDisabled: repo.drainerDisabled,
+		EventStore:            repo.eventStoreEnabled,
+		ReconcilerDisabled:    repo.reconcilerDisabled,
+		Reopen:                repo.reopenEnabled,
+		CategoryBrowsing:      repo.categoryBrowsing,
+	}
+	expected := Features{
+		LeaderElection: true, CloseDryRun: true, AtomicClose: true, MonitorDisabled: true,
+		Outbox: true, OutboxDrainerDisabled: true, EventStore: true, ReconcilerDisabled: true,
+		Reopen: true, CategoryBrowsing: true,
+	}
+	if applied != expected {
+		t.Errorf("Expected every feature from the environment to be applied, got %+v", applied)
+	}
+}
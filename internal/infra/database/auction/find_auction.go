@@ -2,80 +2,291 @@ package auction
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+	"regexp"
 	"time"
 )
 
 func (ar *AuctionRepository) FindAuctionById(
 	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
-	filter := bson.M{"_id": id}
+	if err := ar.checkAuctionId(id); err != nil {
+		return nil, err
+	}
+
+	if cached, ok := ar.readCache.get(id, ar.clock.Now()); ok {
+		return cached, nil
+	}
+	generation := ar.readCache.currentGeneration()
+
+	auction, err := ar.findAuctionInStore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ar.readCache.put(auction, generation, ar.clock.Now())
+
+	return auction, nil
+}
+
+// FindAuctionByIdUncached lê o leilão direto do MongoDB, sem passar pelo cache de
+// leitura. É a leitura usada pelo caminho dos lances, que não pode decidir com um
+// leilão desatualizado por uma escrita feita em outra réplica
+func (ar *AuctionRepository) FindAuctionByIdUncached(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	if err := ar.checkAuctionId(id); err != nil {
+		return nil, err
+	}
+
+	return ar.findAuctionInStore(ctx, id)
+}
+
+func (ar *AuctionRepository) findAuctionInStore(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	filter := bson.M{"_id": ar.DocumentId(id)}
 
 	var auctionEntityMongo AuctionEntityMongo
-	if err := ar.Collection.FindOne(ctx, filter).Decode(&auctionEntityMongo); err != nil {
-		logger.Error(fmt.Sprintf("Error trying to find auction by id = %s", id), err)
-		return nil, internal_error.NewInternalServerError("Error trying to find auction by id")
-	}
-
-	return &auction_entity.Auction{
-		Id:          auctionEntityMongo.Id,
-		ProductName: auctionEntityMongo.ProductName,
-		Category:    auctionEntityMongo.Category,
-		Description: auctionEntityMongo.Description,
-		Condition:   auctionEntityMongo.Condition,
-		Status:      auctionEntityMongo.Status,
-		Timestamp:   time.Unix(auctionEntityMongo.Timestamp, 0),
-	}, nil
+	err := ar.Collection.FindOne(ctx, filter).Decode(&auctionEntityMongo)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, internal_error.NewNotFoundError(fmt.Sprintf("Auction not found with this id = %s", id))
+	}
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to find auction by id = %s", id), err,
+			logger.ContextFields(ctx, zap.String("auction_id", id))...)
+		return nil, readError(err, "Error trying to find auction by id")
+	}
+
+	return fromMongo(&auctionEntityMongo), nil
 }
 
 func (repo *AuctionRepository) FindAuctions(
 	ctx context.Context,
 	status auction_entity.AuctionStatus,
 	category string,
-	productName string) ([]auction_entity.Auction, *internal_error.InternalError) {
-	filter := bson.M{}
+	productName string,
+	sortOrder auction_entity.AuctionSortOrder,
+	viewerId string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	// Nesta consulta o status 0 significa "todos os status"
+	if status == 0 {
+		status = auction_entity.AnyStatus
+	}
+	filter := auctionsFilter(status, category, productName, viewerId)
 
-	if status != 0 {
-		filter["status"] = status
+	opts := options.Find()
+	switch sortOrder {
+	case auction_entity.HighBidAscending:
+		opts.SetSort(bson.D{{Key: "current_high_bid", Value: 1}, {Key: "_id", Value: 1}})
+	case auction_entity.HighBidDescending:
+		opts.SetSort(bson.D{{Key: "current_high_bid", Value: -1}, {Key: "_id", Value: 1}})
+	case auction_entity.NewestFirst:
+		// Com categoria e status, a ordenação usa o índice de navegação por categoria
+		opts.SetSort(bson.D{{Key: "timestamp", Value: -1}})
 	}
 
-	if category != "" {
-		filter["category"] = category
+	ctx, cancel := withTimeout(ctx, repo.readTimeout)
+	defer cancel()
+
+	cursor, err := repo.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		repo.logger.Error("Error finding auctions", err)
+		return nil, readError(err, "Error finding auctions")
 	}
+	defer cursor.Close(ctx)
 
-	if productName != "" {
-		filter["productName"] = primitive.Regex{Pattern: productName, Options: "i"}
+	// Um documento que não decodifica, como um enum inválido gravado à mão, é
+	// ignorado com um aviso para não derrubar a listagem inteira
+	var auctionsEntity []auction_entity.Auction
+	for cursor.Next(ctx) {
+		var auction AuctionEntityMongo
+		if err := cursor.Decode(&auction); err != nil {
+			repo.logger.Warn("Skipping auction that failed to decode",
+				zap.String("auction_id", rawDocumentId(cursor.Current)),
+				zap.Error(err))
+			continue
+		}
+
+		auctionsEntity = append(auctionsEntity, *fromMongo(&auction))
+	}
+	if err := cursor.Err(); err != nil {
+		repo.logger.Error("Error decoding auctions", err)
+		return nil, readError(err, "Error decoding auctions")
+	}
+
+	return auctionsEntity, nil
+}
+
+// rawDocumentId extrai o _id de um documento não decodificado, para o log
+func rawDocumentId(document bson.Raw) string {
+	value := document.Lookup("_id")
+	if id, ok := value.StringValueOK(); ok {
+		return id
 	}
+	if objectId, ok := value.ObjectIDOK(); ok {
+		return objectId.Hex()
+	}
+
+	return value.String()
+}
+
+// FindAuctionsPage retorna uma página dos leilões que atendem aos filtros e o total
+// de leilões que os atendem. auction_entity.AnyStatus não filtra por status
+func (ar *AuctionRepository) FindAuctionsPage(
+	ctx context.Context,
+	status auction_entity.AuctionStatus,
+	category, productName string,
+	offset, limit int,
+	viewerId string) ([]auction_entity.Auction, int64, *internal_error.InternalError) {
+	filter := auctionsFilter(status, category, productName, viewerId)
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	total, err := ar.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		ar.logger.Error("Error counting auctions", err)
+		return nil, 0, readError(err, "Error counting auctions")
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
 
-	cursor, err := repo.Collection.Find(ctx, filter)
+	cursor, err := ar.Collection.Find(ctx, filter, opts)
 	if err != nil {
-		logger.Error("Error finding auctions", err)
-		return nil, internal_error.NewInternalServerError("Error finding auctions")
+		ar.logger.Error("Error finding auctions", err)
+		return nil, 0, readError(err, "Error finding auctions")
 	}
 	defer cursor.Close(ctx)
 
 	var auctionsMongo []AuctionEntityMongo
 	if err := cursor.All(ctx, &auctionsMongo); err != nil {
-		logger.Error("Error decoding auctions", err)
-		return nil, internal_error.NewInternalServerError("Error decoding auctions")
+		ar.logger.Error("Error decoding auctions", err)
+		return nil, 0, readError(err, "Error decoding auctions")
 	}
 
-	var auctionsEntity []auction_entity.Auction
+	auctionsEntity := make([]auction_entity.Auction, 0, len(auctionsMongo))
+	for _, auction := range auctionsMongo {
+		auctionsEntity = append(auctionsEntity, *fromMongo(&auction))
+	}
+
+	return auctionsEntity, total, nil
+}
+
+// auctionsFilter monta o filtro das listagens; a busca por nome do produto é
+// por trecho, sem diferenciar maiúsculas, e trata o texto literalmente
+func auctionsFilter(
+	status auction_entity.AuctionStatus, category, productName, viewerId string) bson.M {
+	filter := bson.M{"$or": visibleToFilter(viewerId)}
+
+	if status != auction_entity.AnyStatus {
+		filter["status"] = status
+	}
+
+	if category != "" {
+		filter["category"] = category
+	}
+
+	if productName != "" {
+		filter["product_name"] = primitive.Regex{Pattern: regexp.QuoteMeta(productName), Options: "i"}
+	}
+
+	return filter
+}
+
+// visibleToFilter seleciona os leilões públicos (inclusive os gravados antes do campo
+// visibility) e, com um usuário, os privados em que ele é dono ou convidado
+func visibleToFilter(viewerId string) bson.A {
+	visible := bson.A{bson.M{"visibility": bson.M{"$ne": auction_entity.Private}}}
+	if viewerId != "" {
+		visible = append(visible,
+			bson.M{"owner_id": viewerId},
+			bson.M{"allowed_user_ids": viewerId})
+	}
+
+	return visible
+}
+
+func (ar *AuctionRepository) FindExpiredAuctionIds(
+	ctx context.Context, auctionDuration time.Duration) ([]string, *internal_error.InternalError) {
+	auctionsMongo, err := ar.findExpiredAuctions(ctx, auctionDuration, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	expiredIds := make([]string, 0, len(auctionsMongo))
 	for _, auction := range auctionsMongo {
-		auctionsEntity = append(auctionsEntity, auction_entity.Auction{
-			Id:          auction.Id,
-			ProductName: auction.ProductName,
-			Category:    auction.Category,
-			Status:      auction.Status,
-			Description: auction.Description,
-			Condition:   auction.Condition,
-			Timestamp:   time.Unix(auction.Timestamp, 0),
+		expiredIds = append(expiredIds, auction.Id)
+	}
+
+	return expiredIds, nil
+}
+
+// findExpiredAuctions busca os leilões ativos já expirados, trazendo apenas os campos
+// necessários para calcular o momento da expiração de cada um. Um leilão só é
+// considerado expirado depois de passada a folga closeGrace. limit 0 traz todos
+func (ar *AuctionRepository) findExpiredAuctions(
+	ctx context.Context,
+	auctionDuration time.Duration,
+	limit int64) ([]AuctionEntityMongo, *internal_error.InternalError) {
+	now := ar.clock.Now().Add(-ar.closeGrace)
+
+	// Leilões com end_time expiram pelo próprio fim; documentos antigos, sem end_time,
+	// expiram pela duração da sua categoria ou pela duração global
+	conditions := bson.A{bson.M{"end_time": bson.M{"$lte": now.Unix()}}}
+
+	categories := make([]string, 0, len(ar.categoryDurations))
+	for category, duration := range ar.categoryDurations {
+		categories = append(categories, category)
+		conditions = append(conditions, bson.M{
+			"end_time":  bson.M{"$exists": false},
+			"category":  category,
+			"timestamp": bson.M{"$lte": now.Add(-duration).Unix()},
 		})
 	}
 
-	return auctionsEntity, nil
+	conditions = append(conditions, bson.M{
+		"end_time":  bson.M{"$exists": false},
+		"category":  bson.M{"$nin": categories},
+		"timestamp": bson.M{"$lte": now.Add(-auctionDuration).Unix()},
+	})
+
+	filter := bson.M{
+		"status": auction_entity.Active,
+		"$or":    conditions,
+	}
+
+	opts := options.Find().SetProjection(bson.M{
+		"_id":       1,
+		"category":  1,
+		"timestamp": 1,
+		"end_time":  1,
+	})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+	cursor, err := ar.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		ar.logger.Error("Error trying to find expired auctions", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find expired auctions")
+	}
+	defer cursor.Close(ctx)
+
+	var auctionsMongo []AuctionEntityMongo
+	if err := cursor.All(ctx, &auctionsMongo); err != nil {
+		ar.logger.Error("Error decoding expired auctions", err)
+		return nil, internal_error.NewInternalServerError("Error decoding expired auctions")
+	}
+
+	return auctionsMongo, nil
 }
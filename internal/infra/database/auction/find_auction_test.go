@@ -0,0 +1,281 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFindExpiredAuctionIds(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	expiredIds := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		expiredAuction, _ := auction_entity.CreateAuction(
+			"Expired Product",
+			"Electronics",
+			"This auction should expire",
+			auction_entity.New,
+		)
+		expiredAuction.Timestamp = time.Now().Add(-20 * time.Minute)
+		repo.CreateAuction(ctx, expiredAuction)
+		expiredIds[expiredAuction.Id] = true
+	}
+
+	activeAuction, _ := auction_entity.CreateAuction(
+		"Active Product",
+		"Electronics",
+		"This auction should remain active",
+		auction_entity.New,
+	)
+	repo.CreateAuction(ctx, activeAuction)
+
+	ids, err := repo.FindExpiredAuctionIds(ctx, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to find expired auctions: %v", err)
+	}
+
+	if len(ids) != len(expiredIds) {
+		t.Fatalf("Expected %d expired auction ids, got %v", len(expiredIds), ids)
+	}
+	for _, id := range ids {
+		if !expiredIds[id] {
+			t.Errorf("Unexpected auction id %s in expired list", id)
+		}
+	}
+}
+
+func TestFindAuctionsSortedByCurrentHighBid(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	for _, price := range []auction_entity.Money{200, 50, 350, 125} {
+		auction, _ := auction_entity.CreateAuction(
+			"Test Product",
+			"Electronics",
+			"A test product for auction",
+			auction_entity.New,
+		)
+		repo.CreateAuction(ctx, auction)
+		repo.RecordBid(ctx, auction.Id, price)
+	}
+
+	tests := []struct {
+		sortOrder auction_entity.AuctionSortOrder
+		expected  []auction_entity.Money
+	}{
+		{sortOrder: auction_entity.HighBidAscending, expected: []auction_entity.Money{50, 125, 200, 350}},
+		{sortOrder: auction_entity.HighBidDescending, expected: []auction_entity.Money{350, 200, 125, 50}},
+	}
+
+	for _, tt := range tests {
+		auctions, err := repo.FindAuctions(ctx, 0, "", "", tt.sortOrder, "")
+		if err != nil {
+			t.Fatalf("Failed to find auctions: %v", err)
+		}
+		if len(auctions) != len(tt.expected) {
+			t.Fatalf("Expected %d auctions, got %d", len(tt.expected), len(auctions))
+		}
+		for i, auction := range auctions {
+			if auction.CurrentHighBid != tt.expected[i] {
+				t.Errorf("Sort order %d: expected high bid %s at position %d, got %s",
+					tt.sortOrder, tt.expected[i], i, auction.CurrentHighBid)
+			}
+		}
+	}
+}
+
+func TestFindAuctionsByCategoryNewestFirst(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithCategoryBrowsing(true))
+	ctx := context.Background()
+	if err := repo.EnsureIndexes(ctx); err != nil {
+		t.Fatalf("Failed to ensure indexes: %v", err)
+	}
+
+	base := time.Now().Add(-time.Hour)
+	var ids []string
+	for i, category := range []string{"Music", "Electronics", "Music", "Music"} {
+		auction, _ := auction_entity.CreateAuction(
+			"Test Product",
+			category,
+			"A test product for auction",
+			auction_entity.New,
+		)
+		auction.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		repo.CreateAuction(ctx, auction)
+		if category == "Music" {
+			ids = append(ids, auction.Id)
+		}
+	}
+
+	auctions, err := repo.FindAuctions(ctx, auction_entity.Active, "Music", "", auction_entity.NewestFirst, "")
+	if err != nil {
+		t.Fatalf("Failed to find auctions: %v", err)
+	}
+
+	expected := []string{ids[2], ids[1], ids[0]}
+	if len(auctions) != len(expected) {
+		t.Fatalf("Expected %d auctions, got %d", len(expected), len(auctions))
+	}
+	for i, id := range expected {
+		if auctions[i].Id != id {
+			t.Errorf("Expected auction %s at position %d, got %s", id, i, auctions[i].Id)
+		}
+	}
+}
+
+func TestFindAuctionsSkipsMalformedDocuments(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	capturing := newCapturingLogger()
+	repo := newTestRepository(t, db, WithMonitorDisabled(true), WithLogger(capturing))
+	ctx := context.Background()
+
+	var validIds []string
+	for i := 0; i < 2; i++ {
+		auction, _ := auction_entity.CreateAuction(
+			"Test Product",
+			"Electronics",
+			"A test product for auction",
+			auction_entity.New,
+		)
+		repo.CreateAuction(ctx, auction)
+		validIds = append(validIds, auction.Id)
+	}
+
+	// Uma edição manual deixou a condição do produto com um valor desconhecido
+	malformedId := uuid.New().String()
+	_, err := repo.Collection.InsertOne(ctx, bson.M{
+		"_id":          malformedId,
+		"product_name": "Test Product",
+		"category":     "Electronics",
+		"status":       "active",
+		"condition":    "broken",
+		"timestamp":    time.Now().Unix(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to insert malformed auction: %v", err)
+	}
+
+	auctions, findErr := repo.FindAuctions(ctx, 0, "Electronics", "", auction_entity.DefaultSortOrder, "")
+	if findErr != nil {
+		t.Fatalf("Expected the valid auctions despite the malformed one, got %v", findErr)
+	}
+	if len(auctions) != len(validIds) {
+		t.Fatalf("Expected %d auctions, got %d", len(validIds), len(auctions))
+	}
+	for _, auction := range auctions {
+		if auction.Id == malformedId {
+			t.Errorf("Expected the malformed auction to be skipped")
+		}
+	}
+
+	log, ok := capturing.find("Skipping auction that failed to decode")
+	if !ok {
+		t.Fatalf("Expected the malformed auction to be logged, got %+v", capturing.logs)
+	}
+	if log.level != "warn" || log.fields["auction_id"] != malformedId {
+		t.Errorf("Expected a warning for auction %s, got %+v", malformedId, log)
+	}
+}
+
+func TestFindAuctionsPage(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		auction, _ := auction_entity.CreateAuction(
+			"Vintage Guitar (1965)",
+			"Music",
+			"A test product for auction",
+			auction_entity.New,
+		)
+		auction.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		if i == 0 {
+			auction.Status = auction_entity.Completed
+		}
+		repo.CreateAuction(ctx, auction)
+	}
+
+	auctions, total, err := repo.FindAuctionsPage(ctx, auction_entity.Active, "Music", "guitar (1965", 0, 3, "")
+	if err != nil {
+		t.Fatalf("Failed to find auctions page: %v", err)
+	}
+	if total != 4 {
+		t.Errorf("Expected 4 matching auctions, got %d", total)
+	}
+	if len(auctions) != 3 {
+		t.Fatalf("Expected a page of 3 auctions, got %d", len(auctions))
+	}
+	if !auctions[0].Timestamp.After(auctions[1].Timestamp) {
+		t.Errorf("Expected the most recent auctions first")
+	}
+
+	_, total, _ = repo.FindAuctionsPage(ctx, auction_entity.AnyStatus, "", "", 0, 10, "")
+	if total != 5 {
+		t.Errorf("Expected 5 auctions with any status, got %d", total)
+	}
+}
+
+func TestFindAuctionsFiltersPrivateAuctionsByViewer(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	owner, invited := uuid.NewString(), uuid.NewString()
+	public, _ := auction_entity.CreateAuction(
+		"Test Product", "Electronics", "A test product for auction", auction_entity.New)
+	private, _ := auction_entity.CreateAuction(
+		"Test Product", "Electronics", "A test product for auction", auction_entity.New,
+		auction_entity.WithOwnerId(owner),
+		auction_entity.WithVisibility(auction_entity.Private),
+		auction_entity.WithAllowedUserIds(invited))
+	repo.CreateAuction(ctx, public)
+	repo.CreateAuction(ctx, private)
+
+	for viewerId, expected := range map[string]int{"": 1, uuid.NewString(): 1, invited: 2, owner: 2} {
+		auctions, err := repo.FindAuctions(ctx, auction_entity.AnyStatus, "", "", auction_entity.DefaultSortOrder, viewerId)
+		if err != nil {
+			t.Fatalf("Failed to find auctions: %v", err)
+		}
+		if len(auctions) != expected {
+			t.Errorf("Expected %d auctions for viewer %q, got %d", expected, viewerId, len(auctions))
+		}
+	}
+}
+
+func TestFindAuctionByIdNotFound(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+
+	if _, err := repo.FindAuctionById(context.Background(), uuid.NewString()); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for a missing auction, got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package auction
+
+import (
+	"os"
+	"time"
+)
+
+// WithCloseGrace define por quanto tempo um leilão precisa estar expirado antes de
+// ser fechado pela varredura
+func WithCloseGrace(grace time.Duration) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		if grace >= 0 {
+			ar.closeGrace = grace
+		}
+	}
+}
+
+// getCloseGrace lê AUCTION_CLOSE_GRACE, uma folga somada ao fim dos leilões para
+// absorver diferenças de relógio e lances ainda em trânsito. O padrão é 0 (sem folga)
+func getCloseGrace() time.Duration {
+	grace, err := time.ParseDuration(os.Getenv("AUCTION_CLOSE_GRACE"))
+	if err != nil || grace < 0 {
+		return 0
+	}
+
+	return grace
+}
@@ -0,0 +1,61 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/testutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCloseGraceDelaysClosingExpiredAuctions(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db,
+		WithClock(clock),
+		WithMonitorDisabled(true),
+		WithCloseGrace(30*time.Second))
+	ctx := context.Background()
+
+	seeded, err := testutil.SeedAuctions(ctx, repo, 1,
+		testutil.WithTimestamp(clock.Now()), testutil.WithDuration(time.Minute))
+	if err != nil {
+		t.Fatalf("Failed to seed auction: %v", err)
+	}
+
+	// Expirado há 10s, ainda dentro da folga
+	clock.Advance(70 * time.Second)
+	if closed, _ := repo.ProcessExpiredAuctionsOnce(ctx); closed != 0 {
+		t.Errorf("Expected no auction closed within the grace period, got %d", closed)
+	}
+	found, _ := repo.FindAuctionById(ctx, seeded[0].Id)
+	if found.Status != auction_entity.Active {
+		t.Errorf("Expected auction to remain Active within the grace period, got %s", found.Status)
+	}
+
+	// Expirado há 31s, depois da folga
+	clock.Advance(21 * time.Second)
+	if closed, _ := repo.ProcessExpiredAuctionsOnce(ctx); closed != 1 {
+		t.Errorf("Expected the auction to close after the grace period, got %d", closed)
+	}
+}
+
+func TestGetCloseGrace(t *testing.T) {
+	defer os.Unsetenv("AUCTION_CLOSE_GRACE")
+
+	testCases := map[string]time.Duration{
+		"":      0,
+		"15s":   15 * time.Second,
+		"-5s":   0,
+		"later": 0,
+	}
+	for value, expected := range testCases {
+		os.Setenv("AUCTION_CLOSE_GRACE", value)
+		if grace := getCloseGrace(); grace != expected {
+			t.Errorf("Expected %v for %q, got %v", expected, value, grace)
+		}
+	}
+}
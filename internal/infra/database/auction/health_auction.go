@@ -0,0 +1,60 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// monitorStallFactor define quantos intervalos do monitor podem passar sem um tick
+// antes de considerarmos a goroutine de monitoramento travada
+const monitorStallFactor = 3
+
+// Health verifica se o MongoDB está acessível e se o monitor de leilões expirados
+// executou um tick dentro da janela esperada. Com o monitor desligado, apenas o
+// MongoDB é verificado
+func (ar *AuctionRepository) Health(ctx context.Context) error {
+	if err := ar.Collection.Database().Client().Ping(ctx, nil); err != nil {
+		return fmt.Errorf("mongodb is unreachable: %w", err)
+	}
+
+	if ar.monitorDisabled {
+		return nil
+	}
+
+	lastTickAt := ar.LastTickAt()
+	window := ar.monitorStallWindow()
+	if elapsed := ar.clock.Now().Sub(lastTickAt); elapsed > window {
+		return fmt.Errorf(
+			"auction expiration monitor appears stalled: last tick %s ago, expected within %s",
+			elapsed.Round(time.Second), window)
+	}
+
+	return nil
+}
+
+// monitorStallWindow é o maior atraso possível até o próximo tick, com o backoff atual
+// e o jitter máximo, multiplicado por monitorStallFactor. Usar só o intervalo base
+// daria o monitor como travado enquanto ele espera o backoff após falhas do MongoDB
+func (ar *AuctionRepository) monitorStallWindow() time.Duration {
+	maxDelay := time.Duration(float64(ar.monitorBackoff()) * (1 + ar.monitorJitter))
+	return maxDelay * monitorStallFactor
+}
+
+// LastTickAt retorna o momento do último tick do monitor
+func (ar *AuctionRepository) LastTickAt() time.Time {
+	ar.lastTickMutex.Lock()
+	defer ar.lastTickMutex.Unlock()
+
+	return ar.lastTickAt
+}
+
+func (ar *AuctionRepository) recordTick(at time.Time) {
+	ar.lastTickMutex.Lock()
+	ar.lastTickAt = at
+	ar.lastTickMutex.Unlock()
+
+	ar.statsMutex.Lock()
+	ar.stats.TotalTicks++
+	ar.statsMutex.Unlock()
+}
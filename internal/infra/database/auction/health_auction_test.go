@@ -0,0 +1,32 @@
+package auction
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestHealth(t *testing.T) {
+	// Duração longa para que o monitor não execute um tick durante o teste
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	// Simula um tick recente do monitor
+	repo.recordTick(time.Now())
+	if err := repo.Health(ctx); err != nil {
+		t.Fatalf("Expected repository to be healthy after a tick, got %v", err)
+	}
+
+	// Envelhece artificialmente o último tick para além da janela esperada
+	repo.recordTick(time.Now().Add(-repo.monitorInterval * (monitorStallFactor + 1)))
+	if err := repo.Health(ctx); err == nil {
+		t.Error("Expected health check to fail when the monitor appears stalled")
+	}
+}
@@ -0,0 +1,111 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Motivos registrados no histórico de status dos leilões
+const (
+	TransitionCreated       = "created"
+	TransitionExpired       = "expired"
+	TransitionManual        = "manual"
+	TransitionReopened      = "reopened"
+	TransitionCategoryClose = "category_close"
+	TransitionActivated     = "activated"
+)
+
+// AuctionStatusTransition é uma entrada do histórico de status de um leilão.
+// FromStatus é nil na criação, quando o leilão ainda não tinha status. Nas
+// transferências, FromOwnerId e ToOwnerId registram a troca de dono
+type AuctionStatusTransition struct {
+	Id            primitive.ObjectID            `bson:"_id" json:"-"`
+	AuctionId     string                        `bson:"auction_id" json:"auction_id"`
+	FromStatus    *auction_entity.AuctionStatus `bson:"from_status,omitempty" json:"from_status,omitempty"`
+	ToStatus      auction_entity.AuctionStatus  `bson:"to_status" json:"to_status"`
+	Reason        string                        `bson:"reason" json:"reason"`
+	FromOwnerId   string                        `bson:"from_owner_id,omitempty" json:"from_owner_id,omitempty"`
+	ToOwnerId     string                        `bson:"to_owner_id,omitempty" json:"to_owner_id,omitempty"`
+	CorrelationId string                        `bson:"correlation_id,omitempty" json:"correlation_id,omitempty"`
+	ActedBy       string                        `bson:"acted_by,omitempty" json:"acted_by,omitempty"`
+	Timestamp     time.Time                     `bson:"timestamp" json:"timestamp"`
+}
+
+// GetAuctionHistory retorna as transições de status do leilão em ordem cronológica
+func (ar *AuctionRepository) GetAuctionHistory(
+	ctx context.Context, id string) ([]AuctionStatusTransition, *internal_error.InternalError) {
+	if err := ar.checkAuctionId(id); err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}})
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	cursor, err := ar.historyCollection.Find(ctx, bson.M{"auction_id": id}, opts)
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to find history of auction %s", id), err)
+		return nil, readError(err, "Error trying to find auction history")
+	}
+	defer cursor.Close(ctx)
+
+	history := []AuctionStatusTransition{}
+	if err := cursor.All(ctx, &history); err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to decode history of auction %s", id), err)
+		return nil, readError(err, "Error trying to decode auction history")
+	}
+
+	return history, nil
+}
+
+// recordTransitions grava a mesma transição para vários leilões. O histórico é
+// auxiliar: uma falha é registrada no log mas não desfaz a mudança de status
+func (ar *AuctionRepository) recordTransitions(
+	ctx context.Context,
+	auctionIds []string,
+	from *auction_entity.AuctionStatus,
+	to auction_entity.AuctionStatus,
+	reason string) {
+	if len(auctionIds) == 0 {
+		return
+	}
+
+	now := ar.clock.Now()
+	correlationId := logger.CorrelationId(ctx)
+	actedBy := logger.ActingUser(ctx)
+
+	entries := make([]interface{}, 0, len(auctionIds))
+	for _, auctionId := range auctionIds {
+		entries = append(entries, AuctionStatusTransition{
+			Id:            primitive.NewObjectID(),
+			AuctionId:     auctionId,
+			FromStatus:    from,
+			ToStatus:      to,
+			Reason:        reason,
+			CorrelationId: correlationId,
+			ActedBy:       actedBy,
+			Timestamp:     now,
+		})
+	}
+
+	if _, err := ar.historyCollection.InsertMany(ctx, entries); err != nil {
+		ar.logger.Error("Error trying to record auction status history", err,
+			zap.String("reason", reason),
+			zap.Strings("auction_ids", auctionIds))
+	}
+}
+
+// statusPtr facilita informar o status de origem de uma transição
+func statusPtr(status auction_entity.AuctionStatus) *auction_entity.AuctionStatus {
+	return &status
+}
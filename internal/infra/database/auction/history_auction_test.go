@@ -0,0 +1,139 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+)
+
+func TestGetAuctionHistory(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock))
+	ctx := context.Background()
+
+	expiring, _ := auction_entity.CreateAuction(
+		"Expiring Product",
+		"Electronics",
+		"This auction should expire",
+		auction_entity.New,
+		auction_entity.WithDuration(time.Minute),
+	)
+	expiring.Timestamp = clock.Now()
+	repo.CreateAuction(ctx, expiring)
+
+	closing, _ := auction_entity.CreateAuction(
+		"Closing Product",
+		"Electronics",
+		"This auction is closed manually",
+		auction_entity.New,
+		auction_entity.WithDuration(time.Hour),
+	)
+	closing.Timestamp = clock.Now()
+	repo.CreateAuction(ctx, closing)
+
+	clock.Advance(time.Second)
+	if err := repo.CloseAuction(ctx, closing.Id); err != nil {
+		t.Fatalf("Failed to close auction: %v", err)
+	}
+
+	clock.Advance(2 * time.Minute)
+	repo.closeExpiredAuctions(ctx, repo.auctionDuration)
+
+	tests := []struct {
+		auctionId string
+		reasons   []string
+	}{
+		{auctionId: expiring.Id, reasons: []string{TransitionCreated, TransitionExpired}},
+		{auctionId: closing.Id, reasons: []string{TransitionCreated, TransitionManual}},
+	}
+
+	for _, tt := range tests {
+		history, err := repo.GetAuctionHistory(ctx, tt.auctionId)
+		if err != nil {
+			t.Fatalf("Failed to get auction history: %v", err)
+		}
+		if len(history) != len(tt.reasons) {
+			t.Fatalf("Expected %d transitions, got %+v", len(tt.reasons), history)
+		}
+
+		for i, transition := range history {
+			if transition.Reason != tt.reasons[i] {
+				t.Errorf("Expected transition %d to be %q, got %q", i, tt.reasons[i], transition.Reason)
+			}
+		}
+
+		if history[0].FromStatus != nil || history[0].ToStatus != auction_entity.Active {
+			t.Errorf("Expected creation to move to Active, got %+v", history[0])
+		}
+
+		last := history[len(history)-1]
+		if last.FromStatus == nil || *last.FromStatus != auction_entity.Active ||
+			last.ToStatus != auction_entity.Completed {
+			t.Errorf("Expected Active -> Completed transition, got %+v", last)
+		}
+		if last.Timestamp.Before(history[0].Timestamp) {
+			t.Errorf("Expected history in chronological order, got %+v", history)
+		}
+	}
+}
+
+func TestAuctionHistoryRecordsActingUser(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock), WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	expiring, _ := auction_entity.CreateAuction(
+		"Expiring Product",
+		"Electronics",
+		"This auction should expire",
+		auction_entity.New,
+		auction_entity.WithDuration(time.Minute),
+	)
+	expiring.Timestamp = clock.Now()
+	repo.CreateAuction(ctx, expiring)
+
+	closing, _ := auction_entity.CreateAuction(
+		"Closing Product",
+		"Electronics",
+		"This auction is closed manually",
+		auction_entity.New,
+		auction_entity.WithDuration(time.Hour),
+	)
+	closing.Timestamp = clock.Now()
+	repo.CreateAuction(ctx, closing)
+
+	if err := repo.CloseAuction(logger.WithActingUser(ctx, "admin-1"), closing.Id); err != nil {
+		t.Fatalf("Failed to close auction: %v", err)
+	}
+
+	clock.Advance(2 * time.Minute)
+	repo.closeExpiredAuctions(ctx, repo.auctionDuration)
+
+	tests := []struct {
+		auctionId string
+		actedBy   string
+	}{
+		{auctionId: closing.Id, actedBy: "admin-1"},
+		{auctionId: expiring.Id, actedBy: logger.SystemActor},
+	}
+
+	for _, tt := range tests {
+		history, err := repo.GetAuctionHistory(ctx, tt.auctionId)
+		if err != nil || len(history) == 0 {
+			t.Fatalf("Failed to get auction history: %v", err)
+		}
+
+		last := history[len(history)-1]
+		if last.ToStatus != auction_entity.Completed || last.ActedBy != tt.actedBy {
+			t.Errorf("Expected the close to be attributed to %q, got %+v", tt.actedBy, last)
+		}
+	}
+}
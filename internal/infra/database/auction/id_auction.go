@@ -0,0 +1,129 @@
+package auction
+
+import (
+	"fmt"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuctionIdMode define como o _id dos leilões é gravado no MongoDB
+type AuctionIdMode string
+
+const (
+	// UUIDIdMode grava o _id como a string UUID do leilão. É o padrão
+	UUIDIdMode AuctionIdMode = "uuid"
+	// ObjectIdMode grava o _id como ObjectID, e o ID do leilão passa a ser o seu hexadecimal
+	ObjectIdMode AuctionIdMode = "objectid"
+)
+
+// WithIdMode define o formato do _id dos leilões criados e consultados pelo repositório
+func WithIdMode(mode AuctionIdMode) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.idMode = mode
+	}
+}
+
+// getIdMode lê AUCTION_ID_MODE. Qualquer valor diferente de "objectid" mantém o UUID
+func getIdMode() AuctionIdMode {
+	if AuctionIdMode(strings.ToLower(os.Getenv("AUCTION_ID_MODE"))) == ObjectIdMode {
+		return ObjectIdMode
+	}
+
+	return UUIDIdMode
+}
+
+// newAuctionId gera o ID de um leilão no formato do modo configurado
+func (ar *AuctionRepository) newAuctionId() string {
+	if ar.idMode == ObjectIdMode {
+		return primitive.NewObjectID().Hex()
+	}
+
+	return uuid.New().String()
+}
+
+// checkAuctionId rejeita IDs fora do formato do modo configurado antes de qualquer
+// consulta, já que nenhum leilão poderia ser encontrado com eles
+func (ar *AuctionRepository) checkAuctionId(id string) *internal_error.InternalError {
+	if ar.idMode == ObjectIdMode {
+		if !primitive.IsValidObjectID(id) {
+			return internal_error.NewValidationError("Invalid auction id", []internal_error.FieldError{
+				{Field: "auction_id", Message: fmt.Sprintf("invalid ObjectID value: %q", id)},
+			})
+		}
+
+		return nil
+	}
+
+	if uuid.Validate(id) != nil {
+		return internal_error.NewValidationError("Invalid auction id", []internal_error.FieldError{
+			{Field: "auction_id", Message: fmt.Sprintf("invalid UUID value: %q", id)},
+		})
+	}
+
+	return nil
+}
+
+// checkAuctionIds aplica checkAuctionId a cada um dos IDs
+func (ar *AuctionRepository) checkAuctionIds(ids []string) *internal_error.InternalError {
+	for _, id := range ids {
+		if err := ar.checkAuctionId(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DocumentId converte o ID do leilão no valor gravado em _id, para os filtros de quem
+// acessa a coleção de leilões diretamente. A leitura não precisa de conversão: o
+// driver decodifica um ObjectID em string como o seu hexadecimal
+func (ar *AuctionRepository) DocumentId(id string) interface{} {
+	if ar.idMode == ObjectIdMode {
+		if objectId, err := primitive.ObjectIDFromHex(id); err == nil {
+			return objectId
+		}
+	}
+
+	return id
+}
+
+// documentIds aplica DocumentId a cada um dos IDs, para filtros com $in
+func (ar *AuctionRepository) documentIds(ids []string) bson.A {
+	values := make(bson.A, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, ar.DocumentId(id))
+	}
+
+	return values
+}
+
+// auctionDocument prepara o leilão para inserção. No modo ObjectID, o documento é
+// convertido para bson.D para que o _id seja gravado como ObjectID em vez de string
+func (ar *AuctionRepository) auctionDocument(auction *AuctionEntityMongo) (interface{}, error) {
+	if ar.idMode != ObjectIdMode {
+		return auction, nil
+	}
+
+	data, err := bson.Marshal(auction)
+	if err != nil {
+		return nil, err
+	}
+
+	var document bson.D
+	if err := bson.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	for i := range document {
+		if document[i].Key == "_id" {
+			document[i].Value = ar.DocumentId(auction.Id)
+		}
+	}
+
+	return document, nil
+}
@@ -0,0 +1,123 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMalformedAuctionIdIsRejectedBeforeQuerying(t *testing.T) {
+	// Um servidor inalcançável: qualquer consulta falharia com internal_server_error
+	client, err := mongo.Connect(context.Background(), options.Client().
+		ApplyURI("mongodb://127.0.0.1:1").
+		SetServerSelectionTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	repo := newTestRepository(t, client.Database("auctions_test"), WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	for _, id := range []string{"", "missing", "123", uuid.NewString() + "x"} {
+		if _, err := repo.FindAuctionById(ctx, id); err == nil || err.Err != "bad_request" {
+			t.Errorf("Expected bad_request for id %q, got %v", id, err)
+		}
+		if err := repo.CloseAuction(ctx, id); err == nil || err.Err != "bad_request" {
+			t.Errorf("Expected CloseAuction to reject id %q, got %v", id, err)
+		}
+	}
+
+	if _, err := repo.FindAuctionById(ctx, uuid.NewString()); err == nil || err.Err != "internal_server_error" {
+		t.Errorf("Expected a well-formed id to reach the database, got %v", err)
+	}
+}
+
+func TestAuctionDocumentEncodesIdByMode(t *testing.T) {
+	objectId := primitive.NewObjectID()
+
+	uuidRepo := &AuctionRepository{idMode: UUIDIdMode}
+	document, err := uuidRepo.auctionDocument(&AuctionEntityMongo{Id: "some-id"})
+	if err != nil || document.(*AuctionEntityMongo).Id != "some-id" {
+		t.Errorf("Expected the document to be kept in UUID mode, got %v (%v)", document, err)
+	}
+
+	objectIdRepo := &AuctionRepository{idMode: ObjectIdMode}
+	document, err = objectIdRepo.auctionDocument(&AuctionEntityMongo{Id: objectId.Hex(), ProductName: "Test Product"})
+	if err != nil {
+		t.Fatalf("Failed to build document: %v", err)
+	}
+	fields := document.(bson.D).Map()
+	if fields["_id"] != objectId || fields["product_name"] != "Test Product" {
+		t.Errorf("Expected _id to be encoded as ObjectID %v, got %+v", objectId, fields)
+	}
+}
+
+func TestCreateAndFindAuctionInBothIdModes(t *testing.T) {
+	for _, tc := range []struct {
+		mode      AuctionIdMode
+		idType    bsontype.Type
+		foreignId string
+	}{
+		{UUIDIdMode, bsontype.String, primitive.NewObjectID().Hex()},
+		{ObjectIdMode, bsontype.ObjectID, uuid.NewString()},
+	} {
+		t.Run(string(tc.mode), func(t *testing.T) {
+			db, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			repo := newTestRepository(t, db, WithIdMode(tc.mode), WithMonitorDisabled(true))
+			ctx := context.Background()
+
+			auction, _ := auction_entity.CreateAuction(
+				"Test Product", "Electronics", "A test product for auction", auction_entity.New)
+			if err := repo.CreateAuction(ctx, auction); err != nil {
+				t.Fatalf("Failed to create auction: %v", err)
+			}
+			if err := repo.checkAuctionId(auction.Id); err != nil {
+				t.Fatalf("Expected the created id %q to match the mode: %v", auction.Id, err)
+			}
+
+			raw, err := db.Collection("auctions").FindOne(ctx, bson.M{}).Raw()
+			if err != nil {
+				t.Fatalf("Failed to read raw auction: %v", err)
+			}
+			if idType := raw.Lookup("_id").Type; idType != tc.idType {
+				t.Errorf("Expected _id stored as %v, got %v", tc.idType, idType)
+			}
+
+			if err := repo.RecordBid(ctx, auction.Id, 1500); err != nil {
+				t.Fatalf("Failed to record bid: %v", err)
+			}
+
+			found, findErr := repo.FindAuctionById(ctx, auction.Id)
+			if findErr != nil {
+				t.Fatalf("Failed to find auction: %v", findErr)
+			}
+			if found.Id != auction.Id || found.BidCount != 1 || found.CurrentHighBid != 1500 {
+				t.Errorf("Expected auction %s with one bid of 15.00, got %+v", auction.Id, found)
+			}
+
+			byIds, idsErr := repo.FindAuctionsByIds(ctx, []string{auction.Id})
+			if idsErr != nil || len(byIds) != 1 || byIds[0].Id != auction.Id {
+				t.Errorf("Expected to find the auction by ids, got %+v (%v)", byIds, idsErr)
+			}
+
+			if err := repo.CloseAuction(ctx, auction.Id); err != nil {
+				t.Errorf("Failed to close auction: %v", err)
+			}
+
+			if _, err := repo.FindAuctionById(ctx, tc.foreignId); err == nil || err.Err != "bad_request" {
+				t.Errorf("Expected an id of the other mode to be rejected, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// FindAuctionsByIds busca vários leilões em uma única consulta, devolvendo-os na
+// ordem dos IDs informados. IDs inexistentes são ignorados e IDs repetidos aparecem
+// uma única vez
+func (ar *AuctionRepository) FindAuctionsByIds(
+	ctx context.Context, ids []string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	if len(ids) == 0 {
+		return []auction_entity.Auction{}, nil
+	}
+	if err := ar.checkAuctionIds(ids); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	cursor, err := ar.Collection.Find(ctx, bson.M{"_id": bson.M{"$in": ar.documentIds(ids)}})
+	if err != nil {
+		ar.logger.Error("Error finding auctions by ids", err, zap.Int("ids", len(ids)))
+		return nil, readError(err, "Error finding auctions by ids")
+	}
+	defer cursor.Close(ctx)
+
+	var auctionsMongo []AuctionEntityMongo
+	if err := cursor.All(ctx, &auctionsMongo); err != nil {
+		ar.logger.Error("Error decoding auctions by ids", err)
+		return nil, readError(err, "Error decoding auctions by ids")
+	}
+
+	byId := make(map[string]AuctionEntityMongo, len(auctionsMongo))
+	for _, auction := range auctionsMongo {
+		byId[auction.Id] = auction
+	}
+
+	auctionsEntity := make([]auction_entity.Auction, 0, len(auctionsMongo))
+	for _, id := range ids {
+		auction, ok := byId[id]
+		if !ok {
+			continue
+		}
+		delete(byId, id)
+
+		auctionsEntity = append(auctionsEntity, *fromMongo(&auction))
+	}
+
+	return auctionsEntity, nil
+}
+
+// GetStatuses busca o status de vários leilões em uma única consulta, projetando
+// apenas o status. IDs inexistentes ficam fora do mapa
+func (ar *AuctionRepository) GetStatuses(
+	ctx context.Context, ids []string) (map[string]auction_entity.AuctionStatus, *internal_error.InternalError) {
+	statuses := make(map[string]auction_entity.AuctionStatus, len(ids))
+	if len(ids) == 0 {
+		return statuses, nil
+	}
+	if err := ar.checkAuctionIds(ids); err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "status": 1})
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	cursor, err := ar.Collection.Find(ctx, bson.M{"_id": bson.M{"$in": ar.documentIds(ids)}}, opts)
+	if err != nil {
+		ar.logger.Error("Error finding auction statuses by ids", err, zap.Int("ids", len(ids)))
+		return nil, readError(err, "Error finding auction statuses by ids")
+	}
+	defer cursor.Close(ctx)
+
+	var auctionsMongo []AuctionEntityMongo
+	if err := cursor.All(ctx, &auctionsMongo); err != nil {
+		ar.logger.Error("Error decoding auction statuses by ids", err)
+		return nil, readError(err, "Error decoding auction statuses by ids")
+	}
+
+	for _, auction := range auctionsMongo {
+		statuses[auction.Id] = auction.Status
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,93 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFindAuctionsByIds(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	seeded, err := testutil.SeedAuctions(ctx, repo, 3)
+	if err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+
+	ids := []string{seeded[2].Id, uuid.NewString(), seeded[0].Id, seeded[2].Id}
+	auctions, findErr := repo.FindAuctionsByIds(ctx, ids)
+	if findErr != nil {
+		t.Fatalf("Failed to find auctions by ids: %v", findErr)
+	}
+
+	if len(auctions) != 2 {
+		t.Fatalf("Expected 2 auctions, got %d", len(auctions))
+	}
+	if auctions[0].Id != seeded[2].Id || auctions[1].Id != seeded[0].Id {
+		t.Errorf("Expected auctions in the requested order, got %s, %s",
+			auctions[0].Id, auctions[1].Id)
+	}
+}
+
+func TestFindAuctionsByIdsEmpty(t *testing.T) {
+	repo := &AuctionRepository{}
+
+	auctions, err := repo.FindAuctionsByIds(context.Background(), nil)
+	if err != nil || auctions == nil || len(auctions) != 0 {
+		t.Errorf("Expected an empty result without querying, got %v (%v)", auctions, err)
+	}
+}
+
+func TestGetStatuses(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	seeded, err := testutil.SeedAuctions(ctx, repo, 3)
+	if err != nil {
+		t.Fatalf("Failed to seed auctions: %v", err)
+	}
+	if err := repo.CloseAuction(ctx, seeded[1].Id); err != nil {
+		t.Fatalf("Failed to close auction: %v", err)
+	}
+
+	unknownId := uuid.NewString()
+	statuses, statusErr := repo.GetStatuses(ctx, []string{seeded[0].Id, seeded[1].Id, unknownId})
+	if statusErr != nil {
+		t.Fatalf("Failed to get statuses: %v", statusErr)
+	}
+
+	expected := map[string]auction_entity.AuctionStatus{
+		seeded[0].Id: auction_entity.Active,
+		seeded[1].Id: auction_entity.Completed,
+	}
+	if len(statuses) != len(expected) {
+		t.Fatalf("Expected %d statuses, got %v", len(expected), statuses)
+	}
+	for id, status := range expected {
+		if statuses[id] != status {
+			t.Errorf("Expected auction %s to be %s, got %s", id, status, statuses[id])
+		}
+	}
+	if _, ok := statuses[unknownId]; ok {
+		t.Errorf("Expected the unknown id to be omitted, got %v", statuses)
+	}
+}
+
+func TestGetStatusesEmpty(t *testing.T) {
+	repo := &AuctionRepository{}
+
+	statuses, err := repo.GetStatuses(context.Background(), nil)
+	if err != nil || statuses == nil || len(statuses) != 0 {
+		t.Errorf("Expected an empty map without querying, got %v (%v)", statuses, err)
+	}
+}
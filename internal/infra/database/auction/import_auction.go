@@ -0,0 +1,174 @@
+package auction
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// ImportAuctions lê leilões em JSON delimitado por linha (o mesmo formato de ExportAuctions),
+// valida cada um pela entidade e os insere em lotes não ordenados. Linhas inválidas não
+// interrompem a importação: elas são reportadas, com o número da linha, no erro retornado
+func (ar *AuctionRepository) ImportAuctions(
+	ctx context.Context, r io.Reader) (imported int, err *internal_error.InternalError) {
+	batchSize := getImportBatchSize()
+
+	var lineErrors []string
+	var batch []interface{}
+	var batchLines []int
+
+	insertBatch := func() *internal_error.InternalError {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		inserted, failedLines, insertErr := ar.insertImportBatch(ctx, batch, batchLines)
+		imported += inserted
+		lineErrors = append(lineErrors, failedLines...)
+		batch, batchLines = nil, nil
+
+		return insertErr
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		auctionMongo, lineErr := ar.parseImportLine(scanner.Bytes())
+		if lineErr != "" {
+			lineErrors = append(lineErrors, fmt.Sprintf("line %d: %s", line, lineErr))
+			continue
+		}
+
+		document, encodeErr := ar.auctionDocument(auctionMongo)
+		if encodeErr != nil {
+			lineErrors = append(lineErrors, fmt.Sprintf("line %d: %s", line, encodeErr.Error()))
+			continue
+		}
+
+		batch = append(batch, document)
+		batchLines = append(batchLines, line)
+
+		if len(batch) >= batchSize {
+			if insertErr := insertBatch(); insertErr != nil {
+				return imported, insertErr
+			}
+		}
+	}
+
+	if scanErr := scanner.Err(); scanErr != nil {
+		ar.logger.Error("Error reading auctions to import", scanErr)
+		return imported, internal_error.NewBadRequestError("Error reading auctions to import")
+	}
+
+	if insertErr := insertBatch(); insertErr != nil {
+		return imported, insertErr
+	}
+
+	ar.logger.Info("Auctions imported",
+		zap.Int("imported", imported),
+		zap.Int("invalid_lines", len(lineErrors)))
+
+	if len(lineErrors) > 0 {
+		return imported, internal_error.NewBadRequestError(
+			fmt.Sprintf("%d invalid auction(s) skipped: %s",
+				len(lineErrors), strings.Join(lineErrors, "; ")))
+	}
+
+	return imported, nil
+}
+
+// parseImportLine decodifica e valida uma linha, retornando a descrição do erro
+// quando a linha é inválida
+func (ar *AuctionRepository) parseImportLine(data []byte) (*AuctionEntityMongo, string) {
+	var auctionMongo AuctionEntityMongo
+	if err := json.Unmarshal(data, &auctionMongo); err != nil {
+		return nil, fmt.Sprintf("malformed json: %s", err.Error())
+	}
+
+	if auctionMongo.Id == "" {
+		auctionMongo.Id = ar.newAuctionId()
+	}
+	if err := ar.checkAuctionId(auctionMongo.Id); err != nil {
+		return nil, err.Error()
+	}
+	if auctionMongo.Timestamp == 0 {
+		auctionMongo.Timestamp = time.Now().Unix()
+	}
+	auctionMongo.ProductName = strings.TrimSpace(auctionMongo.ProductName)
+	auctionMongo.Category = strings.TrimSpace(auctionMongo.Category)
+	auctionMongo.Description = strings.TrimSpace(auctionMongo.Description)
+	auctionMongo.Currency = currencyOrDefault(auction_entity.NormalizeCurrency(auctionMongo.Currency))
+
+	auctionEntity := fromMongo(&auctionMongo)
+	if err := auctionEntity.Validate(); err != nil {
+		return nil, err.Error()
+	}
+	if err := ar.applyDefaultCondition(auctionEntity); err != nil {
+		return nil, err.Error()
+	}
+	auctionMongo.Condition = auctionEntity.Condition
+
+	if auctionMongo.EndTime == 0 {
+		auctionMongo.EndTime = ar.auctionEndTime(auctionEntity).Unix()
+	}
+
+	return &auctionMongo, ""
+}
+
+// insertImportBatch insere um lote sem ordenação, de modo que falhas em documentos
+// isolados (como IDs duplicados) não impedem a inserção dos demais
+func (ar *AuctionRepository) insertImportBatch(
+	ctx context.Context,
+	batch []interface{},
+	batchLines []int) (int, []string, *internal_error.InternalError) {
+	opts := options.InsertMany().SetOrdered(false)
+	result, err := ar.Collection.InsertMany(ctx, batch, opts)
+	if err == nil {
+		return len(result.InsertedIDs), nil, nil
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) {
+		ar.logger.Error("Error trying to import auctions", err)
+		return 0, nil, internal_error.NewInternalServerError("Error trying to import auctions")
+	}
+
+	failedLines := make([]string, 0, len(bulkErr.WriteErrors))
+	for _, writeErr := range bulkErr.WriteErrors {
+		failedLines = append(failedLines,
+			fmt.Sprintf("line %d: %s", batchLines[writeErr.Index], writeErr.Message))
+	}
+
+	return len(batch) - len(bulkErr.WriteErrors), failedLines, nil
+}
+
+// getImportBatchSize retorna o tamanho dos lotes de importação baseado em
+// AUCTION_IMPORT_BATCH_SIZE. Se não estiver definido, retorna 500 como padrão
+func getImportBatchSize() int {
+	value, err := strconv.Atoi(os.Getenv("AUCTION_IMPORT_BATCH_SIZE"))
+	if err != nil || value <= 0 {
+		return 500
+	}
+
+	return value
+}
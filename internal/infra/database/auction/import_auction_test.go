@@ -0,0 +1,45 @@
+package auction
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestImportAuctions(t *testing.T) {
+	os.Setenv("AUCTION_IMPORT_BATCH_SIZE", "2")
+	defer os.Unsetenv("AUCTION_IMPORT_BATCH_SIZE")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	input := strings.Join([]string{
+		`{"product_name":"Notebook","category":"Electronics","description":"Notebook Dell Inspiron 15","condition":1,"status":0}`,
+		`{"product_name":"Broken",`,
+		`{"product_name":"Guitar","category":"Music","description":"Fender Stratocaster 2010","condition":2,"status":0}`,
+		`{"product_name":"X","category":"Music","description":"Invalid product name","condition":2,"status":0}`,
+		``,
+		`{"product_name":"Camera","category":"Photo","description":"Canon EOS Rebel T7 kit","condition":3,"status":1}`,
+	}, "\n")
+
+	imported, err := repo.ImportAuctions(ctx, strings.NewReader(input))
+	if imported != 3 {
+		t.Errorf("Expected 3 imported auctions, got %d", imported)
+	}
+
+	if err == nil {
+		t.Fatal("Expected an error reporting the invalid lines")
+	}
+	if !strings.Contains(err.Error(), "line 2:") || !strings.Contains(err.Error(), "line 4:") {
+		t.Errorf("Expected errors for lines 2 and 4, got %q", err.Error())
+	}
+
+	count, _ := repo.Collection.CountDocuments(ctx, map[string]interface{}{})
+	if count != 3 {
+		t.Errorf("Expected 3 auctions stored, got %d", count)
+	}
+}
@@ -0,0 +1,76 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// categoryIndexName identifica o índice da navegação por categoria
+const categoryIndexName = "category_status_timestamp"
+
+// WithCategoryBrowsing faz EnsureIndexes criar o índice que sustenta a busca de leilões
+// por categoria e status, dos mais novos para os mais antigos
+func WithCategoryBrowsing(enabled bool) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.categoryBrowsing = enabled
+	}
+}
+
+// EnsureIndexes cria os índices usados pelas consultas do repositório. Os índices de
+// funcionalidades opcionais (navegação por categoria, outbox e event store) só são
+// criados quando elas estão habilitadas. A criação é idempotente e pode ser executada
+// a cada inicialização
+func (ar *AuctionRepository) EnsureIndexes(ctx context.Context) *internal_error.InternalError {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "current_high_bid", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "end_time", Value: 1}}},
+		{Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "status", Value: 1}}},
+	}
+	if ar.categoryBrowsing {
+		indexes = append(indexes, mongo.IndexModel{
+			Keys:    bson.D{{Key: "category", Value: 1}, {Key: "status", Value: 1}, {Key: "timestamp", Value: -1}},
+			Options: options.Index().SetName(categoryIndexName),
+		})
+	}
+
+	if _, err := ar.Collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		ar.logger.Error("Error trying to create auction indexes", err)
+		return internal_error.NewInternalServerError("Error trying to create auction indexes")
+	}
+
+	historyIndex := mongo.IndexModel{
+		Keys: bson.D{{Key: "auction_id", Value: 1}, {Key: "timestamp", Value: 1}},
+	}
+	if _, err := ar.historyCollection.Indexes().CreateOne(ctx, historyIndex); err != nil {
+		ar.logger.Error("Error trying to create auction history indexes", err)
+		return internal_error.NewInternalServerError("Error trying to create auction history indexes")
+	}
+
+	if ar.outboxEnabled {
+		outboxIndex := mongo.IndexModel{
+			Keys: bson.D{{Key: "delivered_at", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		}
+		if _, err := ar.outboxCollection.Indexes().CreateOne(ctx, outboxIndex); err != nil {
+			ar.logger.Error("Error trying to create event outbox indexes", err)
+			return internal_error.NewInternalServerError("Error trying to create event outbox indexes")
+		}
+	}
+
+	if ar.eventStoreEnabled {
+		eventsIndex := mongo.IndexModel{
+			Keys: bson.D{{Key: "auction_id", Value: 1}, {Key: "occurred_at", Value: 1}},
+		}
+		if _, err := ar.eventsCollection.Indexes().CreateOne(ctx, eventsIndex); err != nil {
+			ar.logger.Error("Error trying to create auction events indexes", err)
+			return internal_error.NewInternalServerError("Error trying to create auction events indexes")
+		}
+	}
+
+	return ar.ensureRetentionIndex(ctx)
+}
@@ -0,0 +1,70 @@
+package auction
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// auctionIndexes retorna as chaves de cada índice da coleção de leilões, pelo nome
+func auctionIndexes(t *testing.T, repo *AuctionRepository) map[string]bson.D {
+	cursor, err := repo.Collection.Indexes().List(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to list indexes: %v", err)
+	}
+
+	var indexes []struct {
+		Name string `bson:"name"`
+		Key  bson.D `bson:"key"`
+	}
+	if err := cursor.All(context.Background(), &indexes); err != nil {
+		t.Fatalf("Failed to decode indexes: %v", err)
+	}
+
+	keys := make(map[string]bson.D, len(indexes))
+	for _, index := range indexes {
+		keys[index.Name] = index.Key
+	}
+
+	return keys
+}
+
+func TestEnsureIndexesCreatesCategoryIndexWhenBrowsingEnabled(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true), WithCategoryBrowsing(true))
+	if err := repo.EnsureIndexes(context.Background()); err != nil {
+		t.Fatalf("Failed to ensure indexes: %v", err)
+	}
+
+	keys, exists := auctionIndexes(t, repo)[categoryIndexName]
+	if !exists {
+		t.Fatal("Expected the category index to be created")
+	}
+
+	expected := []string{"category", "status", "timestamp"}
+	if len(keys) != len(expected) {
+		t.Fatalf("Expected keys %v, got %v", expected, keys)
+	}
+	for i, key := range expected {
+		if keys[i].Key != key {
+			t.Errorf("Expected key %s at position %d, got %s", key, i, keys[i].Key)
+		}
+	}
+}
+
+func TestEnsureIndexesSkipsCategoryIndexByDefault(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	if err := repo.EnsureIndexes(context.Background()); err != nil {
+		t.Fatalf("Failed to ensure indexes: %v", err)
+	}
+
+	if _, exists := auctionIndexes(t, repo)[categoryIndexName]; exists {
+		t.Error("Expected no category index without category browsing")
+	}
+}
@@ -0,0 +1,114 @@
+package auction
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const monitorLockName = "auction_expiration_monitor"
+
+// Locker decide se esta instância pode executar a varredura de fechamento.
+// Apenas a instância que detém o lock (líder) fecha os leilões em cada tick
+type Locker interface {
+	TryAcquire(ctx context.Context) (bool, error)
+}
+
+// WithLocker define o Locker usado para eleição de líder do monitor
+func WithLocker(locker Locker) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.locker = locker
+	}
+}
+
+type leaseDocumentMongo struct {
+	Id        string    `bson:"_id"`
+	Owner     string    `bson:"owner"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// MongoLeaseLocker implementa Locker com um documento de lease no MongoDB.
+// O lease expira automaticamente após o TTL, permitindo que outra instância assuma
+// caso a líder pare de renová-lo
+type MongoLeaseLocker struct {
+	Collection *mongo.Collection
+	name       string
+	owner      string
+	lease      time.Duration
+}
+
+func NewMongoLeaseLocker(
+	collection *mongo.Collection, name string, lease time.Duration) *MongoLeaseLocker {
+	return &MongoLeaseLocker{
+		Collection: collection,
+		name:       name,
+		owner:      uuid.New().String(),
+		lease:      lease,
+	}
+}
+
+// TryAcquire adquire ou renova o lease. Retorna false quando outra instância detém
+// um lease ainda válido
+func (ml *MongoLeaseLocker) TryAcquire(ctx context.Context) (bool, error) {
+	now := time.Now()
+
+	filter := bson.M{
+		"_id": ml.name,
+		"$or": bson.A{
+			bson.M{"owner": ml.owner},
+			bson.M{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"owner":      ml.owner,
+			"expires_at": now.Add(ml.lease),
+		},
+	}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var lease leaseDocumentMongo
+	err := ml.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&lease)
+	if err != nil {
+		// O upsert falha com chave duplicada quando outra instância detém o lease
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return lease.Owner == ml.owner, nil
+}
+
+// isLeader indica se esta instância deve executar a varredura de fechamento
+func (ar *AuctionRepository) isLeader(ctx context.Context) bool {
+	if ar.locker == nil {
+		return true
+	}
+
+	acquired, err := ar.locker.TryAcquire(ctx)
+	if err != nil {
+		ar.logger.Error("Error trying to acquire auction monitor lease", err)
+		return false
+	}
+
+	return acquired
+}
+
+// getLeaderLease retorna a duração do lease de liderança baseada em AUCTION_LEADER_LEASE.
+// Se não estiver definida, retorna 30 segundos como padrão
+func getLeaderLease() time.Duration {
+	lease, err := time.ParseDuration(os.Getenv("AUCTION_LEADER_LEASE"))
+	if err != nil || lease <= 0 {
+		return 30 * time.Second
+	}
+
+	return lease
+}
@@ -0,0 +1,64 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestLeaderElectionOnlyLeaderCloses(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	defer db.Collection("auction_locks").Drop(context.Background())
+
+	locks := db.Collection("auction_locks")
+	leader := newTestRepository(t, db,
+		WithLocker(NewMongoLeaseLocker(locks, monitorLockName, time.Minute)))
+	follower := newTestRepository(t, db,
+		WithLocker(NewMongoLeaseLocker(locks, monitorLockName, time.Minute)))
+	ctx := context.Background()
+
+	createExpired := func() *auction_entity.Auction {
+		auction, _ := auction_entity.CreateAuction(
+			"Expired Product",
+			"Electronics",
+			"This auction should expire",
+			auction_entity.New,
+		)
+		auction.Timestamp = time.Now().Add(-20 * time.Minute)
+		if err := leader.CreateAuction(ctx, auction); err != nil {
+			t.Fatalf("Failed to create auction: %v", err)
+		}
+		return auction
+	}
+
+	// O primeiro a executar o tick adquire o lease e fecha o leilão
+	first := createExpired()
+	leader.handleTick(ctx, 10*time.Minute)
+
+	var firstMongo AuctionEntityMongo
+	leader.Collection.FindOne(ctx, bson.M{"_id": first.Id}).Decode(&firstMongo)
+	if firstMongo.Status != auction_entity.Completed {
+		t.Fatalf("Expected leader to close the auction, got status %d", firstMongo.Status)
+	}
+
+	// Enquanto o lease for válido, o seguidor não executa a varredura
+	second := createExpired()
+	follower.handleTick(ctx, 10*time.Minute)
+
+	var secondMongo AuctionEntityMongo
+	follower.Collection.FindOne(ctx, bson.M{"_id": second.Id}).Decode(&secondMongo)
+	if secondMongo.Status != auction_entity.Active {
+		t.Errorf("Expected follower to skip the close sweep, got status %d", secondMongo.Status)
+	}
+	if follower.Stats().TotalClosed != 0 {
+		t.Errorf("Expected follower to close nothing, got %d", follower.Stats().TotalClosed)
+	}
+}
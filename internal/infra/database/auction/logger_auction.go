@@ -0,0 +1,11 @@
+package auction
+
+import "fullcycle-auction_go/configuration/logger"
+
+// WithLogger substitui o logger global pelos logs deste repositório, permitindo
+// capturá-los em testes ou marcá-los por tenant
+func WithLogger(l logger.Logger) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.logger = l
+	}
+}
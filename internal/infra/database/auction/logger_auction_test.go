@@ -0,0 +1,103 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type capturedLog struct {
+	level   string
+	message string
+	fields  map[string]interface{}
+}
+
+// capturingLogger guarda as mensagens recebidas para inspeção nos testes
+type capturingLogger struct {
+	mutex *sync.Mutex
+	logs  []capturedLog
+}
+
+func newCapturingLogger() *capturingLogger {
+	return &capturingLogger{mutex: &sync.Mutex{}}
+}
+
+func (l *capturingLogger) Info(message string, tags ...zap.Field) {
+	l.capture("info", message, tags)
+}
+
+func (l *capturingLogger) Error(message string, err error, tags ...zap.Field) {
+	l.capture("error", message, append(tags, zap.NamedError("error", err)))
+}
+
+func (l *capturingLogger) Warn(message string, tags ...zap.Field) {
+	l.capture("warn", message, tags)
+}
+
+func (l *capturingLogger) capture(level, message string, tags []zap.Field) {
+	encoder := zapcore.NewMapObjectEncoder()
+	for _, tag := range tags {
+		tag.AddTo(encoder)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.logs = append(l.logs, capturedLog{level: level, message: message, fields: encoder.Fields})
+}
+
+func (l *capturingLogger) find(message string) (capturedLog, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for _, log := range l.logs {
+		if log.message == message {
+			return log, true
+		}
+	}
+	return capturedLog{}, false
+}
+
+func TestInjectedLoggerCapturesClosedExpiredAuctions(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	capturing := newCapturingLogger()
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock), WithLogger(capturing))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	auction.Timestamp = clock.Now()
+	repo.CreateAuction(ctx, auction)
+
+	clock.Advance(20 * time.Minute)
+	repo.closeExpiredAuctions(ctx, repo.auctionDuration)
+
+	log, ok := capturing.find("Closed expired auctions")
+	if !ok {
+		t.Fatalf("Expected \"Closed expired auctions\" to be logged, got %+v", capturing.logs)
+	}
+	if log.level != "info" {
+		t.Errorf("Expected info level, got %s", log.level)
+	}
+	if log.fields["modified_count"] != int64(1) {
+		t.Errorf("Expected modified_count 1, got %v", log.fields["modified_count"])
+	}
+	if ids, _ := log.fields["auction_ids"].([]interface{}); len(ids) != 1 || ids[0] != auction.Id {
+		t.Errorf("Expected auction_ids [%s], got %v", auction.Id, log.fields["auction_ids"])
+	}
+}
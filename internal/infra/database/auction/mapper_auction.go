@@ -0,0 +1,125 @@
+package auction
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"time"
+)
+
+// toMongo converte o leilão para o documento gravado no MongoDB. Os instantes viram
+// segundos Unix, e os opcionais ausentes ficam zerados para serem omitidos
+func toMongo(a *auction_entity.Auction) *AuctionEntityMongo {
+	return &AuctionEntityMongo{
+		Id:             a.Id,
+		OwnerId:        a.OwnerId,
+		ProductName:    a.ProductName,
+		Category:       a.Category,
+		Description:    a.Description,
+		Condition:      a.Condition,
+		Status:         a.Status,
+		Timestamp:      a.Timestamp.Unix(),
+		StartsAt:       unixOrZeroSeconds(a.StartsAt),
+		Duration:       int64(a.Duration / time.Second),
+		EndTime:        unixOrZeroSeconds(a.EndsAt),
+		BidCount:       a.BidCount,
+		CurrentHighBid: a.CurrentHighBid,
+		StartingBid:    a.StartingBid,
+		Currency:       a.Currency,
+		Visibility:     a.Visibility,
+		AllowedUserIds: a.AllowedUserIds,
+		ImageURLs:      a.ImageURLs,
+		ActivatedAt:    timeOrNil(a.ActivatedAt),
+		CompletedAt:    timeOrNil(a.CompletedAt),
+		Version:        a.Version,
+	}
+}
+
+// fromMongo converte o documento do MongoDB no leilão, preenchendo os campos que
+// documentos antigos não têm, como moeda e visibilidade
+func fromMongo(m *AuctionEntityMongo) *auction_entity.Auction {
+	return &auction_entity.Auction{
+		Id:             m.Id,
+		OwnerId:        m.OwnerId,
+		ProductName:    m.ProductName,
+		Category:       m.Category,
+		Description:    m.Description,
+		Condition:      m.Condition,
+		Status:         m.Status,
+		Timestamp:      unixUTC(m.Timestamp),
+		StartsAt:       unixOrZero(m.StartsAt),
+		Duration:       time.Duration(m.Duration) * time.Second,
+		EndsAt:         unixOrZero(m.EndTime),
+		BidCount:       m.BidCount,
+		CurrentHighBid: m.CurrentHighBid,
+		StartingBid:    m.StartingBid,
+		Currency:       currencyOrDefault(m.Currency),
+		Visibility:     visibilityOrDefault(m.Visibility),
+		AllowedUserIds: m.AllowedUserIds,
+		ImageURLs:      m.ImageURLs,
+		ActivatedAt:    timeOrZero(m.ActivatedAt),
+		CompletedAt:    timeOrZero(m.CompletedAt),
+		Version:        m.Version,
+	}
+}
+
+// currencyOrDefault atribui a moeda padrão a leilões gravados antes do campo currency
+func currencyOrDefault(currency string) string {
+	if currency == "" {
+		return auction_entity.DefaultCurrency()
+	}
+
+	return currency
+}
+
+// timeOrZero converte um campo de data opcional, ausente em leilões não encerrados
+func timeOrZero(value *time.Time) time.Time {
+	if value == nil {
+		return time.Time{}
+	}
+
+	return *value
+}
+
+// visibilityOrDefault trata leilões gravados antes do campo visibility como públicos
+func visibilityOrDefault(visibility auction_entity.Visibility) auction_entity.Visibility {
+	if visibility == "" {
+		return auction_entity.Public
+	}
+
+	return visibility
+}
+
+// unixOrZero converte um timestamp Unix em time.Time, mantendo o valor zero
+// para campos ausentes no documento
+func unixOrZero(seconds int64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+
+	return unixUTC(seconds)
+}
+
+// unixUTC converte um timestamp Unix em time.Time em UTC. time.Unix usaria o fuso
+// local do servidor, o que não muda o instante mas muda o que é exibido e comparado
+// por campo (dia, hora)
+func unixUTC(seconds int64) time.Time {
+	return time.Unix(seconds, 0).UTC()
+}
+
+// unixOrZeroSeconds é o inverso de unixOrZero: o instante zero vira 0, para que o
+// campo seja omitido do documento
+func unixOrZeroSeconds(value time.Time) int64 {
+	if value.IsZero() {
+		return 0
+	}
+
+	return value.Unix()
+}
+
+// timeOrNil é o inverso de timeOrZero
+func timeOrNil(value time.Time) *time.Time {
+	if value.IsZero() {
+		return nil
+	}
+
+	return &value
+}
@@ -0,0 +1,71 @@
+package auction
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestMapperRoundTripsAuction(t *testing.T) {
+	// O MongoDB guarda os instantes em segundos
+	now := time.Now().UTC().Truncate(time.Second)
+
+	auction := &auction_entity.Auction{
+		Id:             uuid.NewString(),
+		OwnerId:        uuid.NewString(),
+		ProductName:    "Notebook",
+		Category:       "Electronics",
+		Description:    "Notebook Dell Inspiron 15",
+		Condition:      auction_entity.Used,
+		Status:         auction_entity.Completed,
+		Timestamp:      now.Add(-2 * time.Hour),
+		StartsAt:       now.Add(-time.Hour),
+		Duration:       time.Hour,
+		EndsAt:         now,
+		BidCount:       3,
+		CurrentHighBid: 2500,
+		StartingBid:    1000,
+		Currency:       "USD",
+		Visibility:     auction_entity.Private,
+		AllowedUserIds: []string{uuid.NewString()},
+		ImageURLs:      []string{"https://example.com/notebook.png"},
+		ActivatedAt:    now.Add(-time.Hour),
+		CompletedAt:    now,
+		Version:        4,
+	}
+
+	if roundTripped := fromMongo(toMongo(auction)); !reflect.DeepEqual(roundTripped, auction) {
+		t.Errorf("Expected %+v, got %+v", auction, roundTripped)
+	}
+
+	auctionMongo := toMongo(auction)
+	if roundTripped := toMongo(fromMongo(auctionMongo)); !reflect.DeepEqual(roundTripped, auctionMongo) {
+		t.Errorf("Expected %+v, got %+v", auctionMongo, roundTripped)
+	}
+}
+
+func TestMapperKeepsOptionalFieldsEmpty(t *testing.T) {
+	auctionMongo := toMongo(&auction_entity.Auction{
+		Id:        uuid.NewString(),
+		Status:    auction_entity.Active,
+		Timestamp: time.Now(),
+	})
+
+	if auctionMongo.StartsAt != 0 || auctionMongo.EndTime != 0 ||
+		auctionMongo.ActivatedAt != nil || auctionMongo.CompletedAt != nil {
+		t.Errorf("Expected optional fields to be omitted, got %+v", auctionMongo)
+	}
+
+	// Documentos antigos não têm moeda nem visibilidade
+	auction := fromMongo(auctionMongo)
+	if auction.Currency != auction_entity.DefaultCurrency() || auction.Visibility != auction_entity.Public {
+		t.Errorf("Expected legacy defaults, got currency %q and visibility %q",
+			auction.Currency, auction.Visibility)
+	}
+	if !auction.StartsAt.IsZero() || !auction.EndsAt.IsZero() || !auction.CompletedAt.IsZero() {
+		t.Errorf("Expected zero optional instants, got %+v", auction)
+	}
+}
@@ -0,0 +1,64 @@
+package auction
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Estratégias de fechamento usadas como rótulo das métricas
+const (
+	CloseStrategyMonitor  = "monitor"
+	CloseStrategyExternal = "external"
+)
+
+// closeLatencyHistogram mede o atraso entre a expiração de um leilão e o seu fechamento
+// efetivo, que depende do intervalo do monitor (ou da frequência do cron externo)
+var closeLatencyHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "auction_close_latency_seconds",
+	Help:    "Time between an auction's expiry and the sweep that closed it.",
+	Buckets: prometheus.ExponentialBuckets(0.5, 2, 12),
+}, []string{"strategy"})
+
+// eventPublishFailures conta as falhas de publicação de eventos, por nome do evento
+var eventPublishFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "auction_event_publish_failures_total",
+	Help: "Events whose publication failed, by event name.",
+}, []string{"event"})
+
+// closeFailures conta os fechamentos de leilão que falharam e ficaram para a próxima varredura
+var closeFailures = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "auction_close_failures_total",
+	Help: "Per-auction closes that failed and were left for the next sweep.",
+})
+
+// WithCloseLatencyObserver substitui o histograma de atraso de fechamento
+func WithCloseLatencyObserver(observer prometheus.ObserverVec) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.closeLatency = observer
+	}
+}
+
+// expiresAt calcula quando o leilão expirou: pelo end_time ou, em documentos antigos,
+// pela duração da sua categoria
+func (ar *AuctionRepository) expiresAt(auction AuctionEntityMongo) time.Time {
+	if auction.EndTime != 0 {
+		return unixUTC(auction.EndTime)
+	}
+
+	return unixUTC(auction.Timestamp).Add(ar.categoryDuration(auction.Category))
+}
+
+// observeCloseLatency registra, para cada leilão fechado, o tempo desde a sua expiração
+func (ar *AuctionRepository) observeCloseLatency(
+	auctions []AuctionEntityMongo, closedAt time.Time, strategy string) {
+	if ar.closeLatency == nil {
+		return
+	}
+
+	observer := ar.closeLatency.WithLabelValues(strategy)
+	for _, auction := range auctions {
+		observer.Observe(closedAt.Sub(ar.expiresAt(auction)).Seconds())
+	}
+}
@@ -0,0 +1,79 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCloseExpiredAuctionsRecordsCloseLatency(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "test_auction_close_latency_seconds",
+		Buckets: prometheus.ExponentialBuckets(0.5, 2, 12),
+	}, []string{"strategy"})
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(histogram)
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock), WithMonitorDisabled(true),
+		WithCloseLatencyObserver(histogram))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+		auction_entity.WithDuration(time.Minute),
+	)
+	auction.Timestamp = clock.Now()
+	if err := repo.CreateAuction(ctx, auction); err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+
+	// Expirou há quase uma hora quando a varredura o fecha
+	clock.Advance(time.Hour)
+	if closed, err := repo.ProcessExpiredAuctionsOnce(ctx); err != nil || closed != 1 {
+		t.Fatalf("Expected 1 closed auction, got %d (%v)", closed, err)
+	}
+
+	families, err := registry.Gather()
+	if err != nil || len(families) != 1 {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	metric := families[0].GetMetric()[0]
+	if label := metric.GetLabel()[0]; label.GetValue() != CloseStrategyExternal {
+		t.Errorf("Expected strategy %s, got %s", CloseStrategyExternal, label.GetValue())
+	}
+
+	sample := metric.GetHistogram()
+	if sample.GetSampleCount() != 1 || sample.GetSampleSum() < (59*time.Minute).Seconds() {
+		t.Errorf("Expected one sample of about an hour, got count=%d sum=%v",
+			sample.GetSampleCount(), sample.GetSampleSum())
+	}
+}
+
+func TestExpiresAtFallsBackToCategoryDuration(t *testing.T) {
+	repo := &AuctionRepository{
+		auctionDuration:   10 * time.Minute,
+		categoryDurations: map[string]time.Duration{"Perishables": 2 * time.Minute},
+	}
+	created := time.Unix(1_700_000_000, 0)
+
+	legacy := AuctionEntityMongo{Category: "Perishables", Timestamp: created.Unix()}
+	if expiresAt := repo.expiresAt(legacy); !expiresAt.Equal(created.Add(2 * time.Minute)) {
+		t.Errorf("Expected legacy auction to expire by its category duration, got %v", expiresAt)
+	}
+
+	withEndTime := AuctionEntityMongo{Timestamp: created.Unix(), EndTime: created.Add(time.Hour).Unix()}
+	if expiresAt := repo.expiresAt(withEndTime); !expiresAt.Equal(created.Add(time.Hour)) {
+		t.Errorf("Expected auction to expire at its end_time, got %v", expiresAt)
+	}
+}
@@ -0,0 +1,85 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// MigrateEnumEncoding reescreve os documentos antigos que armazenam status e condição
+// como inteiros para a representação em string. A leitura aceita ambos os formatos,
+// mas os filtros (como o de fechamento) só encontram os documentos já migrados
+func (ar *AuctionRepository) MigrateEnumEncoding(ctx context.Context) (int64, *internal_error.InternalError) {
+	var migrated int64
+	defer ar.readCache.invalidateAll()
+
+	for _, status := range []auction_entity.AuctionStatus{
+		auction_entity.Active, auction_entity.Completed} {
+		result, err := ar.Collection.UpdateMany(ctx,
+			bson.M{"status": int64(status)},
+			bson.M{"$set": bson.M{"status": status}})
+		if err != nil {
+			ar.logger.Error("Error trying to migrate auction status encoding", err)
+			return migrated, internal_error.NewInternalServerError(
+				"Error trying to migrate auction status encoding")
+		}
+		migrated += result.ModifiedCount
+	}
+
+	for _, condition := range []auction_entity.ProductCondition{
+		auction_entity.New, auction_entity.Used, auction_entity.Refurbished} {
+		result, err := ar.Collection.UpdateMany(ctx,
+			bson.M{"condition": int64(condition)},
+			bson.M{"$set": bson.M{"condition": condition}})
+		if err != nil {
+			ar.logger.Error("Error trying to migrate auction condition encoding", err)
+			return migrated, internal_error.NewInternalServerError(
+				"Error trying to migrate auction condition encoding")
+		}
+		migrated += result.ModifiedCount
+	}
+
+	if migrated > 0 {
+		ar.logger.Info("Migrated auction enum encoding", zap.Int64("modified_count", migrated))
+	}
+
+	return migrated, nil
+}
+
+// MigrateMoneyEncoding reescreve o current_high_bid dos documentos antigos, gravado em
+// float64 na unidade da moeda, como centavos inteiros. Sem a migração, filtros e
+// ordenações por valor comparariam as duas representações entre si
+func (ar *AuctionRepository) MigrateMoneyEncoding(ctx context.Context) (int64, *internal_error.InternalError) {
+	migrated, err := MigrateMoneyField(ctx, ar.Collection, "current_high_bid")
+	if err != nil {
+		ar.logger.Error("Error trying to migrate auction high bid encoding", err)
+		return 0, internal_error.NewInternalServerError(
+			"Error trying to migrate auction high bid encoding")
+	}
+
+	if migrated > 0 {
+		ar.logger.Info("Migrated auction high bid encoding", zap.Int64("modified_count", migrated))
+	}
+	ar.readCache.invalidateAll()
+
+	return migrated, nil
+}
+
+// MigrateMoneyField converte para centavos inteiros os valores monetários gravados em
+// float64 no campo informado da coleção
+func MigrateMoneyField(ctx context.Context, collection *mongo.Collection, field string) (int64, error) {
+	update := bson.A{bson.M{"$set": bson.M{
+		field: bson.M{"$toLong": bson.M{"$round": bson.A{bson.M{"$multiply": bson.A{"$" + field, 100}}, 0}}},
+	}}}
+
+	result, err := collection.UpdateMany(ctx, bson.M{field: bson.M{"$type": "double"}}, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
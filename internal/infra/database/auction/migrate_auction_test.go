@@ -0,0 +1,120 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestEnumsStoredAsStrings(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Expired Product",
+		"Electronics",
+		"This auction should expire",
+		auction_entity.Used,
+	)
+	auction.Timestamp = time.Now().Add(-20 * time.Minute)
+	repo.CreateAuction(ctx, auction)
+
+	var raw bson.M
+	repo.Collection.FindOne(ctx, bson.M{"_id": auction.Id}).Decode(&raw)
+	if raw["status"] != "active" || raw["condition"] != "used" {
+		t.Fatalf("Expected enums stored as strings, got status=%v condition=%v",
+			raw["status"], raw["condition"])
+	}
+
+	found, err := repo.FindAuctionById(ctx, auction.Id)
+	if err != nil {
+		t.Fatalf("Failed to find auction: %v", err)
+	}
+	if found.Status != auction_entity.Active || found.Condition != auction_entity.Used {
+		t.Errorf("Unexpected enums after round-trip: %d, %d", found.Status, found.Condition)
+	}
+
+	// O filtro de fechamento continua encontrando leilões ativos armazenados como string
+	repo.closeExpiredAuctions(ctx, 10*time.Minute)
+
+	repo.Collection.FindOne(ctx, bson.M{"_id": auction.Id}).Decode(&raw)
+	if raw["status"] != "completed" {
+		t.Errorf("Expected auction to be closed, got status %v", raw["status"])
+	}
+}
+
+func TestMigrateEnumEncoding(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	// Documento legado com enums inteiros
+	repo.Collection.InsertOne(ctx, bson.M{
+		"_id":       "legacy-auction",
+		"status":    0,
+		"condition": 3,
+		"timestamp": time.Now().Unix(),
+	})
+
+	migrated, err := repo.MigrateEnumEncoding(ctx)
+	if err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	if migrated != 2 {
+		t.Errorf("Expected 2 migrated fields, got %d", migrated)
+	}
+
+	var raw bson.M
+	repo.Collection.FindOne(ctx, bson.M{"_id": "legacy-auction"}).Decode(&raw)
+	if raw["status"] != "active" || raw["condition"] != "refurbished" {
+		t.Errorf("Expected migrated enums as strings, got %v", raw)
+	}
+}
+
+func TestMigrateMoneyEncodingConvertsLegacyHighBids(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Legacy Product",
+		"Electronics",
+		"Stored before money was kept in cents",
+		auction_entity.New,
+	)
+	repo.CreateAuction(ctx, auction)
+	x, y := 100.1, 0.2
+	repo.Collection.UpdateByID(ctx, auction.Id, bson.M{"$set": bson.M{"current_high_bid": x + y}})
+
+	migrated, err := repo.MigrateMoneyEncoding(ctx)
+	if err != nil {
+		t.Fatalf("Failed to migrate money encoding: %v", err)
+	}
+	if migrated != 1 {
+		t.Errorf("Expected 1 migrated auction, got %d", migrated)
+	}
+
+	var raw bson.M
+	repo.Collection.FindOne(ctx, bson.M{"_id": auction.Id}).Decode(&raw)
+	if raw["current_high_bid"] != int64(10030) {
+		t.Errorf("Expected current_high_bid stored as 10030 cents, got %#v", raw["current_high_bid"])
+	}
+
+	if migrated, _ := repo.MigrateMoneyEncoding(ctx); migrated != 0 {
+		t.Errorf("Expected the migration to be idempotent, got %d migrated", migrated)
+	}
+}
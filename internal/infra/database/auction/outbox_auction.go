@@ -0,0 +1,103 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/event"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+)
+
+// OutboxEventMongo é um evento cuja publicação falhou, guardado para nova tentativa.
+// DeliveredAt é preenchido quando o drainer consegue reenviá-lo
+type OutboxEventMongo struct {
+	Id            string            `bson:"_id"`
+	Name          string            `bson:"name"`
+	OccurredAt    time.Time         `bson:"occurred_at"`
+	Headers       map[string]string `bson:"headers,omitempty"`
+	Payload       bson.M            `bson:"payload"`
+	Attempts      int               `bson:"attempts"`
+	LastError     string            `bson:"last_error"`
+	CreatedAt     time.Time         `bson:"created_at"`
+	NextAttemptAt time.Time         `bson:"next_attempt_at"`
+	DeliveredAt   *time.Time        `bson:"delivered_at,omitempty"`
+}
+
+// WithOutboxEnabled faz o repositório guardar na coleção event_outbox os eventos
+// cuja publicação falhou, em vez de descartá-los
+func WithOutboxEnabled(enabled bool) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.outboxEnabled = enabled
+	}
+}
+
+// publish entrega o evento sem nunca falhar a operação que o originou: erros são
+// registrados em log e em métrica e, com o outbox habilitado, o evento é guardado.
+// Com o event store habilitado, o evento é registrado mesmo sem publisher
+func (ar *AuctionRepository) publish(ctx context.Context, e event.Event) {
+	// Injeta o trace antes de publicar para que ele também seja guardado no outbox
+	e = event.WithTraceContext(ctx, e, otel.GetTextMapPropagator())
+
+	if ar.eventStoreEnabled {
+		ar.recordEvent(ctx, e)
+	}
+	if ar.publisher == nil {
+		return
+	}
+
+	err := ar.publisher.Publish(ctx, e)
+	if err == nil {
+		return
+	}
+
+	eventPublishFailures.WithLabelValues(e.Name).Inc()
+	ar.logger.Error("Error trying to publish event", err,
+		zap.String("event_id", e.Id),
+		zap.String("event_name", e.Name))
+
+	if ar.outboxEnabled {
+		ar.enqueueOutbox(ctx, e, err)
+	}
+}
+
+func (ar *AuctionRepository) enqueueOutbox(ctx context.Context, e event.Event, publishErr error) {
+	payload, err := toBsonM(e.Payload)
+	if err != nil {
+		ar.logger.Error("Error trying to encode event for the outbox", err,
+			zap.String("event_id", e.Id))
+		return
+	}
+
+	now := ar.clock.Now()
+	outboxEvent := OutboxEventMongo{
+		Id:            e.Id,
+		Name:          e.Name,
+		OccurredAt:    e.OccurredAt,
+		Headers:       e.Headers,
+		Payload:       payload,
+		Attempts:      1,
+		LastError:     publishErr.Error(),
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+	if _, err := ar.outboxCollection.InsertOne(ctx, outboxEvent); err != nil {
+		ar.logger.Error("Error trying to store event in the outbox", err,
+			zap.String("event_id", e.Id))
+	}
+}
+
+func toBsonM(value interface{}) (bson.M, error) {
+	data, err := bson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var document bson.M
+	if err := bson.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
@@ -0,0 +1,85 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/event"
+	"fullcycle-auction_go/internal/testutil"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(ctx context.Context, e event.Event) error {
+	return errors.New("broker unavailable")
+}
+
+func TestSweepClosesAuctionsWhenPublishFails(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db,
+		WithMonitorDisabled(true),
+		WithPublisher(failingPublisher{}),
+		WithOutboxEnabled(true))
+	ctx := context.Background()
+
+	auctions, err := testutil.SeedAuctions(ctx, repo, 1, testutil.MakeExpired)
+	if err != nil {
+		t.Fatalf("Failed to seed expired auction: %v", err)
+	}
+
+	closed, err := repo.ProcessExpiredAuctionsOnce(ctx)
+	if err != nil {
+		t.Fatalf("Expected the sweep to succeed despite publish failures: %v", err)
+	}
+	if closed != 1 {
+		t.Errorf("Expected 1 closed auction, got %d", closed)
+	}
+
+	found, err := repo.FindAuctionById(ctx, auctions[0].Id)
+	if err != nil {
+		t.Fatalf("Failed to find auction: %v", err)
+	}
+	if found.Status != auction_entity.Completed {
+		t.Errorf("Expected auction to be completed, got %v", found.Status)
+	}
+
+	var queued OutboxEventMongo
+	filter := bson.M{"name": AuctionClosedEvent, "payload.auction_id": auctions[0].Id}
+	if err := repo.outboxCollection.FindOne(ctx, filter).Decode(&queued); err != nil {
+		t.Fatalf("Expected the closed event in the outbox: %v", err)
+	}
+	if queued.LastError != "broker unavailable" {
+		t.Errorf("Expected the publish error to be stored, got %q", queued.LastError)
+	}
+}
+
+func TestPublishFailureWithoutOutboxIsDropped(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db,
+		WithMonitorDisabled(true),
+		WithPublisher(failingPublisher{}),
+		WithOutboxEnabled(false))
+	ctx := context.Background()
+
+	if _, err := testutil.SeedAuctions(ctx, repo, 1, testutil.MakeExpired); err != nil {
+		t.Fatalf("Failed to seed expired auction: %v", err)
+	}
+	if _, err := repo.ProcessExpiredAuctionsOnce(ctx); err != nil {
+		t.Fatalf("Expected the sweep to succeed despite publish failures: %v", err)
+	}
+
+	count, err := repo.outboxCollection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		t.Fatalf("Failed to count outbox events: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected an empty outbox, got %d events", count)
+	}
+}
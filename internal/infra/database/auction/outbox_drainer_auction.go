@@ -0,0 +1,168 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/event"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	// outboxDrainBatchSize limita quantos eventos são reenviados a cada passada
+	outboxDrainBatchSize = 100
+
+	// maxOutboxBackoff limita o atraso entre tentativas de um mesmo evento
+	maxOutboxBackoff = 30 * time.Minute
+)
+
+// WithOutboxDrainerDisabled desliga a goroutine que reenvia os eventos do outbox
+func WithOutboxDrainerDisabled(disabled bool) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.drainerDisabled = disabled
+	}
+}
+
+// WithOutboxDrainInterval define o intervalo entre as passadas do drainer
+func WithOutboxDrainInterval(interval time.Duration) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.drainInterval = interval
+	}
+}
+
+// getOutboxDrainInterval lê AUCTION_OUTBOX_DRAIN_INTERVAL. O padrão é 30 segundos
+func getOutboxDrainInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("AUCTION_OUTBOX_DRAIN_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return 30 * time.Second
+	}
+
+	return interval
+}
+
+// shouldDrainOutbox indica se o drainer deve ser iniciado: só faz sentido com o
+// outbox habilitado e um publisher para onde reenviar os eventos
+func (ar *AuctionRepository) shouldDrainOutbox() bool {
+	return ar.outboxEnabled && ar.publisher != nil && !ar.drainerDisabled
+}
+
+// drainOutbox é uma goroutine que reenvia periodicamente os eventos pendentes do
+// outbox, garantindo entrega ao menos uma vez mesmo com falhas do broker
+func (ar *AuctionRepository) drainOutbox(ctx context.Context) {
+	ticker := time.NewTicker(ar.drainInterval)
+	defer ticker.Stop()
+
+	ar.logger.Info("Event outbox drainer started",
+		zap.Duration("interval", ar.drainInterval))
+
+	for {
+		select {
+		case <-ctx.Done():
+			ar.logger.Info("Event outbox drainer stopped")
+			return
+		case <-ticker.C:
+			if !hasTimeForSweep(ctx, ar.writeTimeout) {
+				continue
+			}
+
+			passCtx, cancel := withTimeout(ctx, ar.writeTimeout)
+			ar.DrainOutboxOnce(passCtx)
+			cancel()
+		}
+	}
+}
+
+// DrainOutboxOnce tenta publicar os eventos pendentes cuja próxima tentativa já
+// chegou, marcando-os como entregues ou agendando uma nova tentativa com backoff.
+// Retorna quantos eventos foram entregues
+func (ar *AuctionRepository) DrainOutboxOnce(
+	ctx context.Context) (delivered int64, err *internal_error.InternalError) {
+	now := ar.clock.Now()
+	filter := bson.M{
+		"delivered_at":    bson.M{"$exists": false},
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(outboxDrainBatchSize)
+
+	cursor, findErr := ar.outboxCollection.Find(ctx, filter, opts)
+	if findErr != nil {
+		ar.logger.Error("Error trying to find pending outbox events", findErr)
+		return 0, internal_error.NewInternalServerError("Error trying to find pending outbox events")
+	}
+	defer cursor.Close(ctx)
+
+	var pending []OutboxEventMongo
+	if decodeErr := cursor.All(ctx, &pending); decodeErr != nil {
+		ar.logger.Error("Error trying to decode pending outbox events", decodeErr)
+		return 0, internal_error.NewInternalServerError("Error trying to decode pending outbox events")
+	}
+
+	for _, outboxEvent := range pending {
+		if ar.redeliver(ctx, outboxEvent, now) {
+			delivered++
+		}
+	}
+
+	if len(pending) > 0 {
+		ar.logger.Info("Drained event outbox",
+			zap.Int("pending", len(pending)),
+			zap.Int64("delivered", delivered))
+	}
+
+	return delivered, nil
+}
+
+// redeliver publica um evento do outbox e atualiza o seu estado, retornando se
+// ele foi entregue
+func (ar *AuctionRepository) redeliver(
+	ctx context.Context, outboxEvent OutboxEventMongo, now time.Time) bool {
+	publishErr := ar.publisher.Publish(ctx, event.Event{
+		Id:         outboxEvent.Id,
+		Name:       outboxEvent.Name,
+		OccurredAt: outboxEvent.OccurredAt,
+		Headers:    outboxEvent.Headers,
+		Payload:    outboxEvent.Payload,
+	})
+
+	var update bson.M
+	if publishErr == nil {
+		update = bson.M{"$set": bson.M{"delivered_at": now}}
+	} else {
+		eventPublishFailures.WithLabelValues(outboxEvent.Name).Inc()
+		attempts := outboxEvent.Attempts + 1
+		update = bson.M{
+			"$set": bson.M{
+				"attempts":        attempts,
+				"last_error":      publishErr.Error(),
+				"next_attempt_at": now.Add(ar.outboxBackoff(attempts)),
+			},
+		}
+		ar.logger.Warn("Error trying to redeliver outbox event",
+			zap.String("event_id", outboxEvent.Id),
+			zap.Int("attempts", attempts),
+			zap.Error(publishErr))
+	}
+
+	if _, err := ar.outboxCollection.UpdateByID(ctx, outboxEvent.Id, update); err != nil {
+		ar.logger.Error("Error trying to update outbox event", err,
+			zap.String("event_id", outboxEvent.Id))
+	}
+
+	return publishErr == nil
+}
+
+// outboxBackoff dobra o intervalo do drainer a cada tentativa que falhou, até maxOutboxBackoff
+func (ar *AuctionRepository) outboxBackoff(attempts int) time.Duration {
+	backoff := ar.drainInterval
+	for i := 1; i < attempts && backoff < maxOutboxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxOutboxBackoff)
+}
@@ -0,0 +1,104 @@
+package auction
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func seedOutboxEvent(
+	t *testing.T, repo *AuctionRepository, id string, nextAttemptAt time.Time) {
+	t.Helper()
+
+	outboxEvent := OutboxEventMongo{
+		Id:            id,
+		Name:          AuctionClosedEvent,
+		OccurredAt:    nextAttemptAt,
+		Payload:       bson.M{"auction_id": id},
+		Attempts:      1,
+		LastError:     "broker unavailable",
+		CreatedAt:     nextAttemptAt,
+		NextAttemptAt: nextAttemptAt,
+	}
+	if _, err := repo.outboxCollection.InsertOne(context.Background(), outboxEvent); err != nil {
+		t.Fatalf("Failed to seed outbox event: %v", err)
+	}
+}
+
+func TestDrainOutboxOnceMarksEventsDelivered(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	publisher := &recordingPublisher{mutex: &sync.Mutex{}}
+	repo := newTestRepository(t, db,
+		WithMonitorDisabled(true),
+		WithClock(clock),
+		WithPublisher(publisher),
+		WithOutboxEnabled(true),
+		WithOutboxDrainerDisabled(true))
+	ctx := context.Background()
+
+	seedOutboxEvent(t, repo, "event-1", clock.Now().Add(-time.Minute))
+	seedOutboxEvent(t, repo, "event-2", clock.Now())
+	seedOutboxEvent(t, repo, "event-later", clock.Now().Add(time.Minute))
+
+	delivered, err := repo.DrainOutboxOnce(ctx)
+	if err != nil {
+		t.Fatalf("Failed to drain outbox: %v", err)
+	}
+	if delivered != 2 {
+		t.Errorf("Expected 2 delivered events, got %d", delivered)
+	}
+	if len(publisher.events) != 2 || publisher.events[0].Id != "event-1" {
+		t.Fatalf("Expected event-1 and event-2 to be published in order, got %+v", publisher.events)
+	}
+
+	count, _ := repo.outboxCollection.CountDocuments(ctx,
+		bson.M{"delivered_at": bson.M{"$exists": true}})
+	if count != 2 {
+		t.Errorf("Expected 2 events marked delivered, got %d", count)
+	}
+
+	if delivered, _ := repo.DrainOutboxOnce(ctx); delivered != 0 {
+		t.Errorf("Expected delivered events not to be published again, got %d", delivered)
+	}
+}
+
+func TestDrainOutboxOnceBacksOffFailedEvents(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	repo := newTestRepository(t, db,
+		WithMonitorDisabled(true),
+		WithClock(clock),
+		WithPublisher(failingPublisher{}),
+		WithOutboxEnabled(true),
+		WithOutboxDrainerDisabled(true),
+		WithOutboxDrainInterval(10*time.Second))
+	ctx := context.Background()
+
+	seedOutboxEvent(t, repo, "event-1", clock.Now())
+
+	if delivered, err := repo.DrainOutboxOnce(ctx); err != nil || delivered != 0 {
+		t.Fatalf("Expected no delivered events, got %d (%v)", delivered, err)
+	}
+
+	var outboxEvent OutboxEventMongo
+	if err := repo.outboxCollection.FindOne(ctx, bson.M{"_id": "event-1"}).Decode(&outboxEvent); err != nil {
+		t.Fatalf("Failed to find outbox event: %v", err)
+	}
+	if outboxEvent.Attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", outboxEvent.Attempts)
+	}
+	if !outboxEvent.NextAttemptAt.Equal(clock.Now().Add(20 * time.Second)) {
+		t.Errorf("Expected next attempt in 20s, got %v", outboxEvent.NextAttemptAt)
+	}
+	if outboxEvent.DeliveredAt != nil {
+		t.Errorf("Expected event to remain undelivered")
+	}
+}
@@ -0,0 +1,69 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuctionPageCursor identifica o último leilão de uma página na paginação por chave
+type AuctionPageCursor struct {
+	Timestamp int64  `json:"timestamp"`
+	Id        string `json:"id"`
+}
+
+// FindAuctionsAfter retorna até limit leilões ordenados por (timestamp, _id) estritamente
+// após o cursor informado, sem usar skip. Um cursor vazio (0, "") retorna a primeira página.
+// O cursor retornado é nil quando não há mais páginas
+func (ar *AuctionRepository) FindAuctionsAfter(
+	ctx context.Context,
+	afterTimestamp int64,
+	afterId string,
+	limit int) ([]auction_entity.Auction, *AuctionPageCursor, *internal_error.InternalError) {
+	if limit <= 0 {
+		return nil, nil, internal_error.NewBadRequestError("limit must be greater than zero")
+	}
+
+	filter := bson.M{}
+	if afterTimestamp != 0 || afterId != "" {
+		filter["$or"] = bson.A{
+			bson.M{"timestamp": bson.M{"$gt": afterTimestamp}},
+			bson.M{"timestamp": afterTimestamp, "_id": bson.M{"$gt": ar.DocumentId(afterId)}},
+		}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	cursor, err := ar.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		ar.logger.Error("Error finding auctions page", err)
+		return nil, nil, readError(err, "Error finding auctions page")
+	}
+	defer cursor.Close(ctx)
+
+	var auctionsMongo []AuctionEntityMongo
+	if err := cursor.All(ctx, &auctionsMongo); err != nil {
+		ar.logger.Error("Error decoding auctions page", err)
+		return nil, nil, readError(err, "Error decoding auctions page")
+	}
+
+	auctionsEntity := make([]auction_entity.Auction, 0, len(auctionsMongo))
+	for _, auction := range auctionsMongo {
+		auctionsEntity = append(auctionsEntity, *fromMongo(&auction))
+	}
+
+	if len(auctionsMongo) < limit {
+		return auctionsEntity, nil, nil
+	}
+
+	last := auctionsMongo[len(auctionsMongo)-1]
+	return auctionsEntity, &AuctionPageCursor{Timestamp: last.Timestamp, Id: last.Id}, nil
+}
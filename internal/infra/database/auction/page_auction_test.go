@@ -0,0 +1,62 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+)
+
+func TestFindAuctionsAfterPagesWithoutGapsOrDuplicates(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	// Leilões com timestamps repetidos para exercitar o desempate por _id
+	base := time.Now().Add(-time.Hour)
+	seeded := map[string]bool{}
+	for i := 0; i < 7; i++ {
+		auction, _ := auction_entity.CreateAuction(
+			"Test Product",
+			"Electronics",
+			"A test product for auction",
+			auction_entity.New,
+		)
+		auction.Timestamp = base.Add(time.Duration(i/2) * time.Second)
+		repo.CreateAuction(ctx, auction)
+		seeded[auction.Id] = true
+	}
+
+	seen := map[string]bool{}
+	var afterTimestamp int64
+	var afterId string
+	pages := 0
+	for {
+		auctions, next, err := repo.FindAuctionsAfter(ctx, afterTimestamp, afterId, 3)
+		if err != nil {
+			t.Fatalf("Failed to find auctions page: %v", err)
+		}
+		pages++
+
+		for _, auction := range auctions {
+			if seen[auction.Id] {
+				t.Errorf("Auction %s returned in more than one page", auction.Id)
+			}
+			seen[auction.Id] = true
+		}
+
+		if next == nil {
+			break
+		}
+		afterTimestamp, afterId = next.Timestamp, next.Id
+	}
+
+	if len(seen) != len(seeded) {
+		t.Errorf("Expected %d auctions across pages, got %d", len(seeded), len(seen))
+	}
+	if pages != 3 {
+		t.Errorf("Expected 3 pages, got %d", pages)
+	}
+}
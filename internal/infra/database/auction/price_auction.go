@@ -0,0 +1,55 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FindAuctionsByPriceRange retorna os leilões cujo maior lance atual está entre
+// minPrice e maxPrice, inclusive. Um limite nil deixa aquele lado do intervalo aberto
+func (ar *AuctionRepository) FindAuctionsByPriceRange(
+	ctx context.Context,
+	minPrice, maxPrice *auction_entity.Money) ([]auction_entity.Auction, *internal_error.InternalError) {
+	if minPrice != nil && maxPrice != nil && *minPrice > *maxPrice {
+		return nil, internal_error.NewBadRequestError("min price must not be greater than max price")
+	}
+
+	priceFilter := bson.M{}
+	if minPrice != nil {
+		priceFilter["$gte"] = *minPrice
+	}
+	if maxPrice != nil {
+		priceFilter["$lte"] = *maxPrice
+	}
+
+	filter := bson.M{}
+	if len(priceFilter) > 0 {
+		filter["current_high_bid"] = priceFilter
+	}
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	cursor, err := ar.Collection.Find(ctx, filter)
+	if err != nil {
+		ar.logger.Error("Error finding auctions by price range", err)
+		return nil, readError(err, "Error finding auctions by price range")
+	}
+	defer cursor.Close(ctx)
+
+	var auctionsMongo []AuctionEntityMongo
+	if err := cursor.All(ctx, &auctionsMongo); err != nil {
+		ar.logger.Error("Error decoding auctions", err)
+		return nil, readError(err, "Error decoding auctions")
+	}
+
+	auctionsEntity := make([]auction_entity.Auction, 0, len(auctionsMongo))
+	for _, auction := range auctionsMongo {
+		auctionsEntity = append(auctionsEntity, *fromMongo(&auction))
+	}
+
+	return auctionsEntity, nil
+}
@@ -0,0 +1,91 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"sort"
+	"testing"
+)
+
+func TestFindAuctionsByPriceRange(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	// Cada leilão recebe lances até atingir o maior lance indicado
+	highBids := map[string]auction_entity.Money{}
+	for _, prices := range [][]auction_entity.Money{{50}, {80, 150}, {300, 200}, {}} {
+		auction, _ := auction_entity.CreateAuction(
+			"Test Product",
+			"Electronics",
+			"A test product for auction",
+			auction_entity.New,
+		)
+		repo.CreateAuction(ctx, auction)
+
+		high := auction_entity.Money(0)
+		for _, price := range prices {
+			if err := repo.RecordBid(ctx, auction.Id, price); err != nil {
+				t.Fatalf("Failed to record bid: %v", err)
+			}
+			if price > high {
+				high = price
+			}
+		}
+		highBids[auction.Id] = high
+	}
+
+	price := func(value auction_entity.Money) *auction_entity.Money { return &value }
+
+	tests := []struct {
+		name     string
+		min, max *auction_entity.Money
+		expected []auction_entity.Money
+	}{
+		{name: "closed range", min: price(100), max: price(300), expected: []auction_entity.Money{150, 300}},
+		{name: "only min", min: price(150), expected: []auction_entity.Money{150, 300}},
+		{name: "only max", max: price(100), expected: []auction_entity.Money{0, 50}},
+		{name: "open range", expected: []auction_entity.Money{0, 50, 150, 300}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auctions, err := repo.FindAuctionsByPriceRange(ctx, tt.min, tt.max)
+			if err != nil {
+				t.Fatalf("Failed to find auctions by price range: %v", err)
+			}
+
+			var got []auction_entity.Money
+			for _, auction := range auctions {
+				if auction.CurrentHighBid != highBids[auction.Id] {
+					t.Errorf("Expected auction %s high bid %s, got %s",
+						auction.Id, highBids[auction.Id], auction.CurrentHighBid)
+				}
+				got = append(got, auction.CurrentHighBid)
+			}
+			sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Expected high bids %v, got %v", tt.expected, got)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("Expected high bids %v, got %v", tt.expected, got)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestFindAuctionsByPriceRangeRejectsInvertedRange(t *testing.T) {
+	repo := &AuctionRepository{}
+
+	min, max := auction_entity.Money(200), auction_entity.Money(100)
+	_, err := repo.FindAuctionsByPriceRange(context.Background(), &min, &max)
+	if err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for an inverted range, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// WithMonitorDisabled desliga o monitor interno de leilões expirados, para que os
+// fechamentos sejam disparados externamente via ProcessExpiredAuctionsOnce
+func WithMonitorDisabled(disabled bool) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.monitorDisabled = disabled
+	}
+}
+
+// ProcessExpiredAuctionsOnce ativa os leilões agendados cujo início chegou e executa
+// uma única varredura de fechamento com a duração configurada, retornando quantos
+// leilões foram fechados. É destinado a ser chamado por um cron ou CLI, no lugar do
+// monitor; em modo dry-run nada é fechado e o retorno é 0
+func (ar *AuctionRepository) ProcessExpiredAuctionsOnce(
+	ctx context.Context) (closed int64, err *internal_error.InternalError) {
+	if _, err := ar.activateScheduledAuctions(ctx); err != nil {
+		return 0, err
+	}
+
+	_, closed, err = ar.sweepExpiredAuctions(ctx, ar.auctionDuration, CloseStrategyExternal)
+	return closed, err
+}
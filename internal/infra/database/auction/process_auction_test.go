@@ -0,0 +1,35 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/testutil"
+	"testing"
+	"time"
+)
+
+func TestProcessExpiredAuctionsOnceReturnsClosedCount(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	if _, err := testutil.SeedAuctions(ctx, repo, 3, testutil.MakeExpired); err != nil {
+		t.Fatalf("Failed to seed expired auctions: %v", err)
+	}
+	if _, err := testutil.SeedAuctions(ctx, repo, 1, testutil.WithDuration(time.Hour)); err != nil {
+		t.Fatalf("Failed to seed active auction: %v", err)
+	}
+
+	closed, err := repo.ProcessExpiredAuctionsOnce(ctx)
+	if err != nil {
+		t.Fatalf("Failed to process expired auctions: %v", err)
+	}
+	if closed != 3 {
+		t.Errorf("Expected 3 closed auctions, got %d", closed)
+	}
+
+	if closed, _ := repo.ProcessExpiredAuctionsOnce(ctx); closed != 0 {
+		t.Errorf("Expected a second sweep to close nothing, got %d", closed)
+	}
+}
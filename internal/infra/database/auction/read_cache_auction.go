@@ -0,0 +1,176 @@
+package auction
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultReadCacheSize é o número máximo de leilões mantidos no cache de leitura
+const defaultReadCacheSize = 1000
+
+// WithReadCache liga um cache em memória na frente de FindAuctionById. Cada entrada
+// vale por ttl e o cache guarda no máximo size leilões. ttl 0 desliga o cache.
+// A invalidação é local: com várias réplicas, uma escrita feita em outra instância
+// só aparece aqui quando a entrada expira. Por isso o caminho dos lances lê com
+// FindAuctionByIdUncached, e o cache serve apenas às consultas
+func WithReadCache(ttl time.Duration, size int) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.readCache = newAuctionReadCache(ttl, size)
+	}
+}
+
+// getReadCache lê AUCTION_READ_CACHE_TTL e AUCTION_READ_CACHE_SIZE. Sem TTL o cache
+// fica desligado
+func getReadCache() *auctionReadCache {
+	ttl, err := time.ParseDuration(os.Getenv("AUCTION_READ_CACHE_TTL"))
+	if err != nil {
+		return nil
+	}
+
+	size, err := strconv.Atoi(os.Getenv("AUCTION_READ_CACHE_SIZE"))
+	if err != nil {
+		size = defaultReadCacheSize
+	}
+
+	return newAuctionReadCache(ttl, size)
+}
+
+type auctionReadCacheEntry struct {
+	auction   auction_entity.Auction
+	expiresAt time.Time
+}
+
+// auctionReadCache guarda leilões lidos por id. Um cache nil está desligado:
+// todos os métodos podem ser chamados nele sem efeito
+type auctionReadCache struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[string]auctionReadCacheEntry
+
+	// generation muda a cada invalidação, para que uma leitura iniciada antes
+	// de uma escrita não grave no cache o documento anterior a ela
+	generation uint64
+}
+
+func newAuctionReadCache(ttl time.Duration, size int) *auctionReadCache {
+	if ttl <= 0 || size <= 0 {
+		return nil
+	}
+
+	return &auctionReadCache{
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[string]auctionReadCacheEntry),
+	}
+}
+
+func (c *auctionReadCache) get(id string, now time.Time) (*auction_entity.Auction, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(entry.expiresAt) {
+		delete(c.entries, id)
+		return nil, false
+	}
+
+	auction := cloneAuction(entry.auction)
+	return &auction, true
+}
+
+// currentGeneration deve ser lida antes de consultar o MongoDB e repassada a put
+func (c *auctionReadCache) currentGeneration() uint64 {
+	if c == nil {
+		return 0
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.generation
+}
+
+func (c *auctionReadCache) put(auction *auction_entity.Auction, generation uint64, now time.Time) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	if _, ok := c.entries[auction.Id]; !ok && len(c.entries) >= c.size {
+		c.evictOldest()
+	}
+	c.entries[auction.Id] = auctionReadCacheEntry{
+		auction:   cloneAuction(*auction),
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// cloneAuction copia também as fatias do leilão, para que quem altera o leilão
+// devolvido não altere a entrada guardada no cache
+func cloneAuction(auction auction_entity.Auction) auction_entity.Auction {
+	if auction.AllowedUserIds != nil {
+		auction.AllowedUserIds = append([]string(nil), auction.AllowedUserIds...)
+	}
+	if auction.ImageURLs != nil {
+		auction.ImageURLs = append([]string(nil), auction.ImageURLs...)
+	}
+
+	return auction
+}
+
+// evictOldest remove a entrada que expira primeiro. Deve ser chamada com o mutex
+func (c *auctionReadCache) evictOldest() {
+	var oldestId string
+	var oldest time.Time
+	for id, entry := range c.entries {
+		if oldestId == "" || entry.expiresAt.Before(oldest) {
+			oldestId, oldest = id, entry.expiresAt
+		}
+	}
+	delete(c.entries, oldestId)
+}
+
+// invalidate remove os leilões informados após uma escrita neles
+func (c *auctionReadCache) invalidate(ids ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	for _, id := range ids {
+		delete(c.entries, id)
+	}
+}
+
+// invalidateAll esvazia o cache, para escritas que não sabem quais leilões alteraram
+func (c *auctionReadCache) invalidateAll() {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	c.entries = make(map[string]auctionReadCacheEntry)
+}
@@ -0,0 +1,132 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/testutil"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFindAuctionByIdServesCachedAuctionUntilWrite(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock), WithMonitorDisabled(true),
+		WithReadCache(time.Minute, 10))
+	ctx := context.Background()
+
+	seeded, err := testutil.SeedAuctions(ctx, repo, 1, testutil.WithDuration(time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to seed auction: %v", err)
+	}
+	id := seeded[0].Id
+
+	if _, findErr := repo.FindAuctionById(ctx, id); findErr != nil {
+		t.Fatalf("Failed to find auction: %v", findErr)
+	}
+
+	// Alterado direto no MongoDB, sem passar pelo repositório: um acerto no
+	// cache não consulta o banco e continua devolvendo o valor anterior
+	repo.Collection.UpdateByID(ctx, id, bson.M{"$set": bson.M{"product_name": "Changed"}})
+	cached, _ := repo.FindAuctionById(ctx, id)
+	if cached.ProductName != seeded[0].ProductName {
+		t.Errorf("Expected cached product name %s, got %s", seeded[0].ProductName, cached.ProductName)
+	}
+
+	if recordErr := repo.RecordBid(ctx, id, 50); recordErr != nil {
+		t.Fatalf("Failed to record bid: %v", recordErr)
+	}
+	fresh, _ := repo.FindAuctionById(ctx, id)
+	if fresh.ProductName != "Changed" || fresh.BidCount != 1 || fresh.CurrentHighBid != 50 {
+		t.Errorf("Expected a fresh auction after the bid, got %+v", fresh)
+	}
+
+	if closeErr := repo.CloseAuction(ctx, id); closeErr != nil {
+		t.Fatalf("Failed to close auction: %v", closeErr)
+	}
+	closed, _ := repo.FindAuctionById(ctx, id)
+	if closed.Status != auction_entity.Completed {
+		t.Errorf("Expected the closed auction after CloseAuction, got %v", closed.Status)
+	}
+
+	repo.Collection.UpdateByID(ctx, id, bson.M{"$set": bson.M{"product_name": "Expired"}})
+	clock.Advance(time.Minute)
+	expired, _ := repo.FindAuctionById(ctx, id)
+	if expired.ProductName != "Expired" {
+		t.Errorf("Expected the entry to expire after the TTL, got %s", expired.ProductName)
+	}
+}
+
+func TestAuctionReadCache(t *testing.T) {
+	var disabled *auctionReadCache
+	now := time.Now()
+	disabled.put(&auction_entity.Auction{Id: "a"}, disabled.currentGeneration(), now)
+	disabled.invalidate("a")
+	disabled.invalidateAll()
+	if _, ok := disabled.get("a", now); ok {
+		t.Error("Expected a disabled cache to never hit")
+	}
+	if newAuctionReadCache(0, 10) != nil {
+		t.Error("Expected a zero TTL to disable the cache")
+	}
+
+	cache := newAuctionReadCache(time.Minute, 2)
+	cache.put(&auction_entity.Auction{Id: "a"}, cache.currentGeneration(), now)
+	cache.put(&auction_entity.Auction{Id: "b"}, cache.currentGeneration(), now.Add(time.Second))
+	cache.put(&auction_entity.Auction{Id: "c"}, cache.currentGeneration(), now.Add(2*time.Second))
+	if _, ok := cache.get("a", now); ok {
+		t.Error("Expected the oldest entry to be evicted when the cache is full")
+	}
+	if _, ok := cache.get("c", now); !ok {
+		t.Error("Expected the newest entry to be cached")
+	}
+
+	// Uma leitura iniciada antes de uma escrita não pode repovoar o cache
+	generation := cache.currentGeneration()
+	cache.invalidate("b")
+	cache.put(&auction_entity.Auction{Id: "b"}, generation, now)
+	if _, ok := cache.get("b", now); ok {
+		t.Error("Expected a read that raced a write to be discarded")
+	}
+
+	cached, _ := cache.get("c", now)
+	cached.ProductName = "mutated"
+	if again, _ := cache.get("c", now); again.ProductName == "mutated" {
+		t.Error("Expected callers to receive a copy of the cached auction")
+	}
+
+	stored := &auction_entity.Auction{Id: "d", AllowedUserIds: []string{"user"}}
+	cache.put(stored, cache.currentGeneration(), now)
+	stored.AllowedUserIds[0] = "changed by the writer"
+	withSlices, _ := cache.get("d", now)
+	withSlices.AllowedUserIds[0] = "changed by the reader"
+	if again, _ := cache.get("d", now); again.AllowedUserIds[0] != "user" {
+		t.Errorf("Expected the cached slices to be copied, got %v", again.AllowedUserIds)
+	}
+}
+
+func TestBiddableAuctionBypassesReadCache(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true), WithReadCache(time.Minute, 10))
+	ctx := context.Background()
+
+	seeded, err := testutil.SeedAuctions(ctx, repo, 1, testutil.WithDuration(time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to seed auction: %v", err)
+	}
+	id := seeded[0].Id
+	repo.FindAuctionById(ctx, id)
+
+	// Fechado por outra réplica: o cache desta instância não fica sabendo
+	repo.Collection.UpdateByID(ctx, id, bson.M{"$set": bson.M{"status": auction_entity.Completed}})
+
+	if _, biddable, _, findErr := repo.BiddableAuction(ctx, id); findErr != nil || biddable {
+		t.Errorf("Expected bids to see the auction closed elsewhere, biddable=%v err=%v", biddable, findErr)
+	}
+}
@@ -0,0 +1,95 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// WithReconciler define o intervalo do reconciliador e há quanto tempo um leilão
+// agendado precisa ter passado do início para ser considerado preso
+func WithReconciler(interval, threshold time.Duration) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.reconcileInterval = interval
+		ar.reconcileThreshold = threshold
+	}
+}
+
+// WithReconcilerDisabled desliga o reconciliador de leilões agendados
+func WithReconcilerDisabled(disabled bool) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.reconcilerDisabled = disabled
+	}
+}
+
+// getReconcileInterval lê AUCTION_RECONCILE_INTERVAL. O padrão é 10 minutos
+func getReconcileInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("AUCTION_RECONCILE_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return 10 * time.Minute
+	}
+
+	return interval
+}
+
+// getReconcileThreshold lê AUCTION_RECONCILE_THRESHOLD. O padrão é 10 minutos
+func getReconcileThreshold() time.Duration {
+	threshold, err := time.ParseDuration(os.Getenv("AUCTION_RECONCILE_THRESHOLD"))
+	if err != nil || threshold <= 0 {
+		return 10 * time.Minute
+	}
+
+	return threshold
+}
+
+// reconcileScheduledAuctions roda uma passada na inicialização e depois a cada
+// intervalo, como rede de segurança para a ativação feita pelo monitor. Como o
+// monitor, só a instância líder executa as passadas
+func (ar *AuctionRepository) reconcileScheduledAuctions(ctx context.Context) {
+	ticker := time.NewTicker(ar.reconcileInterval)
+	defer ticker.Stop()
+
+	ar.logger.Info("Scheduled auction reconciler started",
+		zap.Duration("interval", ar.reconcileInterval),
+		zap.Duration("threshold", ar.reconcileThreshold))
+
+	for {
+		if hasTimeForSweep(ctx, ar.writeTimeout) && ar.isLeader(ctx) {
+			passCtx, cancel := withTimeout(ctx, ar.writeTimeout)
+			ar.ReconcileScheduledAuctionsOnce(passCtx)
+			cancel()
+		}
+
+		select {
+		case <-ctx.Done():
+			ar.logger.Info("Scheduled auction reconciler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReconcileScheduledAuctionsOnce ativa os leilões agendados cujo início passou há mais
+// que o limite configurado. Eles deveriam ter sido ativados pelo monitor, então cada um
+// é registrado como anomalia. Retorna quantos foram ativados
+func (ar *AuctionRepository) ReconcileScheduledAuctionsOnce(
+	ctx context.Context) (int64, *internal_error.InternalError) {
+	now := ar.clock.Now()
+	overdueAuctions, err := ar.findScheduledStartedBy(ctx, now.Add(-ar.reconcileThreshold))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, auction := range overdueAuctions {
+		startsAt := unixUTC(auction.StartsAt)
+		ar.logger.Warn("Activating overdue scheduled auction",
+			zap.String("auction_id", auction.Id),
+			zap.Time("starts_at", startsAt),
+			zap.Duration("overdue", now.Sub(startsAt)))
+	}
+
+	return ar.activateAuctions(ctx, auctionIds(overdueAuctions), now)
+}
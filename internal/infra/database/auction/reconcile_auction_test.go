@@ -0,0 +1,146 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReconcileScheduledAuctionsActivatesOverdueAuctions(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now().Truncate(time.Second))
+	capturing := newCapturingLogger()
+	repo := newTestRepository(t, db,
+		WithClock(clock),
+		WithMonitorDisabled(true),
+		WithReconciler(time.Minute, 10*time.Minute),
+		WithLogger(capturing))
+	ctx := context.Background()
+
+	createScheduled := func(startsAt time.Time) *auction_entity.Auction {
+		auction, err := auction_entity.CreateAuction(
+			"Notebook", "Electronics", "Notebook Dell Inspiron 15", auction_entity.Used,
+			auction_entity.WithStartsAt(clock.Now().Add(time.Hour)))
+		if err != nil {
+			t.Fatalf("Failed to build auction: %v", err)
+		}
+		// O início fica no passado como se o monitor não o tivesse ativado a tempo
+		auction.StartsAt = startsAt
+		auction.Timestamp = startsAt.Add(-time.Hour)
+		if err := repo.CreateAuction(ctx, auction); err != nil {
+			t.Fatalf("Failed to create auction: %v", err)
+		}
+		return auction
+	}
+	overdue := createScheduled(clock.Now().Add(-2 * time.Hour))
+	recent := createScheduled(clock.Now().Add(-time.Minute))
+
+	activated, err := repo.ReconcileScheduledAuctionsOnce(ctx)
+	if err != nil {
+		t.Fatalf("Failed to reconcile scheduled auctions: %v", err)
+	}
+	if activated != 1 {
+		t.Errorf("Expected 1 activated auction, got %d", activated)
+	}
+
+	found, _ := repo.FindAuctionById(ctx, overdue.Id)
+	if found.Status != auction_entity.Active || !found.ActivatedAt.Equal(clock.Now()) {
+		t.Errorf("Expected the overdue auction to be Active since %v, got %s since %v",
+			clock.Now(), found.Status, found.ActivatedAt)
+	}
+
+	// Atrasos dentro do limite ficam para a ativação normal do monitor
+	found, _ = repo.FindAuctionById(ctx, recent.Id)
+	if found.Status != auction_entity.Scheduled {
+		t.Errorf("Expected the recently due auction to stay Scheduled, got %s", found.Status)
+	}
+
+	log, ok := capturing.find("Activating overdue scheduled auction")
+	if !ok || log.level != "warn" {
+		t.Fatalf("Expected a warning for the overdue auction, got %+v", log)
+	}
+	if log.fields["auction_id"] != overdue.Id || log.fields["overdue"] != 2*time.Hour {
+		t.Errorf("Unexpected warning fields: %+v", log.fields)
+	}
+}
+
+// followerLocker nunca obtém a liderança
+type followerLocker struct{}
+
+func (followerLocker) TryAcquire(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+func createOverdueAuction(t *testing.T, repo *AuctionRepository, startsAt time.Time) *auction_entity.Auction {
+	auction, err := auction_entity.CreateAuction(
+		"Notebook", "Electronics", "Notebook Dell Inspiron 15", auction_entity.Used,
+		auction_entity.WithStartsAt(time.Now().Add(time.Hour)))
+	if err != nil {
+		t.Fatalf("Failed to build auction: %v", err)
+	}
+	auction.StartsAt = startsAt
+	auction.Timestamp = startsAt.Add(-time.Hour)
+	if err := repo.CreateAuction(context.Background(), auction); err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+	return auction
+}
+
+func TestActivateAuctionsEmitsOnceUnderConcurrency(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	publisher := &recordingPublisher{mutex: &sync.Mutex{}}
+	repo := newTestRepository(t, db, WithMonitorDisabled(true), WithPublisher(publisher))
+	ctx := context.Background()
+
+	overdue := createOverdueAuction(t, repo, time.Now().Add(-time.Hour))
+
+	// O monitor e o reconciliador de várias réplicas disputam o mesmo leilão
+	var activated atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count, _ := repo.activateAuctions(ctx, []string{overdue.Id}, time.Now())
+			activated.Add(count)
+		}()
+	}
+	wg.Wait()
+
+	if activated.Load() != 1 {
+		t.Errorf("Expected a single activation, got %d", activated.Load())
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Name != AuctionActivatedEvent {
+		t.Errorf("Expected a single activated event, got %+v", publisher.events)
+	}
+
+	history, _ := repo.GetAuctionHistory(ctx, overdue.Id)
+	if len(history) != 2 || history[1].ToStatus != auction_entity.Active {
+		t.Errorf("Expected a single activation transition, got %+v", history)
+	}
+}
+
+func TestReconcilerRunsOnlyOnLeader(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db,
+		WithLocker(followerLocker{}),
+		WithReconciler(10*time.Millisecond, time.Minute))
+	defer repo.Shutdown(context.Background())
+
+	overdue := createOverdueAuction(t, repo, time.Now().Add(-time.Hour))
+	time.Sleep(100 * time.Millisecond)
+
+	found, _ := repo.FindAuctionById(context.Background(), overdue.Id)
+	if found.Status != auction_entity.Scheduled {
+		t.Errorf("Expected a follower not to activate the overdue auction, got %s", found.Status)
+	}
+}
@@ -0,0 +1,98 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// WithReopenEnabled libera ou bloqueia a reabertura administrativa de leilões
+func WithReopenEnabled(enabled bool) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.reopenEnabled = enabled
+	}
+}
+
+// ReopenAuction devolve um leilão Completed ao status Active com um novo fim,
+// para corrigir fechamentos indevidos do monitor. É uma operação administrativa,
+// liberada via AUCTION_REOPEN_ENABLED, e recusa leilões que já receberam lances
+func (ar *AuctionRepository) ReopenAuction(
+	ctx context.Context, id string, newEndsAt time.Time) *internal_error.InternalError {
+	if err := ar.checkAuctionId(id); err != nil {
+		return err
+	}
+
+	if !ar.reopenEnabled {
+		return internal_error.NewBadRequestError("Auction reopen is disabled")
+	}
+
+	if !newEndsAt.After(ar.clock.Now()) {
+		return internal_error.NewBadRequestError("New auction end time must be in the future")
+	}
+
+	filter := bson.M{
+		"_id":    ar.DocumentId(id),
+		"status": auction_entity.Completed,
+		"$or": bson.A{
+			bson.M{"bid_count": 0},
+			bson.M{"bid_count": bson.M{"$exists": false}},
+		},
+	}
+	// Remove completed_at para que a retenção não apague um leilão reaberto
+	update := bson.M{
+		"$set": bson.M{
+			"status":   auction_entity.Active,
+			"end_time": newEndsAt.Unix(),
+		},
+		"$unset": bson.M{"completed_at": ""},
+		"$inc":   bson.M{"version": 1},
+	}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	ar.readCache.invalidate(id)
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to reopen auction %s", id), err)
+		return internal_error.NewInternalServerError("Error trying to reopen auction")
+	}
+
+	if result.MatchedCount == 0 {
+		return ar.unreopenableAuctionError(ctx, id)
+	}
+
+	ar.recordTransitions(ctx, []string{id},
+		statusPtr(auction_entity.Completed), auction_entity.Active, TransitionReopened)
+
+	ar.logger.Warn("Auction reopened by admin", logger.ContextFields(ctx,
+		zap.String("auction_id", id),
+		zap.Time("ends_at", newEndsAt))...)
+	return nil
+}
+
+// unreopenableAuctionError explica por que a reabertura não encontrou o leilão
+func (ar *AuctionRepository) unreopenableAuctionError(
+	ctx context.Context, id string) *internal_error.InternalError {
+	var auctionEntityMongo AuctionEntityMongo
+	err := ar.Collection.FindOne(ctx, bson.M{"_id": ar.DocumentId(id)}).Decode(&auctionEntityMongo)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return internal_error.NewNotFoundError(fmt.Sprintf("Auction not found with this id = %s", id))
+	}
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to find auction by id = %s", id), err)
+		return internal_error.NewInternalServerError("Error trying to find auction by id")
+	}
+
+	if auctionEntityMongo.Status != auction_entity.Completed {
+		return internal_error.NewBadRequestError(fmt.Sprintf("Auction %s is not completed", id))
+	}
+
+	return internal_error.NewBadRequestError(
+		fmt.Sprintf("Auction %s already has bids and cannot be reopened", id))
+}
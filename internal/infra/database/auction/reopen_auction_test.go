@@ -0,0 +1,81 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+)
+
+func TestReopenAuction(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock), WithReopenEnabled(true))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	auction.Status = auction_entity.Completed
+	repo.CreateAuction(ctx, auction)
+
+	newEndsAt := clock.Now().Add(time.Hour)
+	if err := repo.ReopenAuction(ctx, auction.Id, newEndsAt); err != nil {
+		t.Fatalf("Failed to reopen auction: %v", err)
+	}
+
+	found, _ := repo.FindAuctionById(ctx, auction.Id)
+	if found.Status != auction_entity.Active {
+		t.Errorf("Expected auction to be Active, got %s", found.Status)
+	}
+	if found.EndsAt.Unix() != newEndsAt.Unix() {
+		t.Errorf("Expected auction to end at %v, got %v", newEndsAt, found.EndsAt)
+	}
+
+	// O novo fim impede que o monitor feche o leilão de novo imediatamente
+	if ids := repo.closeExpiredAuctions(ctx, repo.auctionDuration); len(ids) != 0 {
+		t.Errorf("Expected reopened auction to stay open, closed %v", ids)
+	}
+}
+
+func TestReopenAuctionRejectsAuctionWithBids(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithReopenEnabled(true))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	repo.CreateAuction(ctx, auction)
+	repo.RecordBid(ctx, auction.Id, 100)
+	repo.CloseAuction(ctx, auction.Id)
+
+	err := repo.ReopenAuction(ctx, auction.Id, time.Now().Add(time.Hour))
+	if err == nil || err.Err != "bad_request" {
+		t.Fatalf("Expected bad_request when reopening an auction with bids, got %v", err)
+	}
+
+	found, _ := repo.FindAuctionById(ctx, auction.Id)
+	if found.Status != auction_entity.Completed {
+		t.Errorf("Expected auction to remain Completed, got %s", found.Status)
+	}
+}
+
+func TestReopenAuctionRequiresAdminFlag(t *testing.T) {
+	repo := &AuctionRepository{clock: realClock{}}
+
+	err := repo.ReopenAuction(context.Background(), "auction-id", time.Now().Add(time.Hour))
+	if err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request when reopen is disabled, got %v", err)
+	}
+}
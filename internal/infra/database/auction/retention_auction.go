@@ -0,0 +1,96 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// retentionIndexName identifica o índice TTL que apaga leilões encerrados antigos
+const retentionIndexName = "completed_at_ttl"
+
+// ensureRetentionIndex mantém o índice TTL sobre completed_at de acordo com
+// AUCTION_RETENTION. Sem retenção configurada o índice é removido, para que o
+// MongoDB nunca apague leilões sem que isso tenha sido pedido
+func (ar *AuctionRepository) ensureRetentionIndex(ctx context.Context) *internal_error.InternalError {
+	expireAfterSeconds, exists, err := ar.retentionIndexExpiry(ctx)
+	if err != nil {
+		ar.logger.Error("Error trying to list auction indexes", err)
+		return internal_error.NewInternalServerError("Error trying to list auction indexes")
+	}
+
+	wanted := int32(ar.retention.Seconds())
+	if exists && expireAfterSeconds == wanted {
+		return nil
+	}
+
+	if exists {
+		if _, err := ar.Collection.Indexes().DropOne(ctx, retentionIndexName); err != nil {
+			ar.logger.Error("Error trying to drop auction retention index", err)
+			return internal_error.NewInternalServerError("Error trying to drop auction retention index")
+		}
+	}
+
+	if wanted <= 0 {
+		return nil
+	}
+
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "completed_at", Value: 1}},
+		Options: options.Index().
+			SetName(retentionIndexName).
+			SetExpireAfterSeconds(wanted),
+	}
+	if _, err := ar.Collection.Indexes().CreateOne(ctx, index); err != nil {
+		ar.logger.Error("Error trying to create auction retention index", err)
+		return internal_error.NewInternalServerError("Error trying to create auction retention index")
+	}
+
+	ar.logger.Info("Auction retention enabled", zap.Duration("retention", ar.retention))
+	return nil
+}
+
+// retentionIndexExpiry retorna o expireAfterSeconds do índice de retenção, se ele existir
+func (ar *AuctionRepository) retentionIndexExpiry(ctx context.Context) (int32, bool, error) {
+	cursor, err := ar.Collection.Indexes().List(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var index struct {
+			Name               string `bson:"name"`
+			ExpireAfterSeconds *int32 `bson:"expireAfterSeconds"`
+		}
+		if err := cursor.Decode(&index); err != nil {
+			return 0, false, err
+		}
+
+		if index.Name == retentionIndexName {
+			if index.ExpireAfterSeconds == nil {
+				return 0, true, nil
+			}
+			return *index.ExpireAfterSeconds, true, nil
+		}
+	}
+
+	return 0, false, cursor.Err()
+}
+
+// getRetention retorna por quanto tempo leilões encerrados são mantidos, baseado
+// em AUCTION_RETENTION. Se não estiver definido, a retenção fica desativada
+func getRetention() time.Duration {
+	retention, err := time.ParseDuration(os.Getenv("AUCTION_RETENTION"))
+	if err != nil || retention < time.Second {
+		return 0
+	}
+
+	return retention
+}
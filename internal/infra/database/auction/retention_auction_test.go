@@ -0,0 +1,57 @@
+package auction
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestEnsureIndexesCreatesRetentionIndexWhenConfigured(t *testing.T) {
+	os.Setenv("AUCTION_RETENTION", "720h")
+	defer os.Unsetenv("AUCTION_RETENTION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	if err := repo.EnsureIndexes(ctx); err != nil {
+		t.Fatalf("Failed to ensure indexes: %v", err)
+	}
+
+	expireAfterSeconds, exists, err := repo.retentionIndexExpiry(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list indexes: %v", err)
+	}
+	if !exists {
+		t.Fatal("Expected the retention index to be created")
+	}
+	if expireAfterSeconds != 720*60*60 {
+		t.Errorf("Expected expireAfterSeconds to be %d, got %d", 720*60*60, expireAfterSeconds)
+	}
+}
+
+func TestEnsureIndexesSkipsRetentionIndexByDefault(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Uma configuração anterior deixou o índice criado
+	configured := newTestRepository(t, db)
+	configured.retention = 24 * time.Hour
+	if err := configured.EnsureIndexes(ctx); err != nil {
+		t.Fatalf("Failed to ensure indexes: %v", err)
+	}
+
+	repo := newTestRepository(t, db)
+	if err := repo.EnsureIndexes(ctx); err != nil {
+		t.Fatalf("Failed to ensure indexes: %v", err)
+	}
+
+	if _, exists, _ := repo.retentionIndexExpiry(ctx); exists {
+		t.Error("Expected no retention index without AUCTION_RETENTION")
+	}
+}
@@ -0,0 +1,128 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/event"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const AuctionActivatedEvent = "auction.activated"
+
+type AuctionActivatedPayload struct {
+	AuctionId   string    `json:"auction_id" bson:"auction_id"`
+	ActivatedAt time.Time `json:"activated_at" bson:"activated_at"`
+}
+
+// activateScheduledAuctions ativa os leilões agendados cujo início já chegou,
+// registrando o momento da ativação e emitindo AuctionActivated. O fim de cada um é
+// recalculado a partir da ativação, somando a duração guardada na criação
+func (ar *AuctionRepository) activateScheduledAuctions(
+	ctx context.Context) (int64, *internal_error.InternalError) {
+	now := ar.clock.Now()
+	dueAuctions, err := ar.findScheduledStartedBy(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return ar.activateAuctions(ctx, auctionIds(dueAuctions), now)
+}
+
+// findScheduledStartedBy retorna os leilões agendados cujo início é até cutoff
+func (ar *AuctionRepository) findScheduledStartedBy(
+	ctx context.Context, cutoff time.Time) ([]AuctionEntityMongo, *internal_error.InternalError) {
+	filter := bson.M{
+		"status":    auction_entity.Scheduled,
+		"starts_at": bson.M{"$lte": cutoff.Unix()},
+	}
+	projection := bson.M{"_id": 1, "starts_at": 1}
+
+	cursor, err := ar.Collection.Find(ctx, filter, options.Find().SetProjection(projection))
+	if err != nil {
+		ar.logger.Error("Error trying to find scheduled auctions", err)
+		return nil, internal_error.NewInternalServerError("Error trying to find scheduled auctions")
+	}
+	defer cursor.Close(ctx)
+
+	var dueAuctions []AuctionEntityMongo
+	if err := cursor.All(ctx, &dueAuctions); err != nil {
+		ar.logger.Error("Error decoding scheduled auctions", err)
+		return nil, internal_error.NewInternalServerError("Error decoding scheduled auctions")
+	}
+
+	return dueAuctions, nil
+}
+
+func auctionIds(auctions []AuctionEntityMongo) []string {
+	ids := make([]string, 0, len(auctions))
+	for _, auction := range auctions {
+		ids = append(ids, auction.Id)
+	}
+
+	return ids
+}
+
+// activateAuctions ativa os leilões agendados informados, registrando o momento da
+// ativação, a transição no histórico e o evento AuctionActivated. Cada leilão é ativado
+// com o status no filtro, e os efeitos só disparam para os que esta chamada ativou: o
+// monitor e o reconciliador podem disputar o mesmo leilão
+func (ar *AuctionRepository) activateAuctions(
+	ctx context.Context, dueIds []string, now time.Time) (int64, *internal_error.InternalError) {
+	if len(dueIds) == 0 {
+		return 0, nil
+	}
+
+	// Pipeline de atualização para calcular o fim a partir da duração de cada documento
+	update := bson.A{bson.M{"$set": bson.M{
+		"status":       auction_entity.Active,
+		"activated_at": now,
+		"end_time":     bson.M{"$add": bson.A{now.Unix(), "$duration_seconds"}},
+		"version":      bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$version", 0}}, 1}},
+	}}}
+
+	activatedIds := make([]string, 0, len(dueIds))
+	var activateErr *internal_error.InternalError
+	for _, id := range dueIds {
+		result, err := ar.Collection.UpdateOne(ctx,
+			bson.M{"_id": ar.DocumentId(id), "status": auction_entity.Scheduled}, update)
+		ar.readCache.invalidate(id)
+		if err != nil {
+			ar.logger.Error("Error trying to activate scheduled auction", err, zap.String("auction_id", id))
+			activateErr = internal_error.NewInternalServerError("Error trying to activate scheduled auctions")
+			continue
+		}
+		if result.ModifiedCount == 1 {
+			activatedIds = append(activatedIds, id)
+		}
+	}
+
+	ar.recordTransitions(ctx, activatedIds,
+		statusPtr(auction_entity.Scheduled), auction_entity.Active, TransitionActivated)
+	ar.publishActivated(ctx, activatedIds, now)
+
+	ar.logger.Info("Activated scheduled auctions",
+		zap.Int("modified_count", len(activatedIds)),
+		zap.Strings("auction_ids", activatedIds))
+
+	return int64(len(activatedIds)), activateErr
+}
+
+// publishActivated emite um evento por leilão ativado quando há publisher ou event store
+func (ar *AuctionRepository) publishActivated(
+	ctx context.Context, auctionIds []string, activatedAt time.Time) {
+	if !ar.publishesEvents() {
+		return
+	}
+
+	for _, auctionId := range auctionIds {
+		ar.publish(ctx, event.NewEvent(AuctionActivatedEvent, AuctionActivatedPayload{
+			AuctionId:   auctionId,
+			ActivatedAt: activatedAt.UTC(),
+		}))
+	}
+}
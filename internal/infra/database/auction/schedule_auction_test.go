@@ -0,0 +1,116 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduledAuctionActivatesAtStartAndRunsFromActivation(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now().Truncate(time.Second))
+	repo := newTestRepository(t, db, WithClock(clock), WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Notebook", "Electronics", "Notebook Dell Inspiron 15", auction_entity.Used,
+		auction_entity.WithDuration(5*time.Minute),
+		auction_entity.WithStartsAt(clock.Now().Add(10*time.Minute)))
+	auction.Timestamp = clock.Now()
+	if err := repo.CreateAuction(ctx, auction); err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+
+	statusAfter := func(advance time.Duration) *auction_entity.Auction {
+		clock.Advance(advance)
+		repo.handleTick(ctx, repo.auctionDuration)
+
+		found, err := repo.FindAuctionById(ctx, auction.Id)
+		if err != nil {
+			t.Fatalf("Failed to find auction: %v", err)
+		}
+		return found
+	}
+
+	if found := statusAfter(5 * time.Minute); found.Status != auction_entity.Scheduled {
+		t.Fatalf("Expected auction to stay Scheduled before its start, got %s", found.Status)
+	}
+
+	// Ativado 2 minutos depois do início previsto: o fim conta a partir da ativação
+	found := statusAfter(7 * time.Minute)
+	if found.Status != auction_entity.Active {
+		t.Fatalf("Expected auction to be Active after its start, got %s", found.Status)
+	}
+	if expected := clock.Now().Add(5 * time.Minute); !found.EndsAt.Equal(expected) {
+		t.Errorf("Expected auction to end at %v, got %v", expected, found.EndsAt)
+	}
+
+	if found := statusAfter(4 * time.Minute); found.Status != auction_entity.Active {
+		t.Errorf("Expected auction to stay Active within its duration from activation, got %s",
+			found.Status)
+	}
+
+	if found := statusAfter(2 * time.Minute); found.Status != auction_entity.Completed {
+		t.Errorf("Expected auction to be Completed after its duration, got %s", found.Status)
+	}
+}
+
+func TestActivateScheduledAuctionsActivatesOnlyDueAuctions(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now().Truncate(time.Second))
+	publisher := &recordingPublisher{mutex: &sync.Mutex{}}
+	repo := newTestRepository(t, db,
+		WithClock(clock),
+		WithMonitorDisabled(true),
+		WithPublisher(publisher))
+	ctx := context.Background()
+
+	createScheduled := func(startsIn time.Duration) *auction_entity.Auction {
+		auction, err := auction_entity.CreateAuction(
+			"Notebook", "Electronics", "Notebook Dell Inspiron 15", auction_entity.Used,
+			auction_entity.WithStartsAt(clock.Now().Add(startsIn)))
+		if err != nil {
+			t.Fatalf("Failed to build auction: %v", err)
+		}
+		auction.Timestamp = clock.Now()
+		if err := repo.CreateAuction(ctx, auction); err != nil {
+			t.Fatalf("Failed to create auction: %v", err)
+		}
+		return auction
+	}
+	due := createScheduled(time.Minute)
+	future := createScheduled(time.Hour)
+
+	clock.Advance(2 * time.Minute)
+	activated, err := repo.activateScheduledAuctions(ctx)
+	if err != nil {
+		t.Fatalf("Failed to activate scheduled auctions: %v", err)
+	}
+	if activated != 1 {
+		t.Errorf("Expected 1 activated auction, got %d", activated)
+	}
+
+	found, _ := repo.FindAuctionById(ctx, due.Id)
+	if found.Status != auction_entity.Active || !found.ActivatedAt.Equal(clock.Now()) {
+		t.Errorf("Expected the due auction to be Active since %v, got %s since %v",
+			clock.Now(), found.Status, found.ActivatedAt)
+	}
+
+	found, _ = repo.FindAuctionById(ctx, future.Id)
+	if found.Status != auction_entity.Scheduled || !found.ActivatedAt.IsZero() {
+		t.Errorf("Expected the future auction to stay Scheduled, got %s", found.Status)
+	}
+
+	if len(publisher.events) != 1 || publisher.events[0].Name != AuctionActivatedEvent {
+		t.Fatalf("Expected one %s event, got %+v", AuctionActivatedEvent, publisher.events)
+	}
+	if payload := publisher.events[0].Payload.(AuctionActivatedPayload); payload.AuctionId != due.Id {
+		t.Errorf("Expected the event for auction %s, got %s", due.Id, payload.AuctionId)
+	}
+}
@@ -0,0 +1,58 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.uber.org/zap"
+)
+
+// runInBackground inicia uma goroutine de fundo do repositório, aguardada por Shutdown
+func (ar *AuctionRepository) runInBackground(ctx context.Context, run func(context.Context)) {
+	ar.background.Add(1)
+	go func() {
+		defer ar.background.Done()
+		run(ctx)
+	}()
+}
+
+// Shutdown encerra o monitor, o reconciliador e o drainer do outbox, aguardando que
+// terminem o tick em andamento, e faz uma última tentativa de entregar os eventos
+// pendentes do outbox. Retorna erro se ctx expirar antes disso. Chamadas repetidas não
+// têm efeito
+func (ar *AuctionRepository) Shutdown(ctx context.Context) *internal_error.InternalError {
+	var shutdownErr *internal_error.InternalError
+
+	ar.shutdownOnce.Do(func() {
+		ar.stopBackground()
+
+		stopped := make(chan struct{})
+		go func() {
+			ar.background.Wait()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			ar.logger.Warn("Auction repository shutdown timed out waiting for background work")
+			shutdownErr = internal_error.NewInternalServerError(
+				"Timed out waiting for auction background work to stop")
+			return
+		}
+
+		var flushed int64
+		if ar.shouldDrainOutbox() {
+			flushed, _ = ar.DrainOutboxOnce(ctx)
+		}
+
+		stats := ar.Stats()
+		ar.logger.Info("Auction repository shut down",
+			zap.Int64("total_closed", stats.TotalClosed),
+			zap.Int64("total_ticks", stats.TotalTicks),
+			zap.Duration("uptime", ar.clock.Now().Sub(stats.StartedAt)),
+			zap.Int64("outbox_flushed", flushed))
+	})
+
+	return shutdownErr
+}
@@ -0,0 +1,70 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/testutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestShutdownLogsFinalMonitorStats(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "1s")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	capturing := newCapturingLogger()
+	repo := newTestRepository(t, db, WithLogger(capturing))
+	ctx := context.Background()
+
+	if _, err := testutil.SeedAuctions(ctx, repo, 3, testutil.MakeExpired); err != nil {
+		t.Fatalf("Failed to seed expired auctions: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.Stats().TotalClosed < 3 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if closed := repo.Stats().TotalClosed; closed != 3 {
+		t.Fatalf("Expected the monitor to close 3 auctions, got %d", closed)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := repo.Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("Failed to shut down: %v", err)
+	}
+
+	stopped, ok := capturing.find("Auction expiration monitor stopped")
+	if !ok {
+		t.Fatal("Expected the monitor to log that it stopped")
+	}
+	if stopped.fields["total_closed"] != int64(3) {
+		t.Errorf("Expected total_closed = 3, got %v", stopped.fields["total_closed"])
+	}
+	if ticks, _ := stopped.fields["total_ticks"].(int64); ticks < 1 {
+		t.Errorf("Expected at least one tick, got %v", stopped.fields["total_ticks"])
+	}
+	if uptime, _ := stopped.fields["uptime"].(time.Duration); uptime <= 0 {
+		t.Errorf("Expected a positive uptime, got %v", stopped.fields["uptime"])
+	}
+
+	summary, ok := capturing.find("Auction repository shut down")
+	if !ok || summary.fields["total_closed"] != int64(3) || summary.fields["outbox_flushed"] != int64(0) {
+		t.Errorf("Expected the shutdown summary with the totals, got %+v", summary)
+	}
+
+	// Chamadas repetidas não registram um novo resumo
+	repo.Shutdown(shutdownCtx)
+	count := 0
+	for _, log := range capturing.logs {
+		if log.message == "Auction repository shut down" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected a single shutdown summary, got %d", count)
+	}
+}
@@ -0,0 +1,32 @@
+package auction
+
+import "time"
+
+// AuctionCloseStats reúne os números acumulados do fechamento automático de leilões
+type AuctionCloseStats struct {
+	TotalClosed    int64     `json:"total_closed"`
+	LastTickClosed int64     `json:"last_tick_closed"`
+	LastClosedAt   time.Time `json:"last_closed_at"`
+	TotalTicks     int64     `json:"total_ticks"`
+	StartedAt      time.Time `json:"started_at"`
+}
+
+// Stats retorna uma cópia das estatísticas de fechamento do repositório
+func (ar *AuctionRepository) Stats() AuctionCloseStats {
+	ar.statsMutex.Lock()
+	defer ar.statsMutex.Unlock()
+
+	return ar.stats
+}
+
+// recordCloseResult atualiza as estatísticas com o resultado de uma varredura de fechamento
+func (ar *AuctionRepository) recordCloseResult(closed int64, at time.Time) {
+	ar.statsMutex.Lock()
+	defer ar.statsMutex.Unlock()
+
+	ar.stats.LastTickClosed = closed
+	if closed > 0 {
+		ar.stats.TotalClosed += closed
+		ar.stats.LastClosedAt = at
+	}
+}
@@ -0,0 +1,59 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStatsAfterClosingExpiredAuctions(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	// Cria 3 leilões expirados
+	for i := 0; i < 3; i++ {
+		auction, _ := auction_entity.CreateAuction(
+			"Expired Product",
+			"Electronics",
+			"This auction should expire",
+			auction_entity.New,
+		)
+		auction.Timestamp = time.Now().Add(-20 * time.Minute)
+		if err := repo.CreateAuction(ctx, auction); err != nil {
+			t.Fatalf("Failed to create auction: %v", err)
+		}
+	}
+
+	before := time.Now()
+	repo.closeExpiredAuctions(ctx, 10*time.Minute)
+
+	stats := repo.Stats()
+	if stats.TotalClosed != 3 {
+		t.Errorf("Expected 3 total closed auctions, got %d", stats.TotalClosed)
+	}
+	if stats.LastTickClosed != 3 {
+		t.Errorf("Expected 3 auctions closed in the last tick, got %d", stats.LastTickClosed)
+	}
+	if stats.LastClosedAt.Before(before) {
+		t.Errorf("Expected last close time after %v, got %v", before, stats.LastClosedAt)
+	}
+
+	// Uma nova varredura sem leilões expirados não altera o total
+	repo.closeExpiredAuctions(ctx, 10*time.Minute)
+
+	stats = repo.Stats()
+	if stats.TotalClosed != 3 {
+		t.Errorf("Expected total closed to remain 3, got %d", stats.TotalClosed)
+	}
+	if stats.LastTickClosed != 0 {
+		t.Errorf("Expected 0 auctions closed in the last tick, got %d", stats.LastTickClosed)
+	}
+}
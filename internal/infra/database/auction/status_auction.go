@@ -0,0 +1,57 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// auctionStatusProjection limita o documento retornado aos campos de AuctionStatusView
+var auctionStatusProjection = bson.M{
+	"_id":              0,
+	"status":           1,
+	"current_high_bid": 1,
+	"end_time":         1,
+	"bid_count":        1,
+}
+
+// FindAuctionStatus busca apenas o status, o maior lance, o fim e a quantidade de
+// lances do leilão, para consultas frequentes sem trafegar o documento inteiro.
+// Documentos antigos, sem end_time, retornam EndsAt zerado
+func (ar *AuctionRepository) FindAuctionStatus(
+	ctx context.Context, id string) (*auction_entity.AuctionStatusView, *internal_error.InternalError) {
+	if err := ar.checkAuctionId(id); err != nil {
+		return nil, err
+	}
+
+	opts := options.FindOne().SetProjection(auctionStatusProjection)
+
+	ctx, cancel := withTimeout(ctx, ar.readTimeout)
+	defer cancel()
+
+	var auctionEntityMongo AuctionEntityMongo
+	err := ar.Collection.FindOne(ctx, bson.M{"_id": ar.DocumentId(id)}, opts).Decode(&auctionEntityMongo)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, internal_error.NewNotFoundError(fmt.Sprintf("Auction not found with this id = %s", id))
+	}
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to find auction status by id = %s", id), err,
+			logger.ContextFields(ctx, zap.String("auction_id", id))...)
+		return nil, readError(err, "Error trying to find auction status by id")
+	}
+
+	return &auction_entity.AuctionStatusView{
+		Status:         auctionEntityMongo.Status,
+		CurrentHighBid: auctionEntityMongo.CurrentHighBid,
+		EndsAt:         unixOrZero(auctionEntityMongo.EndTime),
+		BidCount:       auctionEntityMongo.BidCount,
+	}, nil
+}
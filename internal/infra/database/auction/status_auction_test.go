@@ -0,0 +1,58 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestFindAuctionStatusReturnsOnlyStatusFields(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	seeded, err := testutil.SeedAuctions(ctx, repo, 1, testutil.WithDuration(time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to seed auction: %v", err)
+	}
+	auction := seeded[0]
+	repo.Collection.UpdateByID(ctx, auction.Id,
+		bson.M{"$set": bson.M{"bid_count": 2, "current_high_bid": 99.9}})
+
+	var raw bson.M
+	opts := options.FindOne().SetProjection(auctionStatusProjection)
+	if err := repo.Collection.FindOne(ctx, bson.M{"_id": auction.Id}, opts).Decode(&raw); err != nil {
+		t.Fatalf("Failed to find projected auction: %v", err)
+	}
+	if len(raw) != 4 {
+		t.Errorf("Expected only 4 projected fields, got %v", raw)
+	}
+	for _, field := range []string{"status", "current_high_bid", "end_time", "bid_count"} {
+		if _, ok := raw[field]; !ok {
+			t.Errorf("Expected projected field %s, got %v", field, raw)
+		}
+	}
+
+	statusView, findErr := repo.FindAuctionStatus(ctx, auction.Id)
+	if findErr != nil {
+		t.Fatalf("Failed to find auction status: %v", findErr)
+	}
+	if statusView.Status != auction_entity.Active ||
+		statusView.BidCount != 2 ||
+		statusView.CurrentHighBid != auction_entity.MoneyFromCents(9990) ||
+		statusView.EndsAt.Unix() != auction.Timestamp.Add(time.Hour).Unix() {
+		t.Errorf("Unexpected auction status: %+v", statusView)
+	}
+
+	if _, findErr := repo.FindAuctionStatus(ctx, uuid.NewString()); findErr == nil || findErr.Err != "not_found" {
+		t.Errorf("Expected not_found for a missing auction, got %v", findErr)
+	}
+}
@@ -0,0 +1,78 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+)
+
+// WithReadTimeout define o timeout aplicado às leituras cujo contexto não tem deadline
+func WithReadTimeout(timeout time.Duration) AuctionRepositoryOption {
+	return func(ar *AuctionRepository) {
+		ar.readTimeout = timeout
+	}
+}
+
+// withTimeout aplica um timeout à operação apenas quando o contexto recebido não
+// possui deadline, respeitando o deadline definido pelo chamador
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// hasTimeForSweep indica se o contexto ainda comporta uma operação completa: ele não
+// pode estar cancelado nem ter um deadline mais próximo que o timeout da operação
+func hasTimeForSweep(ctx context.Context, timeout time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		return false
+	}
+
+	return true
+}
+
+// getWriteTimeout retorna o timeout das operações de escrita baseado em AUCTION_WRITE_TIMEOUT.
+// Se não estiver definido, retorna 10 segundos como padrão
+func getWriteTimeout() time.Duration {
+	timeout, err := time.ParseDuration(os.Getenv("AUCTION_WRITE_TIMEOUT"))
+	if err != nil || timeout <= 0 {
+		return 10 * time.Second
+	}
+
+	return timeout
+}
+
+// getReadTimeout retorna o timeout das operações de leitura baseado em AUCTION_READ_TIMEOUT.
+// Se não estiver definido, retorna 5 segundos como padrão
+func getReadTimeout() time.Duration {
+	timeout, err := time.ParseDuration(os.Getenv("AUCTION_READ_TIMEOUT"))
+	if err != nil || timeout <= 0 {
+		return 5 * time.Second
+	}
+
+	return timeout
+}
+
+// isTimeout indica se a operação falhou por estourar o deadline do contexto. O timeout
+// de seleção de servidor do driver não conta: ele indica um banco inalcançável
+func isTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// readError converte a falha de uma leitura no erro retornado ao chamador. Estourar o
+// deadline vira um erro de timeout, para não ser confundido com uma falha do banco
+func readError(err error, message string) *internal_error.InternalError {
+	if isTimeout(err) {
+		return internal_error.NewTimeoutError(message)
+	}
+
+	return internal_error.NewInternalServerError(message)
+}
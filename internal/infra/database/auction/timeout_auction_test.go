@@ -0,0 +1,161 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestWithTimeoutAppliesDefaultDeadline(t *testing.T) {
+	before := time.Now()
+	ctx, cancel := withTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("Expected a deadline to be applied to a context without one")
+	}
+	if deadline.Before(before.Add(10*time.Second)) || deadline.After(time.Now().Add(10*time.Second)) {
+		t.Errorf("Expected deadline about 10s from now, got %v", deadline)
+	}
+}
+
+func TestWithTimeoutRespectsCallerDeadline(t *testing.T) {
+	callerDeadline := time.Now().Add(time.Minute)
+	parent, parentCancel := context.WithDeadline(context.Background(), callerDeadline)
+	defer parentCancel()
+
+	ctx, cancel := withTimeout(parent, 10*time.Second)
+	defer cancel()
+
+	deadline, _ := ctx.Deadline()
+	if !deadline.Equal(callerDeadline) {
+		t.Errorf("Expected caller deadline %v to be kept, got %v", callerDeadline, deadline)
+	}
+}
+
+func TestCreateAuctionMapsDeadlineExceededToTimeout(t *testing.T) {
+	client, err := mongo.Connect(context.Background(),
+		options.Client().ApplyURI("mongodb://localhost:27017"))
+	if err != nil {
+		t.Fatalf("Failed to create mongo client: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	repo := &AuctionRepository{
+		Collection:   client.Database("auctions_test").Collection("auctions"),
+		writeTimeout: 10 * time.Second,
+		logger:       logger.Default(),
+	}
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+
+	// Contexto com deadline já expirado
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	insertErr := repo.CreateAuction(ctx, auction)
+	if insertErr == nil || insertErr.Err != "timeout" {
+		t.Errorf("Expected a timeout error, got %v", insertErr)
+	}
+}
+
+func TestFindAuctionByIdAbortsAtReadTimeout(t *testing.T) {
+	// Nenhum servidor responde nessa porta, então a leitura fica esperando até o timeout
+	client, err := mongo.Connect(context.Background(),
+		options.Client().ApplyURI("mongodb://127.0.0.1:1"))
+	if err != nil {
+		t.Fatalf("Failed to create mongo client: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	repo := &AuctionRepository{
+		Collection:  client.Database("auctions_test").Collection("auctions"),
+		readTimeout: 200 * time.Millisecond,
+		clock:       realClock{},
+		logger:      logger.Default(),
+	}
+
+	start := time.Now()
+	_, findErr := repo.FindAuctionById(context.Background(), uuid.NewString())
+	elapsed := time.Since(start)
+
+	if findErr == nil || findErr.Err != "timeout" {
+		t.Errorf("Expected a timeout error, got %v", findErr)
+	}
+	if elapsed < repo.readTimeout || elapsed > 2*time.Second {
+		t.Errorf("Expected the read to abort at the %v read timeout, took %v", repo.readTimeout, elapsed)
+	}
+}
+
+func TestHasTimeForSweep(t *testing.T) {
+	if !hasTimeForSweep(context.Background(), 10*time.Second) {
+		t.Error("Expected a context without deadline to allow the sweep")
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if hasTimeForSweep(cancelled, 10*time.Second) {
+		t.Error("Expected a cancelled context to skip the sweep")
+	}
+
+	nearDeadline, cancelNear := context.WithTimeout(context.Background(), time.Second)
+	defer cancelNear()
+	if hasTimeForSweep(nearDeadline, 10*time.Second) {
+		t.Error("Expected a context about to expire to skip the sweep")
+	}
+
+	farDeadline, cancelFar := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelFar()
+	if !hasTimeForSweep(farDeadline, 10*time.Second) {
+		t.Error("Expected a context with enough time left to allow the sweep")
+	}
+}
+
+func TestHandleTickSkipsSweepWhenContextCancelled(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clock := newFakeClock(time.Now())
+	repo := newTestRepository(t, db, WithClock(clock), WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+		auction_entity.WithDuration(time.Minute),
+	)
+	auction.Timestamp = clock.Now()
+	if err := repo.CreateAuction(ctx, auction); err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+	clock.Advance(time.Hour)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	repo.handleTick(cancelled, repo.auctionDuration)
+
+	found, err := repo.FindAuctionById(ctx, auction.Id)
+	if err != nil {
+		t.Fatalf("Failed to find auction: %v", err)
+	}
+	if found.Status != auction_entity.Active {
+		t.Errorf("Expected no close during shutdown, got status %s", found.Status)
+	}
+	if stats := repo.Stats(); stats.TotalClosed != 0 {
+		t.Errorf("Expected no sweep to run, got %+v", stats)
+	}
+}
@@ -0,0 +1,115 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// TransitionOwnershipTransferred registra no histórico a troca de dono de um leilão,
+// que mantém o status
+const TransitionOwnershipTransferred = "ownership_transferred"
+
+// TransferOwnership passa o leilão para newOwnerId, em fusões de contas ou vendas
+// delegadas. Por segurança, só leilões agendados ou ativos e ainda sem lances podem
+// ser transferidos. A transferência fica registrada no histórico do leilão
+func (ar *AuctionRepository) TransferOwnership(
+	ctx context.Context, auctionId, newOwnerId string) *internal_error.InternalError {
+	if err := ar.checkAuctionId(auctionId); err != nil {
+		return err
+	}
+
+	if uuid.Validate(newOwnerId) != nil {
+		return internal_error.NewValidationError("Invalid new owner", []internal_error.FieldError{
+			{Field: "owner_id", Message: "owner id must be a valid UUID"},
+		})
+	}
+
+	filter := bson.M{
+		"_id":    ar.DocumentId(auctionId),
+		"status": bson.M{"$in": bson.A{auction_entity.Scheduled, auction_entity.Active}},
+		"$or": bson.A{
+			bson.M{"bid_count": 0},
+			bson.M{"bid_count": bson.M{"$exists": false}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{"owner_id": newOwnerId},
+		"$inc": bson.M{"version": 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var previous AuctionEntityMongo
+	err := ar.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous)
+	ar.readCache.invalidate(auctionId)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ar.untransferableAuctionError(ctx, auctionId)
+	}
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to transfer auction %s", auctionId), err)
+		return internal_error.NewInternalServerError("Error trying to transfer auction")
+	}
+
+	ar.recordOwnershipTransfer(ctx, previous, newOwnerId)
+
+	ar.logger.Info("Auction ownership transferred", logger.ContextFields(ctx,
+		zap.String("auction_id", auctionId),
+		zap.String("from_owner_id", previous.OwnerId),
+		zap.String("to_owner_id", newOwnerId))...)
+	return nil
+}
+
+// recordOwnershipTransfer grava a transferência no histórico, com o status inalterado.
+// Assim como nas transições de status, uma falha vai para o log e não desfaz a troca
+func (ar *AuctionRepository) recordOwnershipTransfer(
+	ctx context.Context, previous AuctionEntityMongo, newOwnerId string) {
+	entry := AuctionStatusTransition{
+		Id:            primitive.NewObjectID(),
+		AuctionId:     previous.Id,
+		FromStatus:    statusPtr(previous.Status),
+		ToStatus:      previous.Status,
+		Reason:        TransitionOwnershipTransferred,
+		FromOwnerId:   previous.OwnerId,
+		ToOwnerId:     newOwnerId,
+		CorrelationId: logger.CorrelationId(ctx),
+		ActedBy:       logger.ActingUser(ctx),
+		Timestamp:     ar.clock.Now(),
+	}
+
+	if _, err := ar.historyCollection.InsertOne(ctx, entry); err != nil {
+		ar.logger.Error("Error trying to record auction ownership transfer", err,
+			zap.String("auction_id", previous.Id))
+	}
+}
+
+// untransferableAuctionError explica por que a transferência não encontrou o leilão
+func (ar *AuctionRepository) untransferableAuctionError(
+	ctx context.Context, id string) *internal_error.InternalError {
+	var auctionEntityMongo AuctionEntityMongo
+	err := ar.Collection.FindOne(ctx, bson.M{"_id": ar.DocumentId(id)}).Decode(&auctionEntityMongo)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return internal_error.NewNotFoundError(fmt.Sprintf("Auction not found with this id = %s", id))
+	}
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to find auction by id = %s", id), err)
+		return internal_error.NewInternalServerError("Error trying to find auction by id")
+	}
+
+	if auctionEntityMongo.Status == auction_entity.Completed {
+		return internal_error.NewBadRequestError(
+			fmt.Sprintf("Auction %s is completed and cannot be transferred", id))
+	}
+
+	return internal_error.NewBadRequestError(
+		fmt.Sprintf("Auction %s already has bids and cannot be transferred", id))
+}
@@ -0,0 +1,80 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTransferOwnership(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := logger.WithActingUser(context.Background(), "admin-1")
+
+	previousOwner, newOwner := uuid.NewString(), uuid.NewString()
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product", "Electronics", "A test product for auction", auction_entity.New,
+		auction_entity.WithOwnerId(previousOwner))
+	repo.CreateAuction(ctx, auction)
+
+	if err := repo.TransferOwnership(ctx, auction.Id, newOwner); err != nil {
+		t.Fatalf("Failed to transfer auction: %v", err)
+	}
+
+	found, _ := repo.FindAuctionById(ctx, auction.Id)
+	if found.OwnerId != newOwner || found.Version != auction.Version+1 {
+		t.Errorf("Expected owner %s at version %d, got %s at %d",
+			newOwner, auction.Version+1, found.OwnerId, found.Version)
+	}
+
+	history, err := repo.GetAuctionHistory(ctx, auction.Id)
+	if err != nil {
+		t.Fatalf("Failed to get auction history: %v", err)
+	}
+	last := history[len(history)-1]
+	if last.Reason != TransitionOwnershipTransferred || last.FromOwnerId != previousOwner ||
+		last.ToOwnerId != newOwner || last.ActedBy != "admin-1" || last.ToStatus != auction_entity.Active {
+		t.Errorf("Expected the transfer in the audit log, got %+v", last)
+	}
+
+	if err := repo.TransferOwnership(ctx, auction.Id, "not-a-uuid"); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for an invalid owner, got %v", err)
+	}
+}
+
+func TestTransferOwnershipRejectsAuctionsWithBidsOrCompleted(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	withBids, _ := auction_entity.CreateAuction(
+		"Test Product", "Electronics", "A test product for auction", auction_entity.New)
+	completed, _ := auction_entity.CreateAuction(
+		"Test Product", "Electronics", "A test product for auction", auction_entity.New)
+	repo.CreateAuction(ctx, withBids)
+	repo.CreateAuction(ctx, completed)
+	repo.RecordBid(ctx, withBids.Id, 1000)
+	repo.CloseAuction(ctx, completed.Id)
+
+	for _, id := range []string{withBids.Id, completed.Id} {
+		if err := repo.TransferOwnership(ctx, id, uuid.NewString()); err == nil || err.Err != "bad_request" {
+			t.Errorf("Expected bad_request transferring auction %s, got %v", id, err)
+		}
+
+		found, _ := repo.FindAuctionById(ctx, id)
+		if found.OwnerId != "" {
+			t.Errorf("Expected auction %s to keep no owner, got %s", id, found.OwnerId)
+		}
+	}
+
+	if err := repo.TransferOwnership(ctx, uuid.NewString(), uuid.NewString()); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for a missing auction, got %v", err)
+	}
+}
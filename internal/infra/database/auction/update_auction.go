@@ -0,0 +1,169 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/event"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+// RecordBid incrementa atomicamente o contador de lances do leilão e
+// eleva current_high_bid quando o valor do lance é maior que o atual. Leilões que
+// não estão ativos não são alterados e o lance é recusado com conflict
+func (ar *AuctionRepository) RecordBid(
+	ctx context.Context, auctionId string, amount auction_entity.Money) *internal_error.InternalError {
+	if err := ar.checkAuctionId(auctionId); err != nil {
+		return err
+	}
+	defer ar.readCache.invalidate(auctionId)
+
+	// Uma única atualização conta o lance, eleva o maior lance e incrementa a versão
+	// uma vez. O $max garante que lances concorrentes só elevem o valor
+	filter := bson.M{"_id": ar.DocumentId(auctionId), "status": auction_entity.Active}
+	update := bson.A{bson.M{"$set": bson.M{
+		"bid_count":        incremented("$bid_count", 1),
+		"version":          incremented("$version", 1),
+		"current_high_bid": bson.M{"$max": bson.A{"$current_high_bid", amount}},
+	}}}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to record bid on auction %s", auctionId), err)
+		return internal_error.NewInternalServerError("Error trying to record auction bid")
+	}
+	if result.MatchedCount == 0 {
+		return internal_error.NewConflictError(fmt.Sprintf("Auction %s is not accepting bids", auctionId))
+	}
+
+	ar.publish(ctx, event.NewEvent(AuctionBidPlacedEvent, AuctionBidPlacedPayload{
+		AuctionId: auctionId,
+		Amount:    amount,
+	}))
+	return nil
+}
+
+// RecordBidWithdrawal desconta do leilão um lance retirado e baixa current_high_bid
+// para highBid, o maior lance que restou. O filtro condicional evita desfazer um lance
+// maior que tenha chegado depois do lance retirado
+func (ar *AuctionRepository) RecordBidWithdrawal(
+	ctx context.Context,
+	auctionId string,
+	withdrawn, highBid auction_entity.Money) *internal_error.InternalError {
+	if err := ar.checkAuctionId(auctionId); err != nil {
+		return err
+	}
+	defer ar.readCache.invalidate(auctionId)
+
+	// Como em RecordBid, a retirada incrementa a versão uma única vez. O maior lance só
+	// é recalculado quando o lance retirado era o maior
+	filter := bson.M{"_id": ar.DocumentId(auctionId)}
+	update := bson.A{bson.M{"$set": bson.M{
+		"bid_count": incremented("$bid_count", -1),
+		"version":   incremented("$version", 1),
+		"current_high_bid": bson.M{"$cond": bson.A{
+			bson.M{"$lte": bson.A{"$current_high_bid", withdrawn}},
+			highBid,
+			"$current_high_bid",
+		}},
+	}}}
+
+	if _, err := ar.Collection.UpdateOne(ctx, filter, update); err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to record bid withdrawal on auction %s", auctionId), err)
+		return internal_error.NewInternalServerError("Error trying to record auction bid withdrawal")
+	}
+
+	return nil
+}
+
+// incremented soma delta ao campo em uma atualização por pipeline, tratando o campo
+// ausente como zero, como faz o $inc
+func incremented(field string, delta int64) bson.M {
+	return bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{field, 0}}, delta}}
+}
+
+// RepairHighBid reescreve current_high_bid com highBid, o maior lance de fato. O filtro
+// exige que o valor gravado ainda seja stored, para não sobrescrever um lance que tenha
+// chegado durante o reparo. Documentos sem o campo são tratados como stored zero
+func (ar *AuctionRepository) RepairHighBid(
+	ctx context.Context,
+	auctionId string,
+	stored, highBid auction_entity.Money) *internal_error.InternalError {
+	if err := ar.checkAuctionId(auctionId); err != nil {
+		return err
+	}
+	defer ar.readCache.invalidate(auctionId)
+
+	storedFilter := bson.A{bson.M{"current_high_bid": stored}}
+	if stored == 0 {
+		storedFilter = append(storedFilter, bson.M{"current_high_bid": bson.M{"$exists": false}})
+	}
+	filter := bson.M{"_id": ar.DocumentId(auctionId), "$or": storedFilter}
+	update := bson.M{
+		"$set": bson.M{"current_high_bid": highBid},
+		"$inc": bson.M{"version": 1},
+	}
+
+	if _, err := ar.Collection.UpdateOne(ctx, filter, update); err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to repair high bid of auction %s", auctionId), err)
+		return internal_error.NewInternalServerError("Error trying to repair auction high bid")
+	}
+
+	return nil
+}
+
+// CloseAuction encerra manualmente um leilão ativo
+func (ar *AuctionRepository) CloseAuction(
+	ctx context.Context, id string) *internal_error.InternalError {
+	if err := ar.checkAuctionId(id); err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": ar.DocumentId(id), "status": auction_entity.Active}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       auction_entity.Completed,
+			"completed_at": ar.clock.Now(),
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	ar.readCache.invalidate(id)
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to close auction %s", id), err)
+		return internal_error.NewInternalServerError("Error trying to close auction")
+	}
+
+	if result.MatchedCount == 0 {
+		return ar.inactiveAuctionError(ctx, id)
+	}
+
+	ar.recordTransitions(ctx, []string{id},
+		statusPtr(auction_entity.Active), auction_entity.Completed, TransitionManual)
+
+	ar.logger.Info("Auction closed manually",
+		logger.ContextFields(ctx, zap.String("auction_id", id))...)
+	return nil
+}
+
+// inactiveAuctionError explica por que uma atualização condicionada ao status
+// ativo não encontrou o leilão: ou ele não existe ou não está mais ativo
+func (ar *AuctionRepository) inactiveAuctionError(
+	ctx context.Context, id string) *internal_error.InternalError {
+	count, err := ar.Collection.CountDocuments(ctx, bson.M{"_id": ar.DocumentId(id)})
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to find auction by id = %s", id), err)
+		return internal_error.NewInternalServerError("Error trying to find auction by id")
+	}
+
+	if count == 0 {
+		return internal_error.NewNotFoundError(fmt.Sprintf("Auction not found with this id = %s", id))
+	}
+
+	return internal_error.NewBadRequestError(fmt.Sprintf("Auction %s is not active", id))
+}
@@ -0,0 +1,134 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRecordBidConcurrentBidsNeverLowerHighBid(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	repo.CreateAuction(ctx, auction)
+
+	// Lances em ordem embaralhada, disputando a mesma atualização
+	amounts := []auction_entity.Money{120, 500, 80, 499, 250, 10, 300, 450}
+
+	var wg sync.WaitGroup
+	for _, amount := range amounts {
+		wg.Add(1)
+		go func(amount auction_entity.Money) {
+			defer wg.Done()
+			if err := repo.RecordBid(ctx, auction.Id, amount); err != nil {
+				t.Errorf("Failed to record bid: %v", err)
+			}
+		}(amount)
+	}
+	wg.Wait()
+
+	found, err := repo.FindAuctionById(ctx, auction.Id)
+	if err != nil {
+		t.Fatalf("Failed to find auction: %v", err)
+	}
+	if found.CurrentHighBid != 500 {
+		t.Errorf("Expected current_high_bid to be 500, got %s", found.CurrentHighBid)
+	}
+	if found.BidCount != int64(len(amounts)) {
+		t.Errorf("Expected bid_count to be %d, got %d", len(amounts), found.BidCount)
+	}
+
+	if err := repo.RecordBid(ctx, auction.Id, 100); err != nil {
+		t.Fatalf("Failed to record bid: %v", err)
+	}
+	found, _ = repo.FindAuctionById(ctx, auction.Id)
+	if found.CurrentHighBid != 500 {
+		t.Errorf("Expected a lower bid to keep current_high_bid at 500, got %s", found.CurrentHighBid)
+	}
+}
+
+func TestRecordBidRejectsClosedAuction(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db, WithMonitorDisabled(true))
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	repo.CreateAuction(ctx, auction)
+	repo.CloseAuction(ctx, auction.Id)
+
+	if err := repo.RecordBid(ctx, auction.Id, 100); err == nil || err.Err != "conflict" {
+		t.Errorf("Expected a conflict error, got %v", err)
+	}
+
+	found, _ := repo.FindAuctionById(ctx, auction.Id)
+	if found.BidCount != 0 || found.CurrentHighBid != 0 {
+		t.Errorf("Expected the closed auction to be unchanged, got %d bids and %s", found.BidCount, found.CurrentHighBid)
+	}
+}
+
+func TestClosingAuctionsRecordsCompletedAt(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	manual, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	repo.CreateAuction(ctx, manual)
+
+	expired, _ := auction_entity.CreateAuction(
+		"Expired Product",
+		"Electronics",
+		"This auction should expire",
+		auction_entity.New,
+	)
+	expired.Timestamp = time.Now().Add(-20 * time.Minute)
+	repo.CreateAuction(ctx, expired)
+
+	if err := repo.CloseAuction(ctx, manual.Id); err != nil {
+		t.Fatalf("Failed to close auction: %v", err)
+	}
+	repo.closeExpiredAuctions(ctx, repo.auctionDuration)
+
+	for _, id := range []string{manual.Id, expired.Id} {
+		found, err := repo.FindAuctionById(ctx, id)
+		if err != nil {
+			t.Fatalf("Failed to find auction: %v", err)
+		}
+
+		if found.CompletedAt.IsZero() {
+			t.Errorf("Expected completed_at to be set on auction %s", id)
+			continue
+		}
+		if elapsed := time.Since(found.CompletedAt); elapsed < 0 || elapsed > 5*time.Second {
+			t.Errorf("Expected completed_at close to now, got %v", found.CompletedAt)
+		}
+	}
+}
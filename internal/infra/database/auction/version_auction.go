@@ -0,0 +1,102 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// versionMatch monta a condição de versão do filtro de atualização. Leilões gravados
+// antes do campo version existir são tratados como versão 0
+func versionMatch(expectedVersion int64) interface{} {
+	if expectedVersion == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+
+	return expectedVersion
+}
+
+// UpdateAuction altera os dados editáveis de um leilão ativo. A escrita só é aplicada se
+// auctionEntity.Version ainda for a versão gravada, retornando conflict caso outro processo
+// tenha alterado o leilão depois da leitura. Em caso de sucesso, Version passa à nova versão
+func (ar *AuctionRepository) UpdateAuction(
+	ctx context.Context, auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	if err := ar.checkAuctionId(auctionEntity.Id); err != nil {
+		return err
+	}
+
+	auctionEntity.TrimTextFields()
+	if err := auctionEntity.Validate(); err != nil {
+		return err
+	}
+	if err := ar.applyDefaultCondition(auctionEntity); err != nil {
+		return err
+	}
+
+	filter := bson.M{
+		"_id":     ar.DocumentId(auctionEntity.Id),
+		"status":  auction_entity.Active,
+		"version": versionMatch(auctionEntity.Version),
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"product_name": auctionEntity.ProductName,
+			"category":     auctionEntity.Category,
+			"description":  auctionEntity.Description,
+			"condition":    auctionEntity.Condition,
+			"image_urls":   auctionEntity.ImageURLs,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := ar.Collection.UpdateOne(ctx, filter, update)
+	ar.readCache.invalidate(auctionEntity.Id)
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to update auction %s", auctionEntity.Id), err)
+		return internal_error.NewInternalServerError("Error trying to update auction")
+	}
+
+	if result.MatchedCount == 0 {
+		return ar.unupdatableAuctionError(ctx, auctionEntity.Id, auctionEntity.Version)
+	}
+
+	auctionEntity.Version++
+
+	ar.logger.Info("Auction updated", logger.ContextFields(ctx,
+		zap.String("auction_id", auctionEntity.Id),
+		zap.Int64("version", auctionEntity.Version))...)
+	return nil
+}
+
+// unupdatableAuctionError explica por que a atualização não encontrou o leilão
+func (ar *AuctionRepository) unupdatableAuctionError(
+	ctx context.Context, id string, expectedVersion int64) *internal_error.InternalError {
+	var auctionEntityMongo AuctionEntityMongo
+	err := ar.Collection.FindOne(ctx, bson.M{"_id": ar.DocumentId(id)}).Decode(&auctionEntityMongo)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return internal_error.NewNotFoundError(fmt.Sprintf("Auction not found with this id = %s", id))
+	}
+	if err != nil {
+		ar.logger.Error(fmt.Sprintf("Error trying to find auction by id = %s", id), err)
+		return internal_error.NewInternalServerError("Error trying to find auction by id")
+	}
+
+	if auctionEntityMongo.Status != auction_entity.Active {
+		return internal_error.NewBadRequestError(fmt.Sprintf("Auction %s is not active", id))
+	}
+
+	return versionConflictError(id, expectedVersion, auctionEntityMongo.Version)
+}
+
+func versionConflictError(id string, expectedVersion, currentVersion int64) *internal_error.InternalError {
+	return internal_error.NewConflictError(fmt.Sprintf(
+		"Auction %s was modified concurrently: expected version %d, current version %d",
+		id, expectedVersion, currentVersion))
+}
@@ -0,0 +1,119 @@
+package auction
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestUpdateAuctionRejectsStaleVersion(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	if err := repo.CreateAuction(ctx, auction); err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+
+	first, _ := repo.FindAuctionById(ctx, auction.Id)
+	stale, _ := repo.FindAuctionById(ctx, auction.Id)
+
+	first.Description = "An updated description for the product"
+	if err := repo.UpdateAuction(ctx, first); err != nil {
+		t.Fatalf("Expected fresh update to succeed, got %v", err)
+	}
+	if first.Version != 1 {
+		t.Errorf("Expected version 1 after update, got %d", first.Version)
+	}
+
+	stale.Category = "Computers"
+	if err := repo.UpdateAuction(ctx, stale); err == nil || err.Err != "conflict" {
+		t.Fatalf("Expected conflict for a stale update, got %v", err)
+	}
+
+	found, _ := repo.FindAuctionById(ctx, auction.Id)
+	if found.Category != "Electronics" || found.Description != first.Description {
+		t.Errorf("Expected stale update to be discarded, got %+v", found)
+	}
+
+	if err := repo.ExtendAuction(ctx, auction.Id, time.Minute, stale.Version); err == nil || err.Err != "conflict" {
+		t.Errorf("Expected conflict for a stale extension, got %v", err)
+	}
+	if err := repo.ExtendAuction(ctx, auction.Id, time.Minute, found.Version); err != nil {
+		t.Errorf("Expected fresh extension to succeed, got %v", err)
+	}
+}
+
+func TestRecordBidIncrementsVersionOncePerBid(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	if err := repo.CreateAuction(ctx, auction); err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+
+	// Um lance que eleva o maior lance e outro abaixo dele contam uma versão cada
+	for _, amount := range []auction_entity.Money{1000, 500} {
+		if err := repo.RecordBid(ctx, auction.Id, amount); err != nil {
+			t.Fatalf("Failed to record bid: %v", err)
+		}
+	}
+
+	found, _ := repo.FindAuctionById(ctx, auction.Id)
+	if found.Version != 2 || found.BidCount != 2 || found.CurrentHighBid != 1000 {
+		t.Fatalf("Expected version 2 with two bids at 10.00, got version %d, %d bids at %s",
+			found.Version, found.BidCount, found.CurrentHighBid)
+	}
+
+	if err := repo.RecordBidWithdrawal(ctx, auction.Id, 1000, 500); err != nil {
+		t.Fatalf("Failed to record bid withdrawal: %v", err)
+	}
+	withdrawn, _ := repo.FindAuctionById(ctx, auction.Id)
+	if withdrawn.Version != 3 || withdrawn.BidCount != 1 || withdrawn.CurrentHighBid != 500 {
+		t.Errorf("Expected version 3 with one bid at 5.00, got version %d, %d bids at %s",
+			withdrawn.Version, withdrawn.BidCount, withdrawn.CurrentHighBid)
+	}
+}
+
+func TestUpdateAuctionAcceptsLegacyDocumentWithoutVersion(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	auction, _ := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	repo.CreateAuction(ctx, auction)
+	repo.Collection.UpdateOne(ctx, bson.M{"_id": auction.Id}, bson.M{"$unset": bson.M{"version": ""}})
+
+	found, _ := repo.FindAuctionById(ctx, auction.Id)
+	found.ProductName = "Legacy Product"
+	if err := repo.UpdateAuction(ctx, found); err != nil {
+		t.Fatalf("Expected update of a legacy auction to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package bid
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// BidRepositoryOption permite customizar o BidRepository na sua criação
+type BidRepositoryOption func(*BidRepository)
+
+// WithBidRetention define há quanto tempo um leilão precisa estar encerrado para que
+// os seus lances sejam arquivados, e o intervalo entre as passadas do arquivamento.
+// Retenção zero desliga o arquivamento
+func WithBidRetention(retention, interval time.Duration) BidRepositoryOption {
+	return func(bd *BidRepository) {
+		bd.retention = retention
+		if interval > 0 {
+			bd.archiveInterval = interval
+		}
+	}
+}
+
+// getBidRetention lê BID_RETENTION. O arquivamento é opcional: sem a variável, os
+// lances ficam para sempre na coleção bids
+func getBidRetention() time.Duration {
+	retention, err := time.ParseDuration(os.Getenv("BID_RETENTION"))
+	if err != nil || retention <= 0 {
+		return 0
+	}
+
+	return retention
+}
+
+// getBidArchiveInterval lê BID_ARCHIVE_INTERVAL. O padrão é 1 hora
+func getBidArchiveInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("BID_ARCHIVE_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return time.Hour
+	}
+
+	return interval
+}
+
+// archiveBids roda uma passada na inicialização e depois a cada intervalo, até que
+// Shutdown seja chamado
+func (bd *BidRepository) archiveBids(ctx context.Context) {
+	ticker := time.NewTicker(bd.archiveInterval)
+	defer ticker.Stop()
+
+	logger.Info("Bid archiver started",
+		zap.Duration("retention", bd.retention),
+		zap.Duration("interval", bd.archiveInterval))
+
+	for {
+		bd.ArchiveBidsOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Bid archiver stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ArchiveBidsOnce move para bids_archive os lances dos leilões encerrados há mais que a
+// retenção, mantendo em bids apenas o lance vencedor, para que a busca do vencedor
+// continue funcionando. Retorna quantos lances foram arquivados
+func (bd *BidRepository) ArchiveBidsOnce(ctx context.Context) (int64, *internal_error.InternalError) {
+	if bd.retention <= 0 {
+		return 0, nil
+	}
+
+	filter := bson.M{
+		"status":           auction_entity.Completed,
+		"completed_at":     bson.M{"$lt": time.Now().UTC().Add(-bd.retention)},
+		"bids_archived_at": bson.M{"$exists": false},
+	}
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := bd.AuctionRepository.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Error trying to find auctions to archive bids", err)
+		return 0, internal_error.NewInternalServerError("Error trying to find auctions to archive bids")
+	}
+
+	var auctions []struct {
+		Id string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &auctions); err != nil {
+		logger.Error("Error trying to decode auctions to archive bids", err)
+		return 0, internal_error.NewInternalServerError("Error trying to find auctions to archive bids")
+	}
+
+	var archived int64
+	for _, auction := range auctions {
+		count, err := bd.archiveAuctionBids(ctx, auction.Id)
+		archived += count
+		if err != nil {
+			return archived, err
+		}
+	}
+
+	if archived > 0 {
+		logger.Info("Archived bids of closed auctions",
+			zap.Int64("archived", archived),
+			zap.Int("auctions", len(auctions)))
+	}
+
+	return archived, nil
+}
+
+// archiveAuctionBids copia os lances não vencedores do leilão para bids_archive antes
+// de removê-los de bids. Se a remoção falhar, a próxima passada repete a cópia, e as
+// chaves duplicadas no arquivo são ignoradas
+func (bd *BidRepository) archiveAuctionBids(
+	ctx context.Context, auctionId string) (int64, *internal_error.InternalError) {
+	filter := bson.M{"auction_id": auctionId}
+
+	winningBid, winnerErr := bd.FindWinningBidByAuctionId(ctx, auctionId)
+	if winnerErr != nil && winnerErr.Code() != internal_error.NotFound {
+		return 0, winnerErr
+	}
+	if winningBid != nil {
+		filter["_id"] = bson.M{"$ne": winningBid.Id}
+	}
+
+	cursor, err := bd.Collection.Find(ctx, filter)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to find bids to archive of auction %s", auctionId), err)
+		return 0, internal_error.NewInternalServerError("Error trying to archive bids")
+	}
+
+	var bids []bson.M
+	if err := cursor.All(ctx, &bids); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to decode bids to archive of auction %s", auctionId), err)
+		return 0, internal_error.NewInternalServerError("Error trying to archive bids")
+	}
+
+	if len(bids) > 0 {
+		documents := make([]interface{}, 0, len(bids))
+		bidIds := make(bson.A, 0, len(bids))
+		for _, bid := range bids {
+			documents = append(documents, bid)
+			bidIds = append(bidIds, bid["_id"])
+		}
+
+		_, err := bd.archiveCollection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			logger.Error(fmt.Sprintf("Error trying to archive bids of auction %s", auctionId), err)
+			return 0, internal_error.NewInternalServerError("Error trying to archive bids")
+		}
+
+		if _, err := bd.Collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": bidIds}}); err != nil {
+			logger.Error(fmt.Sprintf("Error trying to remove archived bids of auction %s", auctionId), err)
+			return 0, internal_error.NewInternalServerError("Error trying to archive bids")
+		}
+	}
+
+	auctionFilter := bson.M{"_id": bd.AuctionRepository.DocumentId(auctionId)}
+	update := bson.M{"$set": bson.M{"bids_archived_at": time.Now().UTC()}}
+	if _, err := bd.AuctionRepository.Collection.UpdateOne(ctx, auctionFilter, update); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to mark bids of auction %s as archived", auctionId), err)
+	}
+
+	return int64(len(bids)), nil
+}
+
+// Shutdown encerra o arquivamento de lances, aguardando a passada em andamento.
+// Retorna erro se ctx expirar antes disso. Chamadas repetidas não têm efeito
+func (bd *BidRepository) Shutdown(ctx context.Context) *internal_error.InternalError {
+	var shutdownErr *internal_error.InternalError
+
+	bd.shutdownOnce.Do(func() {
+		bd.stopBackground()
+
+		stopped := make(chan struct{})
+		go func() {
+			bd.background.Wait()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			logger.Warn("Bid repository shutdown timed out waiting for the archiver")
+			shutdownErr = internal_error.NewInternalServerError(
+				"Timed out waiting for the bid archiver to stop")
+		}
+	})
+
+	return shutdownErr
+}
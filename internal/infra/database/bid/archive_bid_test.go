@@ -0,0 +1,73 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestArchiveBidsOnceKeepsOnlyTheWinningBid(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	defer db.Collection("bids_archive").Drop(context.Background())
+
+	auctionRepository := newTestAuctionRepository(t, db, auction.WithMonitorDisabled(true))
+	// O intervalo longo deixa só a passada inicial do arquivamento, feita antes dos lances
+	bidRepository := newTestBidRepository(t, db, auctionRepository, WithBidRetention(24*time.Hour, time.Hour))
+	defer bidRepository.Shutdown(context.Background())
+	ctx := context.Background()
+
+	oldAuction := createTestAuction(t, auctionRepository)
+	recentAuction := createTestAuction(t, auctionRepository)
+
+	var bids []bid_entity.Bid
+	for _, auctionId := range []string{oldAuction.Id, recentAuction.Id} {
+		for _, amount := range []auction_entity.Money{100, 300, 200} {
+			bid, _ := bid_entity.CreateBid(uuid.New().String(), auctionId, amount)
+			bids = append(bids, *bid)
+		}
+	}
+	if err := bidRepository.CreateBid(ctx, bids); err != nil {
+		t.Fatalf("Failed to create bids: %v", err)
+	}
+
+	for auctionId, completedAt := range map[string]time.Time{
+		oldAuction.Id:    time.Now().Add(-48 * time.Hour),
+		recentAuction.Id: time.Now().Add(-time.Hour),
+	} {
+		auctionRepository.Collection.UpdateOne(ctx, bson.M{"_id": auctionId}, bson.M{"$set": bson.M{
+			"status":       auction_entity.Completed,
+			"completed_at": completedAt,
+		}})
+	}
+
+	archived, err := bidRepository.ArchiveBidsOnce(ctx)
+	if err != nil {
+		t.Fatalf("Failed to archive bids: %v", err)
+	}
+	if archived != 2 {
+		t.Errorf("Expected the 2 losing bids of the old auction to be archived, got %d", archived)
+	}
+
+	if count, _ := db.Collection("bids_archive").CountDocuments(ctx, bson.M{"auction_id": oldAuction.Id}); count != 2 {
+		t.Errorf("Expected 2 bids in the archive, got %d", count)
+	}
+	if count, _ := bidRepository.Collection.CountDocuments(ctx, bson.M{"auction_id": recentAuction.Id}); count != 3 {
+		t.Errorf("Expected the recent auction to keep its 3 bids, got %d", count)
+	}
+
+	winner, winnerErr := bidRepository.FindWinningBidByAuctionId(ctx, oldAuction.Id)
+	if winnerErr != nil || winner.Amount != 300 {
+		t.Errorf("Expected the winning bid of 300 to remain, got %+v (%v)", winner, winnerErr)
+	}
+
+	if archived, _ := bidRepository.ArchiveBidsOnce(ctx); archived != 0 {
+		t.Errorf("Expected a second pass to archive nothing, got %d", archived)
+	}
+}
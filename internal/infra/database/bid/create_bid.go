@@ -2,46 +2,80 @@ package bid
 
 import (
 	"context"
+	"errors"
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/event"
 	"fullcycle-auction_go/internal/infra/database/auction"
 	"fullcycle-auction_go/internal/internal_error"
-	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
 )
 
 type BidEntityMongo struct {
-	Id        string  `bson:"_id"`
-	UserId    string  `bson:"user_id"`
-	AuctionId string  `bson:"auction_id"`
-	Amount    float64 `bson:"amount"`
-	Timestamp int64   `bson:"timestamp"`
+	Id        string               `bson:"_id"`
+	UserId    string               `bson:"user_id"`
+	AuctionId string               `bson:"auction_id"`
+	Amount    auction_entity.Money `bson:"amount"`
+	Currency  string               `bson:"currency,omitempty"`
+	Timestamp int64                `bson:"timestamp"`
+	RequestId string               `bson:"request_id,omitempty"`
 }
 
 type BidRepository struct {
-	Collection            *mongo.Collection
-	AuctionRepository     *auction.AuctionRepository
-	auctionInterval       time.Duration
-	auctionStatusMap      map[string]auction_entity.AuctionStatus
-	auctionEndTimeMap     map[string]time.Time
-	auctionStatusMapMutex *sync.Mutex
-	auctionEndTimeMutex   *sync.Mutex
+	Collection        *mongo.Collection
+	AuctionRepository *auction.AuctionRepository
+	withdrawalWindow  time.Duration
+	publisher         event.Publisher
+
+	transactionsUnsupported atomic.Bool
+	afterBidInsert          func(ctx context.Context) error
+
+	archiveCollection *mongo.Collection
+	retention         time.Duration
+	archiveInterval   time.Duration
+	stopBackground    context.CancelFunc
+	background        *sync.WaitGroup
+	shutdownOnce      *sync.Once
 }
 
-func NewBidRepository(database *mongo.Database, auctionRepository *auction.AuctionRepository) *BidRepository {
-	return &BidRepository{
-		auctionInterval:       getAuctionInterval(),
-		auctionStatusMap:      make(map[string]auction_entity.AuctionStatus),
-		auctionEndTimeMap:     make(map[string]time.Time),
-		auctionStatusMapMutex: &sync.Mutex{},
-		auctionEndTimeMutex:   &sync.Mutex{},
-		Collection:            database.Collection("bids"),
-		AuctionRepository:     auctionRepository,
+func NewBidRepository(
+	database *mongo.Database,
+	auctionRepository *auction.AuctionRepository,
+	opts ...BidRepositoryOption) *BidRepository {
+	repo := &BidRepository{
+		Collection:        database.Collection("bids"),
+		AuctionRepository: auctionRepository,
+		archiveCollection: database.Collection("bids_archive"),
+		retention:         getBidRetention(),
+		archiveInterval:   getBidArchiveInterval(),
+		withdrawalWindow:  getWithdrawalWindow(),
+		background:        &sync.WaitGroup{},
+		shutdownOnce:      &sync.Once{},
 	}
+
+	for _, opt := range opts {
+		opt(repo)
+	}
+
+	// O arquivamento de lances é opcional e para quando Shutdown cancela este contexto
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	repo.stopBackground = stopBackground
+	if repo.retention > 0 {
+		repo.background.Add(1)
+		go func() {
+			defer repo.background.Done()
+			repo.archiveBids(backgroundCtx)
+		}()
+	}
+
+	return repo
 }
 
 func (bd *BidRepository) CreateBid(
@@ -53,56 +87,21 @@ func (bd *BidRepository) CreateBid(
 		go func(bidValue bid_entity.Bid) {
 			defer wg.Done()
 
-			bd.auctionStatusMapMutex.Lock()
-			auctionStatus, okStatus := bd.auctionStatusMap[bidValue.AuctionId]
-			bd.auctionStatusMapMutex.Unlock()
-
-			bd.auctionEndTimeMutex.Lock()
-			auctionEndTime, okEndTime := bd.auctionEndTimeMap[bidValue.AuctionId]
-			bd.auctionEndTimeMutex.Unlock()
-
-			bidEntityMongo := &BidEntityMongo{
-				Id:        bidValue.Id,
-				UserId:    bidValue.UserId,
-				AuctionId: bidValue.AuctionId,
-				Amount:    bidValue.Amount,
-				Timestamp: bidValue.Timestamp.Unix(),
-			}
-
-			if okEndTime && okStatus {
-				now := time.Now()
-				if auctionStatus == auction_entity.Completed || now.After(auctionEndTime) {
-					return
-				}
-
-				if _, err := bd.Collection.InsertOne(ctx, bidEntityMongo); err != nil {
-					logger.Error("Error trying to insert bid", err)
-					return
-				}
-
-				return
-			}
-
-			auctionEntity, err := bd.AuctionRepository.FindAuctionById(ctx, bidValue.AuctionId)
+			// O leilão é relido a cada lance: o cache de leitura do repositório de leilões
+			// é invalidado em cada escrita, então prorrogações, fechamentos e reaberturas
+			// valem para o próximo lance. Leilões encerrados, expirados ou ainda agendados
+			// não aceitam lances, pela mesma regra de IsBiddable
+			auctionRules, biddable, _, err := bd.AuctionRepository.BiddableAuction(ctx, bidValue.AuctionId)
 			if err != nil {
 				logger.Error("Error trying to find auction by id", err)
 				return
 			}
-			if auctionEntity.Status == auction_entity.Completed {
+			if !biddable {
 				return
 			}
 
-			bd.auctionStatusMapMutex.Lock()
-			bd.auctionStatusMap[bidValue.AuctionId] = auctionEntity.Status
-			bd.auctionStatusMapMutex.Unlock()
-
-			bd.auctionEndTimeMutex.Lock()
-			bd.auctionEndTimeMap[bidValue.AuctionId] = auctionEntity.Timestamp.Add(bd.auctionInterval)
-			bd.auctionEndTimeMutex.Unlock()
-
-			if _, err := bd.Collection.InsertOne(ctx, bidEntityMongo); err != nil {
-				logger.Error("Error trying to insert bid", err)
-				return
+			if bd.acceptsBid(&bidValue, auctionRules) {
+				bd.insertBid(ctx, newBidEntityMongo(bidValue))
 			}
 		}(bid)
 	}
@@ -110,12 +109,143 @@ func (bd *BidRepository) CreateBid(
 	return nil
 }
 
-func getAuctionInterval() time.Duration {
-	auctionInterval := os.Getenv("AUCTION_INTERVAL")
-	duration, err := time.ParseDuration(auctionInterval)
+func newBidEntityMongo(bid bid_entity.Bid) *BidEntityMongo {
+	return &BidEntityMongo{
+		Id:        bid.Id,
+		UserId:    bid.UserId,
+		AuctionId: bid.AuctionId,
+		Amount:    bid.Amount,
+		Currency:  bid.Currency,
+		Timestamp: bid.Timestamp.Unix(),
+		RequestId: bid.RequestId,
+	}
+}
+
+// acceptsBid resolve a moeda do lance pela moeda do leilão, descartando lances em outra
+// moeda, que não podem ser comparados com os demais, lances abaixo do lance mínimo e
+// lances de usuários não convidados para leilões privados
+func (bd *BidRepository) acceptsBid(bid *bid_entity.Bid, auction *auction_entity.Auction) bool {
+	if !auction.VisibleTo(bid.UserId) {
+		logger.Warn("Discarding bid from a user not invited to the auction",
+			zap.String("bid_id", bid.Id),
+			zap.String("auction_id", bid.AuctionId),
+			zap.String("user_id", bid.UserId))
+		return false
+	}
+
+	if err := bid.ResolveCurrency(auction.Currency); err != nil {
+		logger.Warn("Discarding bid with mismatched currency",
+			zap.String("bid_id", bid.Id),
+			zap.String("auction_id", bid.AuctionId),
+			zap.String("reason", err.Error()))
+		return false
+	}
+
+	if err := bid.CheckStartingBid(auction.StartingBid); err != nil {
+		logger.Warn("Discarding bid below the starting bid",
+			zap.String("bid_id", bid.Id),
+			zap.String("auction_id", bid.AuctionId),
+			zap.String("reason", err.Error()))
+		return false
+	}
+
+	return true
+}
+
+// insertBid persiste o lance e atualiza os contadores do leilão em uma única transação,
+// usando escritas sequenciais quando o MongoDB não suporta transações
+func (bd *BidRepository) insertBid(ctx context.Context, bidEntityMongo *BidEntityMongo) error {
+	err := bd.placeBid(ctx, bidEntityMongo)
+	if errors.Is(err, errDuplicateBidRequest) {
+		return bd.resolveDuplicateRequest(ctx, bidEntityMongo)
+	}
+	if err != nil {
+		return err
+	}
+
+	// O aviso só sai depois do commit, para não notificar um lance desfeito
+	bd.notifyOutbid(ctx, bidEntityMongo)
+	return nil
+}
+
+func (bd *BidRepository) placeBid(ctx context.Context, bidEntityMongo *BidEntityMongo) error {
+	if bd.transactionsUnsupported.Load() {
+		return bd.writeBid(ctx, bidEntityMongo)
+	}
+
+	session, err := bd.Collection.Database().Client().StartSession()
 	if err != nil {
-		return time.Minute * 5
+		logger.Error("Error trying to start bid session", err)
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, bd.writeBid(sessCtx, bidEntityMongo)
+	})
+	if err != nil && isTransactionUnsupported(err) {
+		logger.Warn("MongoDB deployment does not support transactions, placing bids without them",
+			zap.String("auction_id", bidEntityMongo.AuctionId))
+		bd.transactionsUnsupported.Store(true)
+		return bd.writeBid(ctx, bidEntityMongo)
+	}
+	if err != nil && !errors.Is(err, errDuplicateBidRequest) {
+		logger.Error("Error trying to place bid", err)
+	}
+
+	return err
+}
+
+func (bd *BidRepository) writeBid(ctx context.Context, bidEntityMongo *BidEntityMongo) error {
+	if _, err := bd.Collection.InsertOne(ctx, bidEntityMongo); err != nil {
+		if bidEntityMongo.RequestId != "" && mongo.IsDuplicateKeyError(err) {
+			return errDuplicateBidRequest
+		}
+		logger.Error("Error trying to insert bid", err)
+		return err
+	}
+
+	if err := bd.recordBid(ctx, bidEntityMongo); err != nil {
+		// Sem transação nada desfaz a inserção, então o lance é removido aqui para não
+		// contar como vencedor de um leilão que o recusou
+		if bd.transactionsUnsupported.Load() {
+			bd.removeBid(ctx, bidEntityMongo)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (bd *BidRepository) recordBid(ctx context.Context, bidEntityMongo *BidEntityMongo) error {
+	if bd.afterBidInsert != nil {
+		if err := bd.afterBidInsert(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := bd.AuctionRepository.RecordBid(ctx, bidEntityMongo.AuctionId, bidEntityMongo.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (bd *BidRepository) removeBid(ctx context.Context, bidEntityMongo *BidEntityMongo) {
+	if _, err := bd.Collection.DeleteOne(ctx, bson.M{"_id": bidEntityMongo.Id}); err != nil {
+		logger.Error("Error trying to remove bid rejected by the auction", err,
+			zap.String("bid_id", bidEntityMongo.Id),
+			zap.String("auction_id", bidEntityMongo.AuctionId))
+	}
+}
+
+// isTransactionUnsupported indica se o erro vem de um MongoDB standalone,
+// que rejeita transações com o código IllegalOperation
+func isTransactionUnsupported(err error) bool {
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) {
+		return commandErr.Code == 20
 	}
 
-	return duration
+	return false
 }
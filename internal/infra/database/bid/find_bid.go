@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/entity/bid_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"time"
 )
@@ -38,29 +40,165 @@ func (bd *BidRepository) FindBidByAuctionId(
 			UserId:    bidEntityMongo.UserId,
 			AuctionId: bidEntityMongo.AuctionId,
 			Amount:    bidEntityMongo.Amount,
-			Timestamp: time.Unix(bidEntityMongo.Timestamp, 0),
+			Currency:  currencyOrDefault(bidEntityMongo.Currency),
+			Timestamp: time.Unix(bidEntityMongo.Timestamp, 0).UTC(),
 		})
 	}
 
 	return bidEntities, nil
 }
 
+// FindWinningBidByAuctionId retorna o maior lance na moeda do leilão. Lances gravados
+// antes do campo currency são considerados na moeda do leilão
 func (bd *BidRepository) FindWinningBidByAuctionId(
 	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	topBids, err := bd.FindTopBids(ctx, auctionId, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(topBids) == 0 {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("No bids found for auction %s", auctionId))
+	}
+
+	return &topBids[0], nil
+}
+
+// FindTopBids retorna até n lances do leilão na moeda dele, do maior para o menor valor.
+// Em caso de empate, o lance mais antigo vem primeiro. Com WithDistinctUsers, cada
+// usuário aparece uma única vez, com o seu maior lance
+func (bd *BidRepository) FindTopBids(
+	ctx context.Context,
+	auctionId string,
+	n int,
+	opts ...bid_entity.TopBidsOption) ([]bid_entity.Bid, *internal_error.InternalError) {
+	if n <= 0 {
+		return nil, internal_error.NewBadRequestError("The number of top bids must be positive")
+	}
+
+	query := bid_entity.TopBidsQuery{}
+	for _, opt := range opts {
+		opt(&query)
+	}
+
+	auctionEntity, auctionErr := bd.AuctionRepository.FindAuctionById(ctx, auctionId)
+	if auctionErr != nil {
+		return nil, auctionErr
+	}
+
+	// Lances abaixo do lance mínimo nunca vencem, mesmo os gravados antes da regra
+	match := bson.M{
+		"auction_id": auctionId,
+		"currency":   bson.M{"$in": bson.A{auctionEntity.Currency, nil}},
+	}
+	if auctionEntity.StartingBid > 0 {
+		match["amount"] = bson.M{"$gte": auctionEntity.StartingBid}
+	}
+
+	topBidsSort := bson.D{{Key: "amount", Value: -1}, {Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: topBidsSort}},
+	}
+	if query.DistinctUsers {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$group", Value: bson.M{"_id": "$user_id", "bid": bson.M{"$first": "$$ROOT"}}}},
+			bson.D{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$bid"}}},
+			bson.D{{Key: "$sort", Value: topBidsSort}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: n}})
+
+	cursor, err := bd.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to find top bids of auction %s", auctionId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find top bids")
+	}
+	defer cursor.Close(ctx)
+
+	var bidEntitiesMongo []BidEntityMongo
+	if err := cursor.All(ctx, &bidEntitiesMongo); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to decode top bids of auction %s", auctionId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find top bids")
+	}
+
+	bidEntities := make([]bid_entity.Bid, 0, len(bidEntitiesMongo))
+	for _, bidEntityMongo := range bidEntitiesMongo {
+		bidEntities = append(bidEntities, bid_entity.Bid{
+			Id:        bidEntityMongo.Id,
+			UserId:    bidEntityMongo.UserId,
+			AuctionId: bidEntityMongo.AuctionId,
+			Amount:    bidEntityMongo.Amount,
+			Currency:  currencyOrDefault(bidEntityMongo.Currency),
+			Timestamp: time.Unix(bidEntityMongo.Timestamp, 0).UTC(),
+		})
+	}
+
+	return bidEntities, nil
+}
+
+// FindBidsPageByAuctionId retorna uma página dos lances do leilão, do maior para o menor
+// valor, e o total de lances. Retorna not_found quando o leilão não existe
+func (bd *BidRepository) FindBidsPageByAuctionId(
+	ctx context.Context,
+	auctionId string,
+	offset, limit int) ([]bid_entity.Bid, int64, *internal_error.InternalError) {
+	if _, err := bd.AuctionRepository.FindAuctionById(ctx, auctionId); err != nil {
+		return nil, 0, err
+	}
+
 	filter := bson.M{"auction_id": auctionId}
 
-	var bidEntityMongo BidEntityMongo
-	opts := options.FindOne().SetSort(bson.D{{"amount", -1}})
-	if err := bd.Collection.FindOne(ctx, filter, opts).Decode(&bidEntityMongo); err != nil {
-		logger.Error("Error trying to find the auction winner", err)
-		return nil, internal_error.NewInternalServerError("Error trying to find the auction winner")
+	total, err := bd.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		logger.Error(
+			fmt.Sprintf("Error trying to count bids by auctionId %s", auctionId), err)
+		return nil, 0, internal_error.NewInternalServerError(
+			fmt.Sprintf("Error trying to count bids by auctionId %s", auctionId))
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "amount", Value: -1}, {Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := bd.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error(
+			fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId), err)
+		return nil, 0, internal_error.NewInternalServerError(
+			fmt.Sprintf("Error trying to find bids by auctionId %s", auctionId))
+	}
+	defer cursor.Close(ctx)
+
+	var bidEntitiesMongo []BidEntityMongo
+	if err := cursor.All(ctx, &bidEntitiesMongo); err != nil {
+		logger.Error(
+			fmt.Sprintf("Error trying to decode bids by auctionId %s", auctionId), err)
+		return nil, 0, internal_error.NewInternalServerError(
+			fmt.Sprintf("Error trying to decode bids by auctionId %s", auctionId))
+	}
+
+	bidEntities := make([]bid_entity.Bid, 0, len(bidEntitiesMongo))
+	for _, bidEntityMongo := range bidEntitiesMongo {
+		bidEntities = append(bidEntities, bid_entity.Bid{
+			Id:        bidEntityMongo.Id,
+			UserId:    bidEntityMongo.UserId,
+			AuctionId: bidEntityMongo.AuctionId,
+			Amount:    bidEntityMongo.Amount,
+			Currency:  currencyOrDefault(bidEntityMongo.Currency),
+			Timestamp: time.Unix(bidEntityMongo.Timestamp, 0).UTC(),
+		})
+	}
+
+	return bidEntities, total, nil
+}
+
+// currencyOrDefault atribui a moeda padrão a lances gravados antes do campo currency
+func currencyOrDefault(currency string) string {
+	if currency == "" {
+		return auction_entity.DefaultCurrency()
 	}
 
-	return &bid_entity.Bid{
-		Id:        bidEntityMongo.Id,
-		UserId:    bidEntityMongo.UserId,
-		AuctionId: bidEntityMongo.AuctionId,
-		Amount:    bidEntityMongo.Amount,
-		Timestamp: time.Unix(bidEntityMongo.Timestamp, 0),
-	}, nil
+	return currency
 }
@@ -0,0 +1,140 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFindBidsPageByAuctionId(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	auctionRepository := newTestAuctionRepository(t, db)
+	bidRepository := newTestBidRepository(t, db, auctionRepository)
+	ctx := context.Background()
+
+	auctionEntity := createTestAuction(t, auctionRepository)
+
+	var bids []bid_entity.Bid
+	for i := 1; i <= 5; i++ {
+		bid, _ := bid_entity.CreateBid(uuid.New().String(), auctionEntity.Id, auction_entity.Money(i*100))
+		bids = append(bids, *bid)
+	}
+	if err := bidRepository.CreateBid(ctx, bids); err != nil {
+		t.Fatalf("Failed to create bids: %v", err)
+	}
+
+	firstPage, total, err := bidRepository.FindBidsPageByAuctionId(ctx, auctionEntity.Id, 0, 2)
+	if err != nil {
+		t.Fatalf("Failed to find bids page: %v", err)
+	}
+	if total != 5 {
+		t.Errorf("Expected total 5, got %d", total)
+	}
+	if len(firstPage) != 2 || firstPage[0].Amount != 500 || firstPage[1].Amount != 400 {
+		t.Errorf("Expected bids 500 and 400 on the first page, got %+v", firstPage)
+	}
+
+	lastPage, _, err := bidRepository.FindBidsPageByAuctionId(ctx, auctionEntity.Id, 4, 2)
+	if err != nil {
+		t.Fatalf("Failed to find bids page: %v", err)
+	}
+	if len(lastPage) != 1 || lastPage[0].Amount != 100 {
+		t.Errorf("Expected only bid 100 on the last page, got %+v", lastPage)
+	}
+}
+
+func TestFindBidsPageByAuctionIdNotFound(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	auctionRepository := newTestAuctionRepository(t, db)
+	bidRepository := newTestBidRepository(t, db, auctionRepository)
+
+	_, _, err := bidRepository.FindBidsPageByAuctionId(
+		context.Background(), uuid.New().String(), 0, 10)
+	if err == nil || err.Err != "not_found" {
+		t.Fatalf("Expected not_found error, got %v", err)
+	}
+}
+
+func TestFindTopBids(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	auctionRepository := newTestAuctionRepository(t, db)
+	bidRepository := newTestBidRepository(t, db, auctionRepository)
+	ctx := context.Background()
+
+	auctionEntity := createTestAuction(t, auctionRepository)
+
+	alice, bob, carol := uuid.New().String(), uuid.New().String(), uuid.New().String()
+	var bids []bid_entity.Bid
+	for _, placed := range []struct {
+		userId string
+		amount auction_entity.Money
+	}{
+		{alice, 100}, {alice, 400}, {bob, 300}, {carol, 200}, {carol, 250},
+	} {
+		bid, _ := bid_entity.CreateBid(placed.userId, auctionEntity.Id, placed.amount)
+		bids = append(bids, *bid)
+	}
+	if err := bidRepository.CreateBid(ctx, bids); err != nil {
+		t.Fatalf("Failed to create bids: %v", err)
+	}
+
+	amounts := func(bids []bid_entity.Bid) []auction_entity.Money {
+		result := []auction_entity.Money{}
+		for _, bid := range bids {
+			result = append(result, bid.Amount)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		n        int
+		opts     []bid_entity.TopBidsOption
+		expected []auction_entity.Money
+	}{
+		{name: "top 3", n: 3, expected: []auction_entity.Money{400, 300, 250}},
+		{name: "fewer bids than n", n: 10, expected: []auction_entity.Money{400, 300, 250, 200, 100}},
+		{name: "distinct users", n: 10, opts: []bid_entity.TopBidsOption{bid_entity.WithDistinctUsers()},
+			expected: []auction_entity.Money{400, 300, 250}},
+		{name: "distinct users top 2", n: 2, opts: []bid_entity.TopBidsOption{bid_entity.WithDistinctUsers()},
+			expected: []auction_entity.Money{400, 300}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topBids, err := bidRepository.FindTopBids(ctx, auctionEntity.Id, tt.n, tt.opts...)
+			if err != nil {
+				t.Fatalf("Failed to find top bids: %v", err)
+			}
+			if got := amounts(topBids); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected top bids %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	distinct, _ := bidRepository.FindTopBids(ctx, auctionEntity.Id, 3, bid_entity.WithDistinctUsers())
+	if distinct[0].UserId != alice || distinct[1].UserId != bob || distinct[2].UserId != carol {
+		t.Errorf("Expected one bid per user ordered alice, bob, carol, got %+v", distinct)
+	}
+
+	if _, err := bidRepository.FindTopBids(ctx, auctionEntity.Id, 0); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for n = 0, got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.uber.org/zap"
+)
+
+// MigrateMoneyEncoding reescreve o valor dos lances antigos, gravado em float64 na
+// unidade da moeda, como centavos inteiros, para que a busca do vencedor ordene
+// todos os lances pela mesma representação
+func (bd *BidRepository) MigrateMoneyEncoding(ctx context.Context) (int64, *internal_error.InternalError) {
+	migrated, err := auction.MigrateMoneyField(ctx, bd.Collection, "amount")
+	if err != nil {
+		logger.Error("Error trying to migrate bid amount encoding", err)
+		return 0, internal_error.NewInternalServerError("Error trying to migrate bid amount encoding")
+	}
+
+	if migrated > 0 {
+		logger.Info("Migrated bid amount encoding", zap.Int64("modified_count", migrated))
+	}
+
+	return migrated, nil
+}
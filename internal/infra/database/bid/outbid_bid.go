@@ -0,0 +1,62 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/event"
+
+	"go.uber.org/zap"
+)
+
+const OutbidEvent = "bid.outbid"
+
+// OutbidPayload avisa o usuário que liderava o leilão que o seu lance foi superado
+type OutbidPayload struct {
+	UserId    string               `json:"user_id" bson:"user_id"`
+	AuctionId string               `json:"auction_id" bson:"auction_id"`
+	Amount    auction_entity.Money `json:"amount" bson:"amount"`
+}
+
+// WithPublisher faz o repositório emitir um OutbidEvent para o líder anterior sempre
+// que um novo lance assume a liderança do leilão
+func WithPublisher(publisher event.Publisher) BidRepositoryOption {
+	return func(bd *BidRepository) {
+		bd.publisher = publisher
+	}
+}
+
+// notifyOutbid compara o lance recém-gravado com os dois maiores do leilão. Quando ele
+// lidera, o segundo colocado é quem liderava antes. Não há aviso sem líder anterior nem
+// quando o usuário apenas cobre o próprio lance. Falhas só são registradas em log
+func (bd *BidRepository) notifyOutbid(ctx context.Context, bidEntityMongo *BidEntityMongo) {
+	if bd.publisher == nil {
+		return
+	}
+
+	topBids, err := bd.FindTopBids(ctx, bidEntityMongo.AuctionId, 2)
+	if err != nil {
+		logger.Error("Error trying to find previous high bidder", err,
+			zap.String("auction_id", bidEntityMongo.AuctionId))
+		return
+	}
+	if len(topBids) < 2 || topBids[0].Id != bidEntityMongo.Id {
+		return
+	}
+
+	previous := topBids[1]
+	if previous.UserId == bidEntityMongo.UserId {
+		return
+	}
+
+	outbidEvent := event.NewEvent(OutbidEvent, OutbidPayload{
+		UserId:    previous.UserId,
+		AuctionId: bidEntityMongo.AuctionId,
+		Amount:    bidEntityMongo.Amount,
+	})
+	if err := bd.publisher.Publish(ctx, outbidEvent); err != nil {
+		logger.Error("Error trying to publish event", err,
+			zap.String("event_id", outbidEvent.Id),
+			zap.String("event_name", outbidEvent.Name))
+	}
+}
@@ -0,0 +1,76 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/event"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// recordingPublisher guarda os eventos publicados para inspeção nos testes
+type recordingPublisher struct {
+	mutex  sync.Mutex
+	events []event.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, e event.Event) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.events = append(p.events, e)
+	return nil
+}
+
+func TestCreateBidNotifiesPreviousHighBidder(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	publisher := &recordingPublisher{}
+	auctionRepository := newTestAuctionRepository(t, db, auction.WithMonitorDisabled(true))
+	bidRepository := newTestBidRepository(t, db, auctionRepository, WithPublisher(publisher))
+
+	auctionEntity := createTestAuction(t, auctionRepository)
+	alice, bob := uuid.New().String(), uuid.New().String()
+
+	// Os usuários se alternam na liderança e, por fim, Alice cobre o próprio lance
+	bids := []struct {
+		userId string
+		amount auction_entity.Money
+	}{
+		{alice, 1000},
+		{bob, 2000},
+		{alice, 3000},
+		{alice, 4000},
+	}
+	for _, b := range bids {
+		bid, _ := bid_entity.CreateBid(b.userId, auctionEntity.Id, b.amount)
+		if err := bidRepository.CreateBid(context.Background(), []bid_entity.Bid{*bid}); err != nil {
+			t.Fatalf("Failed to create bid: %v", err)
+		}
+	}
+
+	expected := []OutbidPayload{
+		{UserId: alice, AuctionId: auctionEntity.Id, Amount: 2000},
+		{UserId: bob, AuctionId: auctionEntity.Id, Amount: 3000},
+	}
+	if len(publisher.events) != len(expected) {
+		t.Fatalf("Expected %d outbid events, got %d: %+v", len(expected), len(publisher.events), publisher.events)
+	}
+	for i, e := range publisher.events {
+		if e.Name != OutbidEvent {
+			t.Errorf("Expected event %q, got %q", OutbidEvent, e.Name)
+		}
+		if payload, _ := e.Payload.(OutbidPayload); payload != expected[i] {
+			t.Errorf("Expected payload %+v, got %+v", expected[i], e.Payload)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package bid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// maxProxyBookAttempts limita as releituras de um livro alterado por outra réplica
+// durante uma atualização
+const maxProxyBookAttempts = 10
+
+type ProxyBidEntityMongo struct {
+	MaxAmount auction_entity.Money `bson:"max_amount"`
+	Timestamp time.Time            `bson:"timestamp"`
+}
+
+// ProxyBookEntityMongo é o livro de máximos de um leilão. Os máximos ficam em proxies,
+// indexados pelo id do usuário, e version serializa as atualizações do leilão
+type ProxyBookEntityMongo struct {
+	AuctionId string                         `bson:"_id"`
+	Price     auction_entity.Money           `bson:"price"`
+	LeaderId  string                         `bson:"leader_id"`
+	Proxies   map[string]ProxyBidEntityMongo `bson:"proxies"`
+	Version   int64                          `bson:"version"`
+}
+
+// ProxyBookRepository guarda os livros de máximos na coleção proxy_bids
+type ProxyBookRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewProxyBookRepository(database *mongo.Database) *ProxyBookRepository {
+	return &ProxyBookRepository{
+		Collection: database.Collection("proxy_bids"),
+	}
+}
+
+func (pr *ProxyBookRepository) FindProxyBook(
+	ctx context.Context, auctionId string) (*bid_entity.ProxyBook, *internal_error.InternalError) {
+	bookMongo, err := pr.findProxyBook(ctx, auctionId)
+	if err != nil || bookMongo == nil {
+		return nil, err
+	}
+
+	return bookMongo.toEntity(), nil
+}
+
+// UpdateProxyBook lê o livro, aplica update e grava o resultado só se a versão lida
+// ainda for a atual. Quando outra réplica atualizou o livro no meio do caminho, a
+// atualização recomeça a partir do livro novo
+func (pr *ProxyBookRepository) UpdateProxyBook(
+	ctx context.Context,
+	auctionId string,
+	update func(book *bid_entity.ProxyBook) *bid_entity.ProxyBook) *internal_error.InternalError {
+	for attempt := 0; attempt < maxProxyBookAttempts; attempt++ {
+		bookMongo, err := pr.findProxyBook(ctx, auctionId)
+		if err != nil {
+			return err
+		}
+
+		var book *bid_entity.ProxyBook
+		var version int64
+		if bookMongo != nil {
+			book, version = bookMongo.toEntity(), bookMongo.Version
+		}
+
+		updated := update(book)
+		if updated == nil {
+			return nil
+		}
+
+		saved, err := pr.saveProxyBook(ctx, newProxyBookEntityMongo(updated, version+1), bookMongo != nil)
+		if err != nil {
+			return err
+		}
+		if saved {
+			return nil
+		}
+	}
+
+	return internal_error.NewConflictError(fmt.Sprintf(
+		"Too many concurrent updates to the proxy book of auction %s, try again", auctionId))
+}
+
+// saveProxyBook grava o livro na versão anterior à de bookMongo. Retorna false quando
+// outra réplica gravou antes
+func (pr *ProxyBookRepository) saveProxyBook(
+	ctx context.Context,
+	bookMongo *ProxyBookEntityMongo,
+	exists bool) (bool, *internal_error.InternalError) {
+	if !exists {
+		_, err := pr.Collection.InsertOne(ctx, bookMongo)
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error trying to save proxy book of auction %s", bookMongo.AuctionId), err)
+			return false, internal_error.NewInternalServerError("Error trying to save proxy book")
+		}
+		return true, nil
+	}
+
+	filter := bson.M{"_id": bookMongo.AuctionId, "version": bookMongo.Version - 1}
+	result, err := pr.Collection.ReplaceOne(ctx, filter, bookMongo)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to save proxy book of auction %s", bookMongo.AuctionId), err)
+		return false, internal_error.NewInternalServerError("Error trying to save proxy book")
+	}
+
+	return result.MatchedCount == 1, nil
+}
+
+func (pr *ProxyBookRepository) DeleteProxyBook(
+	ctx context.Context, auctionId string) *internal_error.InternalError {
+	if _, err := pr.Collection.DeleteOne(ctx, bson.M{"_id": auctionId}); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to delete proxy book of auction %s", auctionId), err)
+		return internal_error.NewInternalServerError("Error trying to delete proxy book")
+	}
+
+	return nil
+}
+
+func (pr *ProxyBookRepository) FindProxyBookAuctionIds(
+	ctx context.Context) ([]string, *internal_error.InternalError) {
+	cursor, err := pr.Collection.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		logger.Error("Error trying to list proxy books", err)
+		return nil, internal_error.NewInternalServerError("Error trying to list proxy books")
+	}
+
+	var books []ProxyBookEntityMongo
+	if err := cursor.All(ctx, &books); err != nil {
+		logger.Error("Error trying to list proxy books", err)
+		return nil, internal_error.NewInternalServerError("Error trying to list proxy books")
+	}
+
+	auctionIds := make([]string, 0, len(books))
+	for _, book := range books {
+		auctionIds = append(auctionIds, book.AuctionId)
+	}
+
+	return auctionIds, nil
+}
+
+// findProxyBook retorna nil, sem erro, quando o leilão não tem livro
+func (pr *ProxyBookRepository) findProxyBook(
+	ctx context.Context, auctionId string) (*ProxyBookEntityMongo, *internal_error.InternalError) {
+	var bookMongo ProxyBookEntityMongo
+	err := pr.Collection.FindOne(ctx, bson.M{"_id": auctionId}).Decode(&bookMongo)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to find proxy book of auction %s", auctionId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find proxy book")
+	}
+
+	return &bookMongo, nil
+}
+
+func newProxyBookEntityMongo(book *bid_entity.ProxyBook, version int64) *ProxyBookEntityMongo {
+	proxies := make(map[string]ProxyBidEntityMongo)
+	for _, proxy := range book.Proxies() {
+		proxies[proxy.UserId] = ProxyBidEntityMongo{
+			MaxAmount: proxy.MaxAmount,
+			Timestamp: proxy.Timestamp,
+		}
+	}
+
+	return &ProxyBookEntityMongo{
+		AuctionId: book.AuctionId,
+		Price:     book.Price,
+		LeaderId:  book.LeaderId,
+		Proxies:   proxies,
+		Version:   version,
+	}
+}
+
+func (bookMongo *ProxyBookEntityMongo) toEntity() *bid_entity.ProxyBook {
+	proxies := make([]bid_entity.ProxyBid, 0, len(bookMongo.Proxies))
+	for userId, proxy := range bookMongo.Proxies {
+		proxies = append(proxies, bid_entity.ProxyBid{
+			UserId:    userId,
+			MaxAmount: proxy.MaxAmount,
+			Timestamp: proxy.Timestamp.UTC(),
+		})
+	}
+
+	return bid_entity.RestoreProxyBook(bookMongo.AuctionId, bookMongo.Price, bookMongo.LeaderId, proxies)
+}
@@ -0,0 +1,89 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestProxyBookRepositoryPersistsBooks(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	defer db.Collection("proxy_bids").Drop(context.Background())
+
+	ctx := context.Background()
+	auctionId := uuid.NewString()
+	repo := NewProxyBookRepository(db)
+
+	err := repo.UpdateProxyBook(ctx, auctionId, func(*bid_entity.ProxyBook) *bid_entity.ProxyBook {
+		book := bid_entity.NewProxyBook(auctionId, 1000)
+		book.Place(bid_entity.Bid{UserId: "alice", Amount: 1100, Timestamp: time.Now()}, 5000, 100)
+		return book
+	})
+	if err != nil {
+		t.Fatalf("Failed to save proxy book: %v", err)
+	}
+
+	// Um repositório novo, como o de outra réplica, lê o mesmo livro
+	book, err := NewProxyBookRepository(db).FindProxyBook(ctx, auctionId)
+	if err != nil || book == nil {
+		t.Fatalf("Expected the proxy book to be persisted, got %v", err)
+	}
+	if book.LeaderId != "alice" || book.Price != 1100 || book.MaxAmount("alice") != 5000 {
+		t.Errorf("Unexpected persisted book: %s at %d", book.LeaderId, book.Price)
+	}
+
+	if auctionIds, _ := repo.FindProxyBookAuctionIds(ctx); len(auctionIds) != 1 || auctionIds[0] != auctionId {
+		t.Errorf("Expected the auction to be listed, got %v", auctionIds)
+	}
+
+	if err := repo.DeleteProxyBook(ctx, auctionId); err != nil {
+		t.Fatalf("Failed to delete proxy book: %v", err)
+	}
+	if book, _ := repo.FindProxyBook(ctx, auctionId); book != nil {
+		t.Errorf("Expected the proxy book to be deleted")
+	}
+}
+
+func TestProxyBookRepositorySerializesUpdates(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	defer db.Collection("proxy_bids").Drop(context.Background())
+
+	ctx := context.Background()
+	auctionId := uuid.NewString()
+
+	// Cada réplica registra um máximo; nenhum pode se perder na corrida
+	const replicas = 5
+	var wg sync.WaitGroup
+	for i := 0; i < replicas; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			userId := uuid.NewString()
+			NewProxyBookRepository(db).UpdateProxyBook(ctx, auctionId,
+				func(book *bid_entity.ProxyBook) *bid_entity.ProxyBook {
+					if book == nil {
+						book = bid_entity.NewProxyBook(auctionId, 0)
+					}
+					book.Place(bid_entity.Bid{UserId: userId, Amount: 100, Timestamp: time.Now()},
+						auction_entity.Money(1000+100*i), 100)
+					return book
+				})
+		}(i)
+	}
+	wg.Wait()
+
+	book, err := NewProxyBookRepository(db).FindProxyBook(ctx, auctionId)
+	if err != nil || book == nil {
+		t.Fatalf("Failed to find proxy book: %v", err)
+	}
+	if proxies := book.Proxies(); len(proxies) != replicas {
+		t.Errorf("Expected %d maxima, got %d", replicas, len(proxies))
+	}
+}
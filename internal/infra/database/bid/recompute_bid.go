@@ -0,0 +1,93 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// RecomputeHighBid corrige o current_high_bid do leilão quando ele diverge do lance
+// vencedor de fato, por exemplo depois de uma escrita parcial. Leilões sem lances
+// voltam a zero. A correção fica registrada no log
+func (bd *BidRepository) RecomputeHighBid(
+	ctx context.Context, auctionId string) *internal_error.InternalError {
+	_, err := bd.recomputeHighBid(ctx, auctionId)
+	return err
+}
+
+// RecomputeAllHighBids aplica RecomputeHighBid a todos os leilões, para manutenção
+// depois de incidentes. Retorna quantos leilões foram corrigidos
+func (bd *BidRepository) RecomputeAllHighBids(ctx context.Context) (int64, *internal_error.InternalError) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := bd.AuctionRepository.Collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		logger.Error("Error trying to find auctions to recompute high bids", err)
+		return 0, internal_error.NewInternalServerError("Error trying to find auctions to recompute high bids")
+	}
+
+	var auctions []struct {
+		Id string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &auctions); err != nil {
+		logger.Error("Error trying to decode auctions to recompute high bids", err)
+		return 0, internal_error.NewInternalServerError("Error trying to find auctions to recompute high bids")
+	}
+
+	var repaired int64
+	for _, auction := range auctions {
+		changed, err := bd.recomputeHighBid(ctx, auction.Id)
+		if err != nil {
+			return repaired, err
+		}
+		if changed {
+			repaired++
+		}
+	}
+
+	logger.Info("Recomputed auction high bids",
+		zap.Int64("repaired", repaired),
+		zap.Int("auctions", len(auctions)))
+
+	return repaired, nil
+}
+
+// recomputeHighBid compara o current_high_bid gravado com o lance vencedor e só
+// escreve quando eles divergem, para não incrementar a versão de leilões corretos
+func (bd *BidRepository) recomputeHighBid(
+	ctx context.Context, auctionId string) (bool, *internal_error.InternalError) {
+	auctionEntity, err := bd.AuctionRepository.FindAuctionByIdUncached(ctx, auctionId)
+	if err != nil {
+		return false, err
+	}
+
+	var highBid auction_entity.Money
+	winningBid, winnerErr := bd.FindWinningBidByAuctionId(ctx, auctionId)
+	if winnerErr != nil && winnerErr.Code() != internal_error.NotFound {
+		return false, winnerErr
+	}
+	if winningBid != nil {
+		highBid = winningBid.Amount
+	}
+
+	if highBid == auctionEntity.CurrentHighBid {
+		return false, nil
+	}
+
+	logger.Warn("Repairing inconsistent auction high bid",
+		zap.String("auction_id", auctionId),
+		zap.Stringer("stored", auctionEntity.CurrentHighBid),
+		zap.Stringer("actual", highBid))
+
+	if err := bd.AuctionRepository.RepairHighBid(
+		ctx, auctionId, auctionEntity.CurrentHighBid, highBid); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
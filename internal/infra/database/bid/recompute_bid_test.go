@@ -0,0 +1,95 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"os"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// corruptHighBid grava um current_high_bid que não corresponde aos lances do leilão
+func corruptHighBid(t *testing.T, db *mongo.Database, auctionId string, amount auction_entity.Money) {
+	_, err := db.Collection("auctions").UpdateOne(context.Background(),
+		bson.M{"_id": auctionId}, bson.M{"$set": bson.M{"current_high_bid": amount}})
+	if err != nil {
+		t.Fatalf("Failed to corrupt high bid: %v", err)
+	}
+}
+
+func TestRecomputeHighBidRepairsCorruptedField(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	auctionRepository := newTestAuctionRepository(t, db, auction.WithMonitorDisabled(true))
+	bidRepository := newTestBidRepository(t, db, auctionRepository)
+	ctx := context.Background()
+
+	auctionEntity := createTestAuction(t, auctionRepository)
+	placeBids(t, bidRepository, auctionEntity.Id, 1000, 2500)
+	corruptHighBid(t, db, auctionEntity.Id, 99900)
+
+	if err := bidRepository.RecomputeHighBid(ctx, auctionEntity.Id); err != nil {
+		t.Fatalf("Failed to recompute high bid: %v", err)
+	}
+
+	found, _ := auctionRepository.FindAuctionById(ctx, auctionEntity.Id)
+	if found.CurrentHighBid != 2500 {
+		t.Errorf("Expected high bid 25.00, got %s", found.CurrentHighBid)
+	}
+}
+
+func TestRecomputeAllHighBidsRepairsOnlyDivergentAuctions(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	auctionRepository := newTestAuctionRepository(t, db, auction.WithMonitorDisabled(true))
+	bidRepository := newTestBidRepository(t, db, auctionRepository)
+	ctx := context.Background()
+
+	corrupted := createTestAuction(t, auctionRepository)
+	placeBids(t, bidRepository, corrupted.Id, 1000, 2500)
+	corruptHighBid(t, db, corrupted.Id, 500)
+
+	withoutBids := createTestAuction(t, auctionRepository)
+	corruptHighBid(t, db, withoutBids.Id, 700)
+
+	consistent := createTestAuction(t, auctionRepository)
+	placeBids(t, bidRepository, consistent.Id, 1500)
+	before, _ := auctionRepository.FindAuctionById(ctx, consistent.Id)
+
+	repaired, err := bidRepository.RecomputeAllHighBids(ctx)
+	if err != nil {
+		t.Fatalf("Failed to recompute high bids: %v", err)
+	}
+	if repaired != 2 {
+		t.Errorf("Expected 2 repaired auctions, got %d", repaired)
+	}
+
+	expected := map[string]auction_entity.Money{
+		corrupted.Id:   2500,
+		withoutBids.Id: 0,
+		consistent.Id:  1500,
+	}
+	for id, highBid := range expected {
+		found, _ := auctionRepository.FindAuctionById(ctx, id)
+		if found.CurrentHighBid != highBid {
+			t.Errorf("Expected high bid %s for auction %s, got %s", highBid, id, found.CurrentHighBid)
+		}
+	}
+
+	// Leilões corretos não são reescritos
+	after, _ := auctionRepository.FindAuctionById(ctx, consistent.Id)
+	if after.Version != before.Version {
+		t.Errorf("Expected version %d to be kept, got %d", before.Version, after.Version)
+	}
+}
@@ -0,0 +1,81 @@
+package bid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// errDuplicateBidRequest indica que o usuário já tem um lance com o mesmo request_id
+var errDuplicateBidRequest = errors.New("duplicate bid request")
+
+// EnsureIndexes cria os índices da coleção de lances. O índice único em
+// (user_id, request_id) vale só para lances com request_id, e é ele que impede que
+// repetições de um mesmo lance sejam gravadas, mesmo quando chegam no mesmo lote
+func (bd *BidRepository) EnsureIndexes(ctx context.Context) *internal_error.InternalError {
+	requestIndex := mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "request_id", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"request_id": bson.M{"$type": "string"}}),
+	}
+
+	if _, err := bd.Collection.Indexes().CreateOne(ctx, requestIndex); err != nil {
+		logger.Error("Error trying to create bid indexes", err)
+		return internal_error.NewInternalServerError("Error trying to create bid indexes")
+	}
+
+	return nil
+}
+
+// FindBidByRequestId retorna o lance que o usuário gravou com o request_id informado
+func (bd *BidRepository) FindBidByRequestId(
+	ctx context.Context, userId, requestId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	filter := bson.M{"user_id": userId, "request_id": requestId}
+
+	var bidEntityMongo BidEntityMongo
+	err := bd.Collection.FindOne(ctx, filter).Decode(&bidEntityMongo)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Bid not found with request id = %s", requestId))
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to find bid by request id %s", requestId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find bid by request id")
+	}
+
+	return &bid_entity.Bid{
+		Id:        bidEntityMongo.Id,
+		UserId:    bidEntityMongo.UserId,
+		AuctionId: bidEntityMongo.AuctionId,
+		Amount:    bidEntityMongo.Amount,
+		Currency:  currencyOrDefault(bidEntityMongo.Currency),
+		Timestamp: time.Unix(bidEntityMongo.Timestamp, 0).UTC(),
+		RequestId: bidEntityMongo.RequestId,
+	}, nil
+}
+
+// resolveDuplicateRequest trata a repetição de um lance como sucesso: o lance gravado
+// antes é mantido e os contadores do leilão não são alterados de novo
+func (bd *BidRepository) resolveDuplicateRequest(ctx context.Context, bidEntityMongo *BidEntityMongo) error {
+	prior, err := bd.FindBidByRequestId(ctx, bidEntityMongo.UserId, bidEntityMongo.RequestId)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Ignoring retried bid with an already used request id",
+		zap.String("request_id", prior.RequestId),
+		zap.String("bid_id", prior.Id),
+		zap.String("retried_bid_id", bidEntityMongo.Id),
+		zap.String("auction_id", prior.AuctionId))
+	return nil
+}
@@ -0,0 +1,71 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCreateBidDeduplicatesByRequestId(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	auctionRepository := newTestAuctionRepository(t, db)
+	bidRepository := newTestBidRepository(t, db, auctionRepository)
+	ctx := context.Background()
+
+	if err := bidRepository.EnsureIndexes(ctx); err != nil {
+		t.Fatalf("Failed to create bid indexes: %v", err)
+	}
+
+	auctionEntity := createTestAuction(t, auctionRepository)
+	userId := uuid.New().String()
+
+	place := func(requestId string) *bid_entity.Bid {
+		bid, _ := bid_entity.CreateBid(userId, auctionEntity.Id, 100,
+			bid_entity.WithRequestId(requestId))
+		if err := bidRepository.CreateBid(ctx, []bid_entity.Bid{*bid}); err != nil {
+			t.Fatalf("Failed to create bid: %v", err)
+		}
+		return bid
+	}
+
+	first := place("req-1")
+	if count, _ := bidRepository.Collection.CountDocuments(ctx, bson.M{}); count != 1 {
+		t.Fatalf("Expected the first bid to be inserted, got %d bids", count)
+	}
+
+	// A repetição gera um novo lance com outro id, mas o mesmo request_id
+	place("req-1")
+	if count, _ := bidRepository.Collection.CountDocuments(ctx, bson.M{}); count != 1 {
+		t.Errorf("Expected the retry to be a no-op, got %d bids", count)
+	}
+	prior, err := bidRepository.FindBidByRequestId(ctx, userId, "req-1")
+	if err != nil || prior.Id != first.Id {
+		t.Errorf("Expected the original bid %s to be kept, got %+v (%v)", first.Id, prior, err)
+	}
+
+	found, _ := auctionRepository.FindAuctionById(ctx, auctionEntity.Id)
+	if found.BidCount != 1 {
+		t.Errorf("Expected the retry not to count as a bid, got bid_count=%d", found.BidCount)
+	}
+
+	place("req-2")
+	if count, _ := bidRepository.Collection.CountDocuments(ctx, bson.M{}); count != 2 {
+		t.Errorf("Expected a different request id to insert a new bid, got %d bids", count)
+	}
+
+	// Lances sem request_id não são afetados pelo índice único
+	place("")
+	place("")
+	if count, _ := bidRepository.Collection.CountDocuments(ctx, bson.M{}); count != 4 {
+		t.Errorf("Expected bids without request id to always be inserted, got %d bids", count)
+	}
+}
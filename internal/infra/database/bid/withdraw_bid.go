@@ -0,0 +1,113 @@
+package bid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// WithWithdrawalWindow define por quanto tempo, antes do fim do leilão, o lance que
+// lidera não pode mais ser retirado
+func WithWithdrawalWindow(window time.Duration) BidRepositoryOption {
+	return func(bd *BidRepository) {
+		bd.withdrawalWindow = window
+	}
+}
+
+// getWithdrawalWindow lê BID_WITHDRAWAL_WINDOW. O padrão é 5 minutos
+func getWithdrawalWindow() time.Duration {
+	window, err := time.ParseDuration(os.Getenv("BID_WITHDRAWAL_WINDOW"))
+	if err != nil || window < 0 {
+		return 5 * time.Minute
+	}
+
+	return window
+}
+
+// WithdrawBid retira o lance do usuário enquanto o leilão aceita lances. O lance que
+// lidera não pode ser retirado nos últimos withdrawalWindow do leilão. O lance é
+// removido, o maior lance do leilão é recalculado e a retirada fica registrada no log
+func (bd *BidRepository) WithdrawBid(
+	ctx context.Context, bidId, userId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	var bidEntityMongo BidEntityMongo
+	err := bd.Collection.FindOne(ctx, bson.M{"_id": bidId}).Decode(&bidEntityMongo)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, internal_error.NewNotFoundError(fmt.Sprintf("Bid not found with this id = %s", bidId))
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to find bid by id %s", bidId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to find bid by id")
+	}
+
+	if bidEntityMongo.UserId != userId {
+		return nil, internal_error.NewForbiddenError("Only the bidder can withdraw this bid")
+	}
+
+	auctionEntity, biddable, _, auctionErr := bd.AuctionRepository.BiddableAuction(ctx, bidEntityMongo.AuctionId)
+	if auctionErr != nil {
+		return nil, auctionErr
+	}
+	if !biddable {
+		return nil, internal_error.NewConflictError("Bids can only be withdrawn while the auction is active")
+	}
+
+	if auctionEntity.RemainingTime(bd.AuctionRepository.Now()) <= bd.withdrawalWindow {
+		winningBid, winningErr := bd.FindWinningBidByAuctionId(ctx, auctionEntity.Id)
+		if winningErr != nil && winningErr.Code() != internal_error.NotFound {
+			return nil, winningErr
+		}
+		if winningBid != nil && winningBid.Id == bidId {
+			return nil, internal_error.NewConflictError(fmt.Sprintf(
+				"The winning bid cannot be withdrawn in the last %s of the auction", bd.withdrawalWindow))
+		}
+	}
+
+	result, err := bd.Collection.DeleteOne(ctx, bson.M{"_id": bidId, "user_id": userId})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to withdraw bid %s", bidId), err)
+		return nil, internal_error.NewInternalServerError("Error trying to withdraw bid")
+	}
+	if result.DeletedCount == 0 {
+		return nil, internal_error.NewNotFoundError(fmt.Sprintf("Bid not found with this id = %s", bidId))
+	}
+
+	var highBid auction_entity.Money
+	winningBid, winningErr := bd.FindWinningBidByAuctionId(ctx, auctionEntity.Id)
+	if winningErr != nil && winningErr.Code() != internal_error.NotFound {
+		return nil, winningErr
+	}
+	if winningBid != nil {
+		highBid = winningBid.Amount
+	}
+
+	if err := bd.AuctionRepository.RecordBidWithdrawal(
+		ctx, auctionEntity.Id, bidEntityMongo.Amount, highBid); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Bid withdrawn",
+		zap.String("bid_id", bidId),
+		zap.String("auction_id", auctionEntity.Id),
+		zap.String("user_id", userId),
+		zap.Stringer("amount", bidEntityMongo.Amount),
+		zap.Stringer("current_high_bid", highBid))
+
+	return &bid_entity.Bid{
+		Id:        bidEntityMongo.Id,
+		UserId:    bidEntityMongo.UserId,
+		AuctionId: bidEntityMongo.AuctionId,
+		Amount:    bidEntityMongo.Amount,
+		Currency:  currencyOrDefault(bidEntityMongo.Currency),
+		Timestamp: time.Unix(bidEntityMongo.Timestamp, 0).UTC(),
+	}, nil
+}
@@ -0,0 +1,166 @@
+package bid
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/database/auction"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// placeBids grava um lance de um usuário diferente para cada valor, na ordem informada
+func placeBids(
+	t *testing.T,
+	bidRepository *BidRepository,
+	auctionId string,
+	amounts ...auction_entity.Money) []bid_entity.Bid {
+	var bids []bid_entity.Bid
+	for _, amount := range amounts {
+		bid, _ := bid_entity.CreateBid(uuid.New().String(), auctionId, amount)
+		bids = append(bids, *bid)
+	}
+	if err := bidRepository.CreateBid(context.Background(), bids); err != nil {
+		t.Fatalf("Failed to create bids: %v", err)
+	}
+
+	return bids
+}
+
+func TestWithdrawBidRecomputesHighBid(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	auctionRepository := newTestAuctionRepository(t, db, auction.WithMonitorDisabled(true))
+	bidRepository := newTestBidRepository(t, db, auctionRepository, WithWithdrawalWindow(time.Minute))
+	ctx := context.Background()
+
+	auctionEntity := createTestAuction(t, auctionRepository)
+	bids := placeBids(t, bidRepository, auctionEntity.Id, 1000, 2500)
+
+	// O lance vencedor pode ser retirado fora da janela final
+	if _, err := bidRepository.WithdrawBid(ctx, bids[1].Id, bids[1].UserId); err != nil {
+		t.Fatalf("Failed to withdraw bid: %v", err)
+	}
+
+	found, _ := auctionRepository.FindAuctionById(ctx, auctionEntity.Id)
+	if found.CurrentHighBid != 1000 || found.BidCount != 1 {
+		t.Errorf("Expected 1 bid with high bid 10.00, got %d and %s", found.BidCount, found.CurrentHighBid)
+	}
+
+	winning, err := bidRepository.FindWinningBidByAuctionId(ctx, auctionEntity.Id)
+	if err != nil || winning.Id != bids[0].Id {
+		t.Errorf("Expected the remaining bid to win, got %+v (%v)", winning, err)
+	}
+
+	if _, err := bidRepository.WithdrawBid(ctx, bids[1].Id, bids[1].UserId); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected a not_found error withdrawing twice, got %v", err)
+	}
+}
+
+func TestWithdrawBidRejectsOtherUser(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	auctionRepository := newTestAuctionRepository(t, db, auction.WithMonitorDisabled(true))
+	bidRepository := newTestBidRepository(t, db, auctionRepository)
+
+	auctionEntity := createTestAuction(t, auctionRepository)
+	bids := placeBids(t, bidRepository, auctionEntity.Id, 1000)
+
+	_, err := bidRepository.WithdrawBid(context.Background(), bids[0].Id, uuid.New().String())
+	if err == nil || err.Err != "forbidden" {
+		t.Errorf("Expected a forbidden error, got %v", err)
+	}
+}
+
+func TestWithdrawBidRejectsClosedAuction(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	auctionRepository := newTestAuctionRepository(t, db, auction.WithMonitorDisabled(true))
+	bidRepository := newTestBidRepository(t, db, auctionRepository)
+	ctx := context.Background()
+
+	auctionEntity := createTestAuction(t, auctionRepository)
+	bids := placeBids(t, bidRepository, auctionEntity.Id, 1000, 2500)
+	if err := auctionRepository.CloseAuction(ctx, auctionEntity.Id); err != nil {
+		t.Fatalf("Failed to close auction: %v", err)
+	}
+
+	_, err := bidRepository.WithdrawBid(ctx, bids[0].Id, bids[0].UserId)
+	if err == nil || err.Err != "conflict" {
+		t.Errorf("Expected a conflict error, got %v", err)
+	}
+
+	found, _ := auctionRepository.FindAuctionById(ctx, auctionEntity.Id)
+	if found.BidCount != 2 {
+		t.Errorf("Expected the bid to be kept, got bid_count %d", found.BidCount)
+	}
+}
+
+func TestWithdrawBidRejectsWinningBidInFinalWindow(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	auctionRepository := newTestAuctionRepository(t, db, auction.WithMonitorDisabled(true))
+	// Uma janela maior que a duração coloca o leilão inteiro dentro dela
+	bidRepository := newTestBidRepository(t, db, auctionRepository, WithWithdrawalWindow(time.Hour))
+	ctx := context.Background()
+
+	auctionEntity := createTestAuction(t, auctionRepository)
+	bids := placeBids(t, bidRepository, auctionEntity.Id, 1000, 2500)
+
+	_, err := bidRepository.WithdrawBid(ctx, bids[1].Id, bids[1].UserId)
+	if err == nil || err.Err != "conflict" {
+		t.Errorf("Expected a conflict error for the winning bid, got %v", err)
+	}
+
+	// Lances que não lideram continuam podendo ser retirados
+	if _, err := bidRepository.WithdrawBid(ctx, bids[0].Id, bids[0].UserId); err != nil {
+		t.Errorf("Expected the losing bid to be withdrawn, got %v", err)
+	}
+
+	found, _ := auctionRepository.FindAuctionById(ctx, auctionEntity.Id)
+	if found.CurrentHighBid != 2500 || found.BidCount != 1 {
+		t.Errorf("Expected 1 bid with high bid 25.00, got %d and %s", found.BidCount, found.CurrentHighBid)
+	}
+}
+
+func TestWithdrawBidUsesAuctionRepositoryClock(t *testing.T) {
+	os.Setenv("AUCTION_DURATION", "10m")
+	defer os.Unsetenv("AUCTION_DURATION")
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// Pela hora real faltam 10 minutos; pelo relógio do repositório faltam 4, dentro
+	// da janela de 5 minutos em que o lance que lidera não pode ser retirado
+	auctionRepository := newTestAuctionRepository(t, db,
+		auction.WithMonitorDisabled(true), auction.WithClock(fixedClock(time.Now().Add(6*time.Minute))))
+	bidRepository := newTestBidRepository(t, db, auctionRepository, WithWithdrawalWindow(5*time.Minute))
+	ctx := context.Background()
+
+	auctionEntity := createTestAuction(t, auctionRepository)
+	bids := placeBids(t, bidRepository, auctionEntity.Id, 1000, 2500)
+
+	_, err := bidRepository.WithdrawBid(ctx, bids[1].Id, bids[1].UserId)
+	if err == nil || err.Err != "conflict" {
+		t.Errorf("Expected the window to follow the repository clock, got %v", err)
+	}
+}
@@ -0,0 +1,259 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuctionRepository é uma implementação em memória de
+// auction_entity.AuctionRepositoryInterface, usada nos testes de casos de uso
+type AuctionRepository struct {
+	auctions map[string]auction_entity.Auction
+	mutex    *sync.Mutex
+}
+
+func NewAuctionRepository(auctions ...auction_entity.Auction) *AuctionRepository {
+	repo := &AuctionRepository{
+		auctions: make(map[string]auction_entity.Auction),
+		mutex:    &sync.Mutex{},
+	}
+
+	for _, auction := range auctions {
+		repo.auctions[auction.Id] = auction
+	}
+
+	return repo
+}
+
+func (ar *AuctionRepository) CreateAuction(
+	ctx context.Context,
+	auctionEntity *auction_entity.Auction) *internal_error.InternalError {
+	ar.mutex.Lock()
+	defer ar.mutex.Unlock()
+
+	if _, ok := ar.auctions[auctionEntity.Id]; ok {
+		return internal_error.NewInternalServerError("Error trying to insert auction")
+	}
+
+	ar.auctions[auctionEntity.Id] = *auctionEntity
+	return nil
+}
+
+func (ar *AuctionRepository) FindAuctions(
+	ctx context.Context,
+	status auction_entity.AuctionStatus,
+	category, productName string,
+	sortOrder auction_entity.AuctionSortOrder,
+	viewerId string) ([]auction_entity.Auction, *internal_error.InternalError) {
+	ar.mutex.Lock()
+	defer ar.mutex.Unlock()
+
+	var auctions []auction_entity.Auction
+	for _, auction := range ar.auctions {
+		if !auction.VisibleTo(viewerId) {
+			continue
+		}
+		if status != 0 && auction.Status != status {
+			continue
+		}
+		if category != "" && auction.Category != category {
+			continue
+		}
+		if productName != "" &&
+			!strings.Contains(strings.ToLower(auction.ProductName), strings.ToLower(productName)) {
+			continue
+		}
+
+		auctions = append(auctions, auction)
+	}
+
+	switch sortOrder {
+	case auction_entity.HighBidAscending:
+		sort.SliceStable(auctions, func(i, j int) bool {
+			return auctions[i].CurrentHighBid < auctions[j].CurrentHighBid
+		})
+	case auction_entity.HighBidDescending:
+		sort.SliceStable(auctions, func(i, j int) bool {
+			return auctions[i].CurrentHighBid > auctions[j].CurrentHighBid
+		})
+	case auction_entity.NewestFirst:
+		sort.SliceStable(auctions, func(i, j int) bool {
+			return auctions[i].Timestamp.After(auctions[j].Timestamp)
+		})
+	}
+
+	return auctions, nil
+}
+
+func (ar *AuctionRepository) FindAuctionsPage(
+	ctx context.Context,
+	status auction_entity.AuctionStatus,
+	category, productName string,
+	offset, limit int,
+	viewerId string) ([]auction_entity.Auction, int64, *internal_error.InternalError) {
+	ar.mutex.Lock()
+	defer ar.mutex.Unlock()
+
+	var auctions []auction_entity.Auction
+	for _, auction := range ar.auctions {
+		if !auction.VisibleTo(viewerId) {
+			continue
+		}
+		if status != auction_entity.AnyStatus && auction.Status != status {
+			continue
+		}
+		if category != "" && auction.Category != category {
+			continue
+		}
+		if productName != "" &&
+			!strings.Contains(strings.ToLower(auction.ProductName), strings.ToLower(productName)) {
+			continue
+		}
+
+		auctions = append(auctions, auction)
+	}
+
+	sort.Slice(auctions, func(i, j int) bool {
+		if !auctions[i].Timestamp.Equal(auctions[j].Timestamp) {
+			return auctions[i].Timestamp.After(auctions[j].Timestamp)
+		}
+		return auctions[i].Id > auctions[j].Id
+	})
+
+	total := int64(len(auctions))
+	if offset >= len(auctions) {
+		return []auction_entity.Auction{}, total, nil
+	}
+
+	end := offset + limit
+	if end > len(auctions) {
+		end = len(auctions)
+	}
+
+	return auctions[offset:end], total, nil
+}
+
+func (ar *AuctionRepository) FindAuctionById(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	ar.mutex.Lock()
+	defer ar.mutex.Unlock()
+
+	auction, ok := ar.auctions[id]
+	if !ok {
+		return nil, internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s", id))
+	}
+
+	return &auction, nil
+}
+
+// FindAuctionByIdUncached é igual a FindAuctionById: o repositório em memória não
+// tem cache de leitura
+func (ar *AuctionRepository) FindAuctionByIdUncached(
+	ctx context.Context, id string) (*auction_entity.Auction, *internal_error.InternalError) {
+	return ar.FindAuctionById(ctx, id)
+}
+
+func (ar *AuctionRepository) FindAuctionStatus(
+	ctx context.Context, id string) (*auction_entity.AuctionStatusView, *internal_error.InternalError) {
+	auction, err := ar.FindAuctionById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auction_entity.AuctionStatusView{
+		Status:         auction.Status,
+		CurrentHighBid: auction.CurrentHighBid,
+		EndsAt:         auction.EndsAt,
+		BidCount:       auction.BidCount,
+	}, nil
+}
+
+func (ar *AuctionRepository) RemainingTime(
+	ctx context.Context, id string) (time.Duration, *internal_error.InternalError) {
+	auction, err := ar.FindAuctionById(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return auction.RemainingTime(time.Now()), nil
+}
+
+func (ar *AuctionRepository) CountAuctions(
+	ctx context.Context, status auction_entity.AuctionStatus) (int64, *internal_error.InternalError) {
+	ar.mutex.Lock()
+	defer ar.mutex.Unlock()
+
+	var count int64
+	for _, auction := range ar.auctions {
+		if status == auction_entity.AnyStatus || auction.Status == status {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (ar *AuctionRepository) CountAuctionsByOwner(
+	ctx context.Context,
+	ownerId string,
+	status auction_entity.AuctionStatus) (int64, *internal_error.InternalError) {
+	ar.mutex.Lock()
+	defer ar.mutex.Unlock()
+
+	var count int64
+	for _, auction := range ar.auctions {
+		if auction.OwnerId == ownerId &&
+			(status == auction_entity.AnyStatus || auction.Status == status) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (ar *AuctionRepository) ListCategories(
+	ctx context.Context) ([]string, *internal_error.InternalError) {
+	ar.mutex.Lock()
+	defer ar.mutex.Unlock()
+
+	seen := make(map[string]bool)
+	categories := []string{}
+	for _, auction := range ar.auctions {
+		if auction.Category != "" && !seen[auction.Category] {
+			seen[auction.Category] = true
+			categories = append(categories, auction.Category)
+		}
+	}
+	sort.Strings(categories)
+
+	return categories, nil
+}
+
+func (ar *AuctionRepository) CloseAuction(
+	ctx context.Context, id string) *internal_error.InternalError {
+	ar.mutex.Lock()
+	defer ar.mutex.Unlock()
+
+	auction, ok := ar.auctions[id]
+	if !ok {
+		return internal_error.NewNotFoundError(
+			fmt.Sprintf("Auction not found with this id = %s", id))
+	}
+
+	if auction.Status != auction_entity.Active {
+		return internal_error.NewBadRequestError(fmt.Sprintf("Auction %s is not active", id))
+	}
+
+	auction.Status = auction_entity.Completed
+	auction.CompletedAt = time.Now().UTC()
+	auction.Version++
+	ar.auctions[id] = auction
+	return nil
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+)
+
+// ProxyBookRepository guarda os livros de máximos em memória, para testes. As
+// atualizações são serializadas por um único mutex
+type ProxyBookRepository struct {
+	mutex sync.Mutex
+	books map[string]*bid_entity.ProxyBook
+}
+
+func NewProxyBookRepository() *ProxyBookRepository {
+	return &ProxyBookRepository{
+		books: make(map[string]*bid_entity.ProxyBook),
+	}
+}
+
+func (pr *ProxyBookRepository) FindProxyBook(
+	ctx context.Context, auctionId string) (*bid_entity.ProxyBook, *internal_error.InternalError) {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	return copyProxyBook(pr.books[auctionId]), nil
+}
+
+func (pr *ProxyBookRepository) UpdateProxyBook(
+	ctx context.Context,
+	auctionId string,
+	update func(book *bid_entity.ProxyBook) *bid_entity.ProxyBook) *internal_error.InternalError {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	if updated := update(copyProxyBook(pr.books[auctionId])); updated != nil {
+		pr.books[auctionId] = copyProxyBook(updated)
+	}
+
+	return nil
+}
+
+func (pr *ProxyBookRepository) DeleteProxyBook(
+	ctx context.Context, auctionId string) *internal_error.InternalError {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	delete(pr.books, auctionId)
+	return nil
+}
+
+func (pr *ProxyBookRepository) FindProxyBookAuctionIds(
+	ctx context.Context) ([]string, *internal_error.InternalError) {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	auctionIds := make([]string, 0, len(pr.books))
+	for auctionId := range pr.books {
+		auctionIds = append(auctionIds, auctionId)
+	}
+
+	return auctionIds, nil
+}
+
+// copyProxyBook evita que quem recebe o livro altere o guardado sem passar por Update
+func copyProxyBook(book *bid_entity.ProxyBook) *bid_entity.ProxyBook {
+	if book == nil {
+		return nil
+	}
+
+	return bid_entity.RestoreProxyBook(book.AuctionId, book.Price, book.LeaderId, book.Proxies())
+}
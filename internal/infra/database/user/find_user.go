@@ -13,8 +13,9 @@ import (
 )
 
 type UserEntityMongo struct {
-	Id   string `bson:"_id"`
-	Name string `bson:"name"`
+	Id    string `bson:"_id"`
+	Name  string `bson:"name"`
+	Email string `bson:"email,omitempty"`
 }
 
 type UserRepository struct {
@@ -45,8 +46,9 @@ func (ur *UserRepository) FindUserById(
 	}
 
 	userEntity := &user_entity.User{
-		Id:   userEntityMongo.Id,
-		Name: userEntityMongo.Name,
+		Id:    userEntityMongo.Id,
+		Name:  userEntityMongo.Name,
+		Email: userEntityMongo.Email,
 	}
 
 	return userEntity, nil
@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MemoryRateLimiter limita as requisições por chave em janelas fixas.
+// Chaves inativas são descartadas a cada janela, sem goroutine dedicada
+type MemoryRateLimiter struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	windows   map[string]*rateWindow
+	lastSweep time.Time
+	mutex     *sync.Mutex
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		limit:   getBidRateLimit(),
+		window:  getBidRateLimitWindow(),
+		now:     time.Now,
+		windows: make(map[string]*rateWindow),
+		mutex:   &sync.Mutex{},
+	}
+}
+
+// Allow registra uma requisição para a chave e indica se ela está dentro do limite.
+// Um limite menor ou igual a zero desativa a limitação
+func (rl *MemoryRateLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	if rl.limit <= 0 {
+		return true, nil
+	}
+
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	now := rl.now()
+	rl.sweep(now)
+
+	current, ok := rl.windows[key]
+	if !ok || now.Sub(current.start) >= rl.window {
+		current = &rateWindow{start: now}
+		rl.windows[key] = current
+	}
+
+	if current.count >= rl.limit {
+		return false, nil
+	}
+
+	current.count++
+	return true, nil
+}
+
+// sweep remove as janelas já encerradas, no máximo uma vez por janela
+func (rl *MemoryRateLimiter) sweep(now time.Time) {
+	if now.Sub(rl.lastSweep) < rl.window {
+		return
+	}
+
+	for key, current := range rl.windows {
+		if now.Sub(current.start) >= rl.window {
+			delete(rl.windows, key)
+		}
+	}
+	rl.lastSweep = now
+}
+
+func getBidRateLimit() int {
+	value, err := strconv.Atoi(os.Getenv("BID_RATE_LIMIT"))
+	if err != nil {
+		return 10
+	}
+
+	return value
+}
+
+func getBidRateLimitWindow() time.Duration {
+	window, err := time.ParseDuration(os.Getenv("BID_RATE_LIMIT_WINDOW"))
+	if err != nil || window <= 0 {
+		return time.Minute
+	}
+
+	return window
+}
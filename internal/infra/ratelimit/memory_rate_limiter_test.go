@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestRateLimiter(limit int, window time.Duration, now *time.Time) *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		limit:   limit,
+		window:  window,
+		now:     func() time.Time { return *now },
+		windows: make(map[string]*rateWindow),
+		mutex:   &sync.Mutex{},
+	}
+}
+
+func TestMemoryRateLimiterAllowsLimitThenBlocks(t *testing.T) {
+	now := time.Now()
+	rl := newTestRateLimiter(3, time.Minute, &now)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if allowed, _ := rl.Allow(ctx, "user-1"); !allowed {
+			t.Fatalf("Expected request %d to be allowed", i+1)
+		}
+	}
+
+	if allowed, _ := rl.Allow(ctx, "user-1"); allowed {
+		t.Error("Expected request beyond the limit to be blocked")
+	}
+
+	if allowed, _ := rl.Allow(ctx, "user-2"); !allowed {
+		t.Error("Expected another user to have its own limit")
+	}
+}
+
+func TestMemoryRateLimiterResetsAfterWindow(t *testing.T) {
+	now := time.Now()
+	rl := newTestRateLimiter(1, time.Minute, &now)
+	ctx := context.Background()
+
+	rl.Allow(ctx, "user-1")
+	if allowed, _ := rl.Allow(ctx, "user-1"); allowed {
+		t.Fatal("Expected second request in the window to be blocked")
+	}
+
+	now = now.Add(time.Minute)
+	if allowed, _ := rl.Allow(ctx, "user-1"); !allowed {
+		t.Error("Expected request to be allowed after the window")
+	}
+}
+
+func TestMemoryRateLimiterDropsInactiveKeys(t *testing.T) {
+	now := time.Now()
+	rl := newTestRateLimiter(1, time.Minute, &now)
+	ctx := context.Background()
+
+	for _, key := range []string{"user-1", "user-2", "user-3"} {
+		rl.Allow(ctx, key)
+	}
+
+	now = now.Add(2 * time.Minute)
+	rl.Allow(ctx, "user-4")
+
+	if len(rl.windows) != 1 {
+		t.Errorf("Expected only the active key to remain, got %d keys", len(rl.windows))
+	}
+}
+
+func TestMemoryRateLimiterDisabled(t *testing.T) {
+	now := time.Now()
+	rl := newTestRateLimiter(0, time.Minute, &now)
+
+	for i := 0; i < 100; i++ {
+		if allowed, _ := rl.Allow(context.Background(), "user-1"); !allowed {
+			t.Fatal("Expected a zero limit to disable rate limiting")
+		}
+	}
+}
@@ -1,31 +1,106 @@
 package internal_error
 
+import (
+	"fmt"
+	"strings"
+)
+
+// Code identifica a categoria do erro de forma estável, para que o mapeamento HTTP e
+// os clientes não dependam da comparação de textos. O valor é o mesmo de Err
+type Code string
+
+const (
+	NotFound        Code = "not_found"
+	BadRequest      Code = "bad_request"
+	Conflict        Code = "conflict"
+	Internal        Code = "internal_server_error"
+	Timeout         Code = "timeout"
+	TooManyRequests Code = "too_many_requests"
+	LimitExceeded   Code = "limit_exceeded"
+	Forbidden       Code = "forbidden"
+)
+
 type InternalError struct {
 	Message string
 	Err     string
+	Fields  []FieldError
+
+	code Code
 }
 
-func (ie *InternalError) Error() string {
-	return ie.Message
+// FieldError descreve um campo inválido de uma validação
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
-func NewNotFoundError(message string) *InternalError {
+// Code retorna a categoria do erro
+func (ie *InternalError) Code() Code {
+	return ie.code
+}
+
+func newError(code Code, message string) *InternalError {
 	return &InternalError{
 		Message: message,
-		Err:     "not_found",
+		Err:     string(code),
+		code:    code,
 	}
 }
 
-func NewInternalServerError(message string) *InternalError {
-	return &InternalError{
-		Message: message,
-		Err:     "internal_server_error",
+func (ie *InternalError) Error() string {
+	if len(ie.Fields) == 0 {
+		return ie.Message
 	}
+
+	fields := make([]string, 0, len(ie.Fields))
+	for _, field := range ie.Fields {
+		fields = append(fields, fmt.Sprintf("%s: %s", field.Field, field.Message))
+	}
+
+	return fmt.Sprintf("%s (%s)", ie.Message, strings.Join(fields, "; "))
+}
+
+func NewNotFoundError(message string) *InternalError {
+	return newError(NotFound, message)
+}
+
+// NewValidationError agrupa todos os campos inválidos em um único erro de bad_request,
+// para que a API informe todos eles na mesma resposta
+func NewValidationError(message string, fields []FieldError) *InternalError {
+	validationError := newError(BadRequest, message)
+	validationError.Fields = fields
+
+	return validationError
+}
+
+func NewInternalServerError(message string) *InternalError {
+	return newError(Internal, message)
 }
 
 func NewBadRequestError(message string) *InternalError {
-	return &InternalError{
-		Message: message,
-		Err:     "bad_request",
-	}
+	return newError(BadRequest, message)
+}
+
+func NewTooManyRequestsError(message string) *InternalError {
+	return newError(TooManyRequests, message)
+}
+
+func NewConflictError(message string) *InternalError {
+	return newError(Conflict, message)
+}
+
+func NewTimeoutError(message string) *InternalError {
+	return newError(Timeout, message)
+}
+
+// NewLimitExceededError indica que a operação ultrapassaria um limite de negócio,
+// como o número de leilões ativos por usuário
+func NewLimitExceededError(message string) *InternalError {
+	return newError(LimitExceeded, message)
+}
+
+// NewForbiddenError indica que o usuário não tem acesso ao recurso, como um leilão
+// privado para o qual não foi convidado
+func NewForbiddenError(message string) *InternalError {
+	return newError(Forbidden, message)
 }
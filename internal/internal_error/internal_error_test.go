@@ -0,0 +1,30 @@
+package internal_error
+
+import "testing"
+
+func TestConstructorsSetCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *InternalError
+		code Code
+	}{
+		{"not found", NewNotFoundError("message"), NotFound},
+		{"validation", NewValidationError("message", []FieldError{{Field: "name", Message: "required"}}), BadRequest},
+		{"bad request", NewBadRequestError("message"), BadRequest},
+		{"internal", NewInternalServerError("message"), Internal},
+		{"too many requests", NewTooManyRequestsError("message"), TooManyRequests},
+		{"conflict", NewConflictError("message"), Conflict},
+		{"timeout", NewTimeoutError("message"), Timeout},
+		{"limit exceeded", NewLimitExceededError("message"), LimitExceeded},
+		{"forbidden", NewForbiddenError("message"), Forbidden},
+	}
+	for _, tt := range tests {
+		if code := tt.err.Code(); code != tt.code {
+			t.Errorf("%s: expected code %q, got %q", tt.name, tt.code, code)
+		}
+		// Err continua com o mesmo valor para quem ainda compara o texto
+		if tt.err.Err != string(tt.code) {
+			t.Errorf("%s: expected Err %q, got %q", tt.name, tt.code, tt.err.Err)
+		}
+	}
+}
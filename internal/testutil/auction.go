@@ -0,0 +1,90 @@
+// Package testutil reúne helpers para montar leilões válidos nos testes do repositório
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"time"
+)
+
+// AuctionOption sobrescreve um campo do leilão de teste depois de criado
+type AuctionOption func(*auction_entity.Auction)
+
+func WithStatus(status auction_entity.AuctionStatus) AuctionOption {
+	return func(auction *auction_entity.Auction) {
+		auction.Status = status
+	}
+}
+
+func WithTimestamp(timestamp time.Time) AuctionOption {
+	return func(auction *auction_entity.Auction) {
+		auction.Timestamp = timestamp
+	}
+}
+
+func WithCategory(category string) AuctionOption {
+	return func(auction *auction_entity.Auction) {
+		auction.Category = category
+	}
+}
+
+func WithProductName(productName string) AuctionOption {
+	return func(auction *auction_entity.Auction) {
+		auction.ProductName = productName
+	}
+}
+
+func WithDuration(duration time.Duration) AuctionOption {
+	return func(auction *auction_entity.Auction) {
+		auction.Duration = duration
+	}
+}
+
+// NewTestAuction cria um leilão ativo e válido com valores padrão, aplicando as opções
+func NewTestAuction(opts ...AuctionOption) *auction_entity.Auction {
+	auction, err := auction_entity.CreateAuction(
+		"Test Product",
+		"Electronics",
+		"A test product for auction",
+		auction_entity.New,
+	)
+	if err != nil {
+		panic(fmt.Sprintf("testutil: default auction is invalid: %v", err))
+	}
+
+	for _, opt := range opts {
+		opt(auction)
+	}
+
+	return auction
+}
+
+// MakeExpired faz o leilão terminar no passado, independentemente da duração configurada
+// no repositório: ele passa a ter começado há um dia e durado um minuto
+func MakeExpired(auction *auction_entity.Auction) {
+	auction.Timestamp = time.Now().Add(-24 * time.Hour)
+	auction.Duration = time.Minute
+}
+
+// SeedAuctions cria e persiste n leilões de teste, com nomes de produto numerados
+func SeedAuctions(
+	ctx context.Context,
+	repo auction_entity.AuctionRepositoryInterface,
+	n int,
+	opts ...AuctionOption) ([]*auction_entity.Auction, *internal_error.InternalError) {
+	auctions := make([]*auction_entity.Auction, 0, n)
+	for i := 0; i < n; i++ {
+		auction := NewTestAuction(append(
+			[]AuctionOption{WithProductName(fmt.Sprintf("Test Product %02d", i))}, opts...)...)
+
+		if err := repo.CreateAuction(ctx, auction); err != nil {
+			return auctions, err
+		}
+
+		auctions = append(auctions, auction)
+	}
+
+	return auctions, nil
+}
@@ -0,0 +1,56 @@
+package testutil
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/database/memory"
+	"testing"
+	"time"
+)
+
+func TestNewTestAuctionIsValid(t *testing.T) {
+	auction := NewTestAuction()
+	if err := auction.Validate(); err != nil {
+		t.Fatalf("Expected a valid default auction, got %v", err)
+	}
+	if auction.Status != auction_entity.Active {
+		t.Errorf("Expected an active auction, got %s", auction.Status)
+	}
+
+	timestamp := time.Now().Add(-time.Hour)
+	overridden := NewTestAuction(
+		WithStatus(auction_entity.Completed),
+		WithTimestamp(timestamp),
+		WithCategory("Music"))
+	if overridden.Status != auction_entity.Completed ||
+		!overridden.Timestamp.Equal(timestamp) ||
+		overridden.Category != "Music" {
+		t.Errorf("Expected overrides to be applied, got %+v", overridden)
+	}
+}
+
+func TestMakeExpired(t *testing.T) {
+	auction := NewTestAuction()
+	MakeExpired(auction)
+
+	if endsAt := auction.Timestamp.Add(auction.Duration); !endsAt.Before(time.Now()) {
+		t.Errorf("Expected auction to end in the past, ends at %v", endsAt)
+	}
+}
+
+func TestSeedAuctions(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewAuctionRepository()
+
+	seeded, err := SeedAuctions(ctx, repo, 3, WithCategory("Music"))
+	if err != nil || len(seeded) != 3 {
+		t.Fatalf("Expected 3 seeded auctions, got %d (%v)", len(seeded), err)
+	}
+
+	for _, auction := range seeded {
+		found, err := repo.FindAuctionById(ctx, auction.Id)
+		if err != nil || found.Category != "Music" {
+			t.Errorf("Expected seeded auction %s to be persisted, got %+v (%v)", auction.Id, found, err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/event"
 	"fullcycle-auction_go/internal/internal_error"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
 	"time"
@@ -12,32 +13,161 @@ import (
 type AuctionInputDTO struct {
 	ProductName string           `json:"product_name" binding:"required,min=1"`
 	Category    string           `json:"category" binding:"required,min=2"`
-	Description string           `json:"description" binding:"required,min=10,max=200"`
-	Condition   ProductCondition `json:"condition" binding:"oneof=0 1 2"`
+	Description string           `json:"description" binding:"required,min=10"`
+	Condition   ProductCondition `json:"condition" binding:"omitempty,oneof=1 2 3"`
+	Duration    int64            `json:"duration_seconds" binding:"omitempty,min=1"`
+	Currency    string           `json:"currency" binding:"omitempty,len=3"`
+	StartsAt    time.Time        `json:"starts_at"`
+
+	// StartingBid é o lance mínimo aceito pelo leilão; zero significa sem mínimo
+	StartingBid auction_entity.Money `json:"starting_bid" binding:"omitempty,min=0"`
+
+	// OwnerId é o usuário vendedor. Vem do header X-User-Id, preenchido pelo controller,
+	// e não do corpo da requisição, para que o cliente não escolha o dono livremente
+	OwnerId string `json:"-"`
+
+	// Visibility restringe leilões privados ao dono e aos usuários em AllowedUserIds
+	Visibility     string   `json:"visibility" binding:"omitempty,oneof=public private"`
+	AllowedUserIds []string `json:"allowed_user_ids"`
+
+	// ImageURLs referencia as imagens do produto, guardadas em um armazenamento externo
+	ImageURLs []string `json:"image_urls"`
 }
 
 type AuctionOutputDTO struct {
-	Id          string           `json:"id"`
-	ProductName string           `json:"product_name"`
-	Category    string           `json:"category"`
-	Description string           `json:"description"`
-	Condition   ProductCondition `json:"condition"`
-	Status      AuctionStatus    `json:"status"`
-	Timestamp   time.Time        `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+	Id             string               `json:"id"`
+	OwnerId        string               `json:"owner_id,omitempty"`
+	ProductName    string               `json:"product_name"`
+	Category       string               `json:"category"`
+	Description    string               `json:"description"`
+	Condition      string               `json:"condition"`
+	Status         string               `json:"status"`
+	Timestamp      time.Time            `json:"timestamp"`
+	StartsAt       *time.Time           `json:"starts_at,omitempty"`
+	BidCount       int64                `json:"bid_count"`
+	CurrentHighBid auction_entity.Money `json:"current_high_bid"`
+	StartingBid    auction_entity.Money `json:"starting_bid"`
+	Currency       string               `json:"currency"`
+	Visibility     string               `json:"visibility"`
+	AllowedUserIds []string             `json:"allowed_user_ids,omitempty"`
+	ImageURLs      []string             `json:"image_urls,omitempty"`
+	ActivatedAt    *time.Time           `json:"activated_at,omitempty"`
+	CompletedAt    *time.Time           `json:"completed_at,omitempty"`
+	Version        int64                `json:"version"`
+}
+
+// NewAuctionOutputDTO monta a resposta do leilão para viewerId. A lista de convidados
+// de um leilão privado só aparece para o dono
+func NewAuctionOutputDTO(auction auction_entity.Auction, viewerId string) AuctionOutputDTO {
+	output := AuctionOutputDTO{
+		Id:             auction.Id,
+		OwnerId:        auction.OwnerId,
+		ProductName:    auction.ProductName,
+		Category:       auction.Category,
+		Description:    auction.Description,
+		Condition:      auction.Condition.String(),
+		Status:         auction.Status.String(),
+		Timestamp:      auction.Timestamp.UTC(),
+		BidCount:       auction.BidCount,
+		CurrentHighBid: auction.CurrentHighBid,
+		StartingBid:    auction.StartingBid,
+		Currency:       auction.Currency,
+		Visibility:     string(auction.Visibility),
+		ImageURLs:      auction.ImageURLs,
+		Version:        auction.Version,
+	}
+
+	if viewerId != "" && viewerId == auction.OwnerId {
+		output.AllowedUserIds = auction.AllowedUserIds
+	}
+
+	if !auction.StartsAt.IsZero() {
+		startsAt := auction.StartsAt.UTC()
+		output.StartsAt = &startsAt
+	}
+
+	if !auction.ActivatedAt.IsZero() {
+		activatedAt := auction.ActivatedAt.UTC()
+		output.ActivatedAt = &activatedAt
+	}
+
+	if !auction.CompletedAt.IsZero() {
+		completedAt := auction.CompletedAt.UTC()
+		output.CompletedAt = &completedAt
+	}
+
+	return output
+}
+
+// AuctionStatusOutputDTO é a resposta enxuta para clientes que acompanham o leilão
+// por polling
+type AuctionStatusOutputDTO struct {
+	Status         string               `json:"status"`
+	CurrentHighBid auction_entity.Money `json:"current_high_bid"`
+	EndsAt         *time.Time           `json:"ends_at,omitempty"`
+	BidCount       int64                `json:"bid_count"`
+}
+
+// AuctionRemainingOutputDTO traz o tempo restante para contadores regressivos.
+// Completed distingue um leilão já fechado de um que terminou e aguarda a varredura
+type AuctionRemainingOutputDTO struct {
+	RemainingSeconds int64 `json:"remaining_seconds"`
+	Completed        bool  `json:"completed"`
+}
+
+type AuctionCountOutputDTO struct {
+	Count int64 `json:"count"`
+}
+
+type PaginationOutputDTO struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+type AuctionListOutputDTO struct {
+	Data       []AuctionOutputDTO  `json:"data"`
+	Pagination PaginationOutputDTO `json:"pagination"`
 }
 
 type WinningInfoOutputDTO struct {
 	Auction AuctionOutputDTO          `json:"auction"`
 	Bid     *bid_usecase.BidOutputDTO `json:"bid,omitempty"`
+
+	// RunnerUps são os maiores lances dos demais usuários, do segundo colocado em diante
+	RunnerUps []bid_usecase.BidOutputDTO `json:"runner_ups,omitempty"`
+}
+
+// AuctionUseCaseOption permite customizar o AuctionUseCase na sua criação
+type AuctionUseCaseOption func(*AuctionUseCase)
+
+// WithPublisher define para onde vão os eventos emitidos pelo caso de uso
+func WithPublisher(publisher event.Publisher) AuctionUseCaseOption {
+	return func(au *AuctionUseCase) {
+		au.publisher = publisher
+	}
 }
 
 func NewAuctionUseCase(
 	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
-	bidRepositoryInterface bid_entity.BidEntityRepository) AuctionUseCaseInterface {
-	return &AuctionUseCase{
+	bidRepositoryInterface bid_entity.BidEntityRepository,
+	opts ...AuctionUseCaseOption) AuctionUseCaseInterface {
+	auctionUseCase := &AuctionUseCase{
 		auctionRepositoryInterface: auctionRepositoryInterface,
 		bidRepositoryInterface:     bidRepositoryInterface,
+		publisher: event.NewTracingPublisher(
+			event.NewRetryingPublisher(event.NewLogPublisher())),
 	}
+
+	for _, opt := range opts {
+		opt(auctionUseCase)
+	}
+
+	auctionUseCase.createAuctionUseCase = NewCreateAuctionUseCase(
+		auctionRepositoryInterface, auctionUseCase.publisher)
+
+	return auctionUseCase
 }
 
 type AuctionUseCaseInterface interface {
@@ -45,17 +175,37 @@ type AuctionUseCaseInterface interface {
 		ctx context.Context,
 		auctionInput AuctionInputDTO) *internal_error.InternalError
 
+	// As buscas por id respondem not_found para leilões privados que viewerId não pode ver
 	FindAuctionById(
-		ctx context.Context, id string) (*AuctionOutputDTO, *internal_error.InternalError)
+		ctx context.Context, id, viewerId string) (*AuctionOutputDTO, *internal_error.InternalError)
+
+	FindAuctionStatus(
+		ctx context.Context, id, viewerId string) (*AuctionStatusOutputDTO, *internal_error.InternalError)
+
+	FindRemainingTime(
+		ctx context.Context, id, viewerId string) (*AuctionRemainingOutputDTO, *internal_error.InternalError)
 
 	FindAuctions(
 		ctx context.Context,
 		status AuctionStatus,
-		category, productName string) ([]AuctionOutputDTO, *internal_error.InternalError)
+		category, productName, sort string,
+		viewerId string) ([]AuctionOutputDTO, *internal_error.InternalError)
+
+	ListAuctions(
+		ctx context.Context,
+		status, category, productName string,
+		page, limit int,
+		viewerId string) (*AuctionListOutputDTO, *internal_error.InternalError)
 
 	FindWinningBidByAuctionId(
 		ctx context.Context,
-		auctionId string) (*WinningInfoOutputDTO, *internal_error.InternalError)
+		auctionId string,
+		runnerUps int,
+		viewerId string) (*WinningInfoOutputDTO, *internal_error.InternalError)
+
+	ListCategories(ctx context.Context) ([]string, *internal_error.InternalError)
+
+	CountActiveAuctions(ctx context.Context) (*AuctionCountOutputDTO, *internal_error.InternalError)
 }
 
 type ProductCondition int64
@@ -64,24 +214,12 @@ type AuctionStatus int64
 type AuctionUseCase struct {
 	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
 	bidRepositoryInterface     bid_entity.BidEntityRepository
+	publisher                  event.Publisher
+	createAuctionUseCase       *CreateAuctionUseCase
 }
 
 func (au *AuctionUseCase) CreateAuction(
 	ctx context.Context,
 	auctionInput AuctionInputDTO) *internal_error.InternalError {
-	auction, err := auction_entity.CreateAuction(
-		auctionInput.ProductName,
-		auctionInput.Category,
-		auctionInput.Description,
-		auction_entity.ProductCondition(auctionInput.Condition))
-	if err != nil {
-		return err
-	}
-
-	if err := au.auctionRepositoryInterface.CreateAuction(
-		ctx, auction); err != nil {
-		return err
-	}
-
-	return nil
+	return au.createAuctionUseCase.Execute(ctx, auctionInput)
 }
@@ -0,0 +1,63 @@
+package auction_usecase
+
+import (
+	"encoding/json"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"testing"
+	"time"
+)
+
+func TestAuctionOutputDTOSerialization(t *testing.T) {
+	timestamp := time.Date(2024, 3, 10, 15, 4, 5, 0, time.FixedZone("BRT", -3*60*60))
+	auction := auction_entity.Auction{
+		Id:          "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+		ProductName: "Notebook",
+		Category:    "Electronics",
+		Description: "Notebook Dell Inspiron 15",
+		Condition:   auction_entity.Used,
+		Status:      auction_entity.Completed,
+		Timestamp:   timestamp,
+	}
+
+	body, err := json.Marshal(NewAuctionOutputDTO(auction, ""))
+	if err != nil {
+		t.Fatalf("Failed to marshal auction output: %v", err)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("Failed to unmarshal auction output: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"id":           auction.Id,
+		"product_name": "Notebook",
+		"category":     "Electronics",
+		"description":  "Notebook Dell Inspiron 15",
+		"condition":    "Used",
+		"status":       "Completed",
+		"timestamp":    "2024-03-10T18:04:05Z",
+	}
+	for key, value := range expected {
+		if payload[key] != value {
+			t.Errorf("Expected %s to be %v, got %v", key, value, payload[key])
+		}
+	}
+}
+
+func TestAuctionOutputDTOCompletedAt(t *testing.T) {
+	open := NewAuctionOutputDTO(auction_entity.Auction{Status: auction_entity.Active}, "")
+	if open.CompletedAt != nil {
+		t.Errorf("Expected no completed_at for an open auction, got %v", open.CompletedAt)
+	}
+
+	completedAt := time.Date(2024, 3, 10, 15, 4, 5, 0, time.FixedZone("BRT", -3*60*60))
+	closed := NewAuctionOutputDTO(auction_entity.Auction{
+		Status:      auction_entity.Completed,
+		CompletedAt: completedAt,
+	}, "")
+	if closed.CompletedAt == nil || !closed.CompletedAt.Equal(completedAt) ||
+		closed.CompletedAt.Location() != time.UTC {
+		t.Errorf("Expected completed_at %v in UTC, got %v", completedAt, closed.CompletedAt)
+	}
+}
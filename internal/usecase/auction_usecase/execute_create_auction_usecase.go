@@ -0,0 +1,131 @@
+package auction_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/event"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const AuctionCreatedEvent = "auction.created"
+
+type AuctionCreatedPayload struct {
+	AuctionId   string    `json:"auction_id" bson:"auction_id"`
+	ProductName string    `json:"product_name" bson:"product_name"`
+	Category    string    `json:"category" bson:"category"`
+	Condition   string    `json:"condition" bson:"condition"`
+	Timestamp   time.Time `json:"timestamp" bson:"timestamp"`
+}
+
+// CreateAuctionUseCase orquestra a criação de um leilão: valida e monta a entidade,
+// persiste pelo repositório e emite o evento AuctionCreated
+type CreateAuctionUseCase struct {
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface
+	publisher                  event.Publisher
+	maxActivePerUser           int64
+}
+
+func NewCreateAuctionUseCase(
+	auctionRepositoryInterface auction_entity.AuctionRepositoryInterface,
+	publisher event.Publisher) *CreateAuctionUseCase {
+	return &CreateAuctionUseCase{
+		auctionRepositoryInterface: auctionRepositoryInterface,
+		publisher:                  publisher,
+		maxActivePerUser:           getMaxActivePerUser(),
+	}
+}
+
+// getMaxActivePerUser lê AUCTION_MAX_ACTIVE_PER_USER; zero (o padrão) significa sem limite
+func getMaxActivePerUser() int64 {
+	value, err := strconv.ParseInt(os.Getenv("AUCTION_MAX_ACTIVE_PER_USER"), 10, 64)
+	if err != nil || value < 0 {
+		return 0
+	}
+
+	return value
+}
+
+func (uc *CreateAuctionUseCase) Execute(
+	ctx context.Context,
+	auctionInput AuctionInputDTO) *internal_error.InternalError {
+	auction, err := auction_entity.CreateAuction(
+		auctionInput.ProductName,
+		auctionInput.Category,
+		auctionInput.Description,
+		auction_entity.ProductCondition(auctionInput.Condition),
+		auction_entity.WithDuration(time.Duration(auctionInput.Duration)*time.Second),
+		auction_entity.WithCurrency(auctionInput.Currency),
+		auction_entity.WithStartingBid(auctionInput.StartingBid),
+		auction_entity.WithOwnerId(auctionInput.OwnerId),
+		auction_entity.WithVisibility(auction_entity.Visibility(auctionInput.Visibility)),
+		auction_entity.WithAllowedUserIds(auctionInput.AllowedUserIds...),
+		auction_entity.WithImageURLs(auctionInput.ImageURLs...),
+		auction_entity.WithStartsAt(auctionInput.StartsAt))
+	if err != nil {
+		return err
+	}
+
+	if err := uc.checkActiveLimit(ctx, auction); err != nil {
+		return err
+	}
+
+	if err := uc.auctionRepositoryInterface.CreateAuction(ctx, auction); err != nil {
+		return err
+	}
+
+	createdEvent := event.NewEvent(AuctionCreatedEvent, AuctionCreatedPayload{
+		AuctionId:   auction.Id,
+		ProductName: auction.ProductName,
+		Category:    auction.Category,
+		Condition:   auction.Condition.String(),
+		Timestamp:   auction.Timestamp.UTC(),
+	})
+	// O leilão já está persistido: uma falha na publicação só é registrada, para que o
+	// cliente não receba erro e repita a criação, duplicando o leilão
+	if err := uc.publisher.Publish(ctx, createdEvent); err != nil {
+		logger.ErrorContext(ctx, "Error trying to publish auction created event", err,
+			zap.String("auction_id", auction.Id))
+	}
+
+	return nil
+}
+
+// checkActiveLimit impede que um mesmo dono tenha mais leilões ativos ou agendados que
+// o permitido; os agendados contam porque ficarão ativos sozinhos. Com o limite ligado
+// o dono é obrigatório. A contagem e a criação não são atômicas, então criações
+// simultâneas podem exceder o limite por pouco
+func (uc *CreateAuctionUseCase) checkActiveLimit(
+	ctx context.Context, auction *auction_entity.Auction) *internal_error.InternalError {
+	if uc.maxActivePerUser <= 0 {
+		return nil
+	}
+	if auction.OwnerId == "" {
+		return internal_error.NewBadRequestError(
+			"The X-User-Id header is required to create auctions")
+	}
+
+	var count int64
+	for _, status := range []auction_entity.AuctionStatus{
+		auction_entity.Active, auction_entity.Scheduled} {
+		statusCount, err := uc.auctionRepositoryInterface.CountAuctionsByOwner(
+			ctx, auction.OwnerId, status)
+		if err != nil {
+			return err
+		}
+		count += statusCount
+	}
+
+	if count >= uc.maxActivePerUser {
+		return internal_error.NewLimitExceededError(fmt.Sprintf(
+			"User %s already has %d active or scheduled auctions, the maximum allowed", auction.OwnerId, count))
+	}
+
+	return nil
+}
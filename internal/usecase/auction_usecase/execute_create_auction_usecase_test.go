@@ -0,0 +1,187 @@
+package auction_usecase
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/event"
+	"fullcycle-auction_go/internal/infra/database/memory"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// recordingPublisher guarda os eventos publicados para inspeção nos testes
+type recordingPublisher struct {
+	events []event.Event
+	err    error
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, e event.Event) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.events = append(p.events, e)
+	return nil
+}
+
+func TestCreateAuctionUseCaseExecute(t *testing.T) {
+	repository := memory.NewAuctionRepository()
+	publisher := &recordingPublisher{}
+	useCase := NewCreateAuctionUseCase(repository, publisher)
+	ctx := context.Background()
+
+	input := AuctionInputDTO{
+		ProductName: "Notebook",
+		Category:    "Electronics",
+		Description: "Notebook Dell Inspiron 15",
+		Condition:   1,
+	}
+	if err := useCase.Execute(ctx, input); err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+
+	if len(publisher.events) != 1 {
+		t.Fatalf("Expected one event to be published, got %d", len(publisher.events))
+	}
+	published := publisher.events[0]
+	if published.Name != AuctionCreatedEvent {
+		t.Errorf("Expected %s event, got %s", AuctionCreatedEvent, published.Name)
+	}
+
+	payload, ok := published.Payload.(AuctionCreatedPayload)
+	if !ok {
+		t.Fatalf("Expected AuctionCreatedPayload, got %T", published.Payload)
+	}
+
+	stored, err := repository.FindAuctionById(ctx, payload.AuctionId)
+	if err != nil {
+		t.Fatalf("Expected the auction from the event to be persisted: %v", err)
+	}
+	if stored.ProductName != "Notebook" || stored.Condition != auction_entity.New {
+		t.Errorf("Unexpected persisted auction: %+v", stored)
+	}
+}
+
+func TestCreateAuctionUseCaseExecutePropagatesValidationError(t *testing.T) {
+	repository := memory.NewAuctionRepository()
+	publisher := &recordingPublisher{}
+	useCase := NewCreateAuctionUseCase(repository, publisher)
+	ctx := context.Background()
+
+	// Condição fora do intervalo válido é rejeitada pelo construtor da entidade
+	input := AuctionInputDTO{
+		ProductName: "Notebook",
+		Category:    "Electronics",
+		Description: "Notebook Dell Inspiron 15",
+		Condition:   9,
+	}
+	err := useCase.Execute(ctx, input)
+	if err == nil || err.Err != "bad_request" {
+		t.Fatalf("Expected bad_request from entity validation, got %v", err)
+	}
+
+	if len(publisher.events) != 0 {
+		t.Errorf("Expected no event for an invalid auction, got %d", len(publisher.events))
+	}
+	if auctions, _, _ := repository.FindAuctionsPage(
+		ctx, auction_entity.AnyStatus, "", "", 0, 10, ""); len(auctions) != 0 {
+		t.Errorf("Expected no auction to be persisted, got %d", len(auctions))
+	}
+}
+
+func TestCreateAuctionUseCaseExecutePublishError(t *testing.T) {
+	publisher := &recordingPublisher{err: errors.New("broker unavailable")}
+	repository := memory.NewAuctionRepository()
+	useCase := NewCreateAuctionUseCase(repository, publisher)
+	ctx := context.Background()
+
+	input := AuctionInputDTO{
+		ProductName: "Notebook",
+		Category:    "Electronics",
+		Description: "Notebook Dell Inspiron 15",
+		Condition:   1,
+	}
+	if err := useCase.Execute(ctx, input); err != nil {
+		t.Fatalf("Expected publish failures not to fail the creation, got %v", err)
+	}
+
+	// Um retry do cliente duplicaria o leilão, então a criação precisa ter valido
+	if auctions, _, _ := repository.FindAuctionsPage(
+		ctx, auction_entity.AnyStatus, "", "", 0, 10, ""); len(auctions) != 1 {
+		t.Errorf("Expected the auction to be persisted once, got %d", len(auctions))
+	}
+}
+
+func TestCreateAuctionUseCaseEnforcesActiveLimitPerOwner(t *testing.T) {
+	ownerId, otherOwnerId := uuid.New().String(), uuid.New().String()
+	repository := memory.NewAuctionRepository(
+		auction_entity.Auction{Id: "completed", OwnerId: ownerId, Status: auction_entity.Completed},
+		auction_entity.Auction{Id: "active", OwnerId: ownerId, Status: auction_entity.Active},
+	)
+	useCase := NewCreateAuctionUseCase(repository, &recordingPublisher{})
+	useCase.maxActivePerUser = 2
+	ctx := context.Background()
+
+	input := AuctionInputDTO{
+		ProductName: "Notebook",
+		Category:    "Electronics",
+		Description: "Notebook Dell Inspiron 15",
+		OwnerId:     ownerId,
+	}
+	if err := useCase.Execute(ctx, input); err != nil {
+		t.Fatalf("Expected creation under the limit to succeed, got %v", err)
+	}
+
+	if err := useCase.Execute(ctx, input); err == nil || err.Err != "limit_exceeded" {
+		t.Fatalf("Expected limit_exceeded at the limit, got %v", err)
+	}
+
+	input.OwnerId = otherOwnerId
+	if err := useCase.Execute(ctx, input); err != nil {
+		t.Errorf("Expected another owner to be unaffected, got %v", err)
+	}
+
+	input.OwnerId = ""
+	if err := useCase.Execute(ctx, input); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for an auction without owner, got %v", err)
+	}
+}
+
+func TestCreateAuctionUseCaseCountsScheduledAuctionsInActiveLimit(t *testing.T) {
+	ownerId := uuid.New().String()
+	repository := memory.NewAuctionRepository(
+		auction_entity.Auction{Id: "scheduled", OwnerId: ownerId, Status: auction_entity.Scheduled},
+	)
+	useCase := NewCreateAuctionUseCase(repository, &recordingPublisher{})
+	useCase.maxActivePerUser = 1
+
+	input := AuctionInputDTO{
+		ProductName: "Notebook",
+		Category:    "Electronics",
+		Description: "Notebook Dell Inspiron 15",
+		OwnerId:     ownerId,
+	}
+	if err := useCase.Execute(context.Background(), input); err == nil || err.Err != "limit_exceeded" {
+		t.Fatalf("Expected scheduled auctions to count towards the limit, got %v", err)
+	}
+}
+
+func TestCreateAuctionUseCaseWithoutActiveLimit(t *testing.T) {
+	ownerId := uuid.New().String()
+	repository := memory.NewAuctionRepository()
+	useCase := NewCreateAuctionUseCase(repository, &recordingPublisher{})
+	ctx := context.Background()
+
+	input := AuctionInputDTO{
+		ProductName: "Notebook",
+		Category:    "Electronics",
+		Description: "Notebook Dell Inspiron 15",
+		OwnerId:     ownerId,
+	}
+	for i := 0; i < 3; i++ {
+		if err := useCase.Execute(ctx, input); err != nil {
+			t.Fatalf("Expected no limit by default, got %v", err)
+		}
+	}
+}
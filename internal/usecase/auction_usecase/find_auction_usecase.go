@@ -2,93 +2,210 @@ package auction_usecase
 
 import (
 	"context"
+	"fmt"
 	"fullcycle-auction_go/configuration/logger"
 	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"fullcycle-auction_go/internal/usecase/bid_usecase"
+	"time"
 )
 
-func (au *AuctionUseCase) FindAuctionById(
-	ctx context.Context, id string) (*AuctionOutputDTO, *internal_error.InternalError) {
+// findVisibleAuction busca o leilão e responde not_found quando viewerId não pode
+// vê-lo, para não revelar a existência de um leilão privado
+func (au *AuctionUseCase) findVisibleAuction(
+	ctx context.Context, id, viewerId string) (*auction_entity.Auction, *internal_error.InternalError) {
 	auctionEntity, err := au.auctionRepositoryInterface.FindAuctionById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return &AuctionOutputDTO{
-		Id:          auctionEntity.Id,
-		ProductName: auctionEntity.ProductName,
-		Category:    auctionEntity.Category,
-		Description: auctionEntity.Description,
-		Condition:   ProductCondition(auctionEntity.Condition),
-		Status:      AuctionStatus(auctionEntity.Status),
-		Timestamp:   auctionEntity.Timestamp,
+	if !auctionEntity.VisibleTo(viewerId) {
+		return nil, internal_error.NewNotFoundError(fmt.Sprintf("Auction not found with this id = %s", id))
+	}
+
+	return auctionEntity, nil
+}
+
+func (au *AuctionUseCase) FindAuctionById(
+	ctx context.Context, id, viewerId string) (*AuctionOutputDTO, *internal_error.InternalError) {
+	auctionEntity, err := au.findVisibleAuction(ctx, id, viewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	auctionOutput := NewAuctionOutputDTO(*auctionEntity, viewerId)
+	return &auctionOutput, nil
+}
+
+func (au *AuctionUseCase) FindAuctionStatus(
+	ctx context.Context, id, viewerId string) (*AuctionStatusOutputDTO, *internal_error.InternalError) {
+	if _, err := au.findVisibleAuction(ctx, id, viewerId); err != nil {
+		return nil, err
+	}
+
+	statusView, err := au.auctionRepositoryInterface.FindAuctionStatus(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &AuctionStatusOutputDTO{
+		Status:         statusView.Status.String(),
+		CurrentHighBid: statusView.CurrentHighBid,
+		BidCount:       statusView.BidCount,
+	}
+	if !statusView.EndsAt.IsZero() {
+		endsAt := statusView.EndsAt.UTC()
+		output.EndsAt = &endsAt
+	}
+
+	return output, nil
+}
+
+// FindRemainingTime arredonda o tempo restante para cima, para que zero só apareça
+// quando o leilão de fato terminou
+func (au *AuctionUseCase) FindRemainingTime(
+	ctx context.Context, id, viewerId string) (*AuctionRemainingOutputDTO, *internal_error.InternalError) {
+	if _, err := au.findVisibleAuction(ctx, id, viewerId); err != nil {
+		return nil, err
+	}
+
+	remaining, err := au.auctionRepositoryInterface.RemainingTime(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if remaining > 0 {
+		return &AuctionRemainingOutputDTO{
+			RemainingSeconds: int64((remaining + time.Second - 1) / time.Second),
+		}, nil
+	}
+
+	statusView, err := au.auctionRepositoryInterface.FindAuctionStatus(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuctionRemainingOutputDTO{
+		Completed: statusView.Status == auction_entity.Completed,
 	}, nil
 }
 
+// FindAuctions lista os leilões visíveis para viewerId: os públicos e os privados
+// em que ele é dono ou convidado
 func (au *AuctionUseCase) FindAuctions(
 	ctx context.Context,
 	status AuctionStatus,
-	category, productName string) ([]AuctionOutputDTO, *internal_error.InternalError) {
+	category, productName, sort string,
+	viewerId string) ([]AuctionOutputDTO, *internal_error.InternalError) {
+	sortOrder, err := auction_entity.ParseAuctionSortOrder(sort)
+	if err != nil {
+		return nil, err
+	}
+
 	auctionEntities, err := au.auctionRepositoryInterface.FindAuctions(
-		ctx, auction_entity.AuctionStatus(status), category, productName)
+		ctx, auction_entity.AuctionStatus(status), category, productName, sortOrder, viewerId)
 	if err != nil {
 		return nil, err
 	}
 
 	var auctionOutputs []AuctionOutputDTO
 	for _, value := range auctionEntities {
-		auctionOutputs = append(auctionOutputs, AuctionOutputDTO{
-			Id:          value.Id,
-			ProductName: value.ProductName,
-			Category:    value.Category,
-			Description: value.Description,
-			Condition:   ProductCondition(value.Condition),
-			Status:      AuctionStatus(value.Status),
-			Timestamp:   value.Timestamp,
-		})
+		auctionOutputs = append(auctionOutputs, NewAuctionOutputDTO(value, viewerId))
 	}
 
 	return auctionOutputs, nil
 }
 
+// ListAuctions retorna uma página dos leilões visíveis para viewerId, dos mais recentes
+// para os mais antigos. Um status vazio lista leilões de qualquer status
+func (au *AuctionUseCase) ListAuctions(
+	ctx context.Context,
+	status, category, productName string,
+	page, limit int,
+	viewerId string) (*AuctionListOutputDTO, *internal_error.InternalError) {
+	if page < 1 || limit < 1 {
+		return nil, internal_error.NewBadRequestError("page and limit must be greater than zero")
+	}
+
+	auctionStatus := auction_entity.AnyStatus
+	if status != "" {
+		parsed, err := auction_entity.ParseAuctionStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		auctionStatus = parsed
+	}
+
+	auctionEntities, total, err := au.auctionRepositoryInterface.FindAuctionsPage(
+		ctx, auctionStatus, category, productName, (page-1)*limit, limit, viewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	auctionOutputs := make([]AuctionOutputDTO, 0, len(auctionEntities))
+	for _, value := range auctionEntities {
+		auctionOutputs = append(auctionOutputs, NewAuctionOutputDTO(value, viewerId))
+	}
+
+	return &AuctionListOutputDTO{
+		Data: auctionOutputs,
+		Pagination: PaginationOutputDTO{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		},
+	}, nil
+}
+
+// FindWinningBidByAuctionId retorna o leilão com o lance vencedor e, quando runnerUps
+// é positivo, os maiores lances de até runnerUps outros usuários
 func (au *AuctionUseCase) FindWinningBidByAuctionId(
 	ctx context.Context,
-	auctionId string) (*WinningInfoOutputDTO, *internal_error.InternalError) {
-	auction, err := au.auctionRepositoryInterface.FindAuctionById(ctx, auctionId)
+	auctionId string,
+	runnerUps int,
+	viewerId string) (*WinningInfoOutputDTO, *internal_error.InternalError) {
+	auction, err := au.findVisibleAuction(ctx, auctionId, viewerId)
 	if err != nil {
 		return nil, err
 	}
 
-	auctionOutputDTO := AuctionOutputDTO{
-		Id:          auction.Id,
-		ProductName: auction.ProductName,
-		Category:    auction.Category,
-		Description: auction.Description,
-		Condition:   ProductCondition(auction.Condition),
-		Status:      AuctionStatus(auction.Status),
-		Timestamp:   auction.Timestamp,
+	winningInfo := &WinningInfoOutputDTO{
+		Auction: NewAuctionOutputDTO(*auction, viewerId),
 	}
 
-	bidWinning, err := au.bidRepositoryInterface.FindWinningBidByAuctionId(ctx, auction.Id)
+	topBids, err := au.bidRepositoryInterface.FindTopBids(
+		ctx, auction.Id, runnerUps+1, bid_entity.WithDistinctUsers())
 	if err != nil {
 		logger.Error("", err)
-		return &WinningInfoOutputDTO{
-			Auction: auctionOutputDTO,
-			Bid:     nil,
-		}, nil
+		return winningInfo, nil
 	}
 
-	bidOutputDTO := &bid_usecase.BidOutputDTO{
-		Id:        bidWinning.Id,
-		UserId:    bidWinning.UserId,
-		AuctionId: bidWinning.AuctionId,
-		Amount:    bidWinning.Amount,
-		Timestamp: bidWinning.Timestamp,
+	for i, bid := range topBids {
+		bidOutputDTO := bid_usecase.NewBidOutputDTO(bid)
+		if i == 0 {
+			winningInfo.Bid = &bidOutputDTO
+			continue
+		}
+		winningInfo.RunnerUps = append(winningInfo.RunnerUps, bidOutputDTO)
 	}
 
-	return &WinningInfoOutputDTO{
-		Auction: auctionOutputDTO,
-		Bid:     bidOutputDTO,
-	}, nil
+	return winningInfo, nil
+}
+
+// ListCategories retorna as categorias existentes, para filtros de busca
+func (au *AuctionUseCase) ListCategories(ctx context.Context) ([]string, *internal_error.InternalError) {
+	return au.auctionRepositoryInterface.ListCategories(ctx)
+}
+
+// CountActiveAuctions conta os leilões ativos sem carregá-los, para contadores da home
+func (au *AuctionUseCase) CountActiveAuctions(
+	ctx context.Context) (*AuctionCountOutputDTO, *internal_error.InternalError) {
+	count, err := au.auctionRepositoryInterface.CountAuctions(ctx, auction_entity.Active)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuctionCountOutputDTO{Count: count}, nil
 }
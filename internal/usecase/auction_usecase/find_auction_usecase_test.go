@@ -0,0 +1,226 @@
+package auction_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/database/memory"
+	"fullcycle-auction_go/internal/internal_error"
+	"testing"
+	"time"
+)
+
+func TestAuctionUseCaseWithInMemoryRepository(t *testing.T) {
+	auctionRepository := memory.NewAuctionRepository()
+	useCase := NewAuctionUseCase(auctionRepository, nil)
+	ctx := context.Background()
+
+	inputs := []AuctionInputDTO{
+		{ProductName: "Notebook", Category: "Electronics", Description: "Notebook Dell Inspiron 15", Condition: 1},
+		{ProductName: "Guitar", Category: "Music", Description: "Fender Stratocaster 2010", Condition: 2},
+	}
+	for _, input := range inputs {
+		if err := useCase.CreateAuction(ctx, input); err != nil {
+			t.Fatalf("Failed to create auction: %v", err)
+		}
+	}
+
+	auctions, err := useCase.FindAuctions(ctx, 0, "Electronics", "", "", "")
+	if err != nil {
+		t.Fatalf("Failed to find auctions: %v", err)
+	}
+	if len(auctions) != 1 || auctions[0].ProductName != "Notebook" {
+		t.Fatalf("Expected only the Electronics auction, got %+v", auctions)
+	}
+
+	found, err := useCase.FindAuctionById(ctx, auctions[0].Id, "")
+	if err != nil {
+		t.Fatalf("Failed to find auction by id: %v", err)
+	}
+	if found.Status != "Active" || found.Condition != "New" {
+		t.Errorf("Unexpected auction status/condition: %s/%s", found.Status, found.Condition)
+	}
+
+	if err := auctionRepository.CloseAuction(ctx, found.Id); err != nil {
+		t.Fatalf("Failed to close auction: %v", err)
+	}
+
+	closed, _ := useCase.FindAuctionById(ctx, found.Id, "")
+	if closed.Status != "Completed" {
+		t.Errorf("Expected auction to be Completed, got %s", closed.Status)
+	}
+
+	if _, err := useCase.FindAuctionById(ctx, "missing", ""); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for a missing auction, got %v", err)
+	}
+}
+
+func TestFindAuctionsSortedByCurrentHighBid(t *testing.T) {
+	useCase := NewAuctionUseCase(memory.NewAuctionRepository(
+		auction_entity.Auction{Id: "a", ProductName: "Notebook", CurrentHighBid: 300},
+		auction_entity.Auction{Id: "b", ProductName: "Guitar", CurrentHighBid: 100},
+		auction_entity.Auction{Id: "c", ProductName: "Camera", CurrentHighBid: 200},
+	), nil)
+	ctx := context.Background()
+
+	auctions, err := useCase.FindAuctions(ctx, 0, "", "", "-current_high_bid", "")
+	if err != nil {
+		t.Fatalf("Failed to find auctions: %v", err)
+	}
+	for i, id := range []string{"a", "c", "b"} {
+		if auctions[i].Id != id {
+			t.Errorf("Expected auction %s at position %d, got %s", id, i, auctions[i].Id)
+		}
+	}
+
+	if _, err := useCase.FindAuctions(ctx, 0, "", "", "price", ""); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for an unknown sort order, got %v", err)
+	}
+}
+
+// topBidsRepository devolve lances fixos em FindTopBids e registra o n pedido
+type topBidsRepository struct {
+	bid_entity.BidEntityRepository
+	bids      []bid_entity.Bid
+	requested int
+}
+
+func (r *topBidsRepository) FindTopBids(
+	ctx context.Context,
+	auctionId string,
+	n int,
+	opts ...bid_entity.TopBidsOption) ([]bid_entity.Bid, *internal_error.InternalError) {
+	r.requested = n
+	if n > len(r.bids) {
+		n = len(r.bids)
+	}
+	return r.bids[:n], nil
+}
+
+func TestFindWinningBidWithRunnerUps(t *testing.T) {
+	auction := auction_entity.Auction{Id: "a", ProductName: "Notebook"}
+	bids := &topBidsRepository{bids: []bid_entity.Bid{
+		{Id: "b1", UserId: "alice", AuctionId: "a", Amount: 400},
+		{Id: "b2", UserId: "bob", AuctionId: "a", Amount: 300},
+	}}
+	useCase := NewAuctionUseCase(memory.NewAuctionRepository(auction), bids)
+	ctx := context.Background()
+
+	winning, err := useCase.FindWinningBidByAuctionId(ctx, "a", 0, "")
+	if err != nil {
+		t.Fatalf("Failed to find winning bid: %v", err)
+	}
+	if winning.Bid == nil || winning.Bid.Id != "b1" || len(winning.RunnerUps) != 0 {
+		t.Errorf("Expected only the winner, got %+v", winning)
+	}
+
+	winning, _ = useCase.FindWinningBidByAuctionId(ctx, "a", 5, "")
+	if bids.requested != 6 {
+		t.Errorf("Expected the winner plus 5 runner-ups to be requested, got %d", bids.requested)
+	}
+	if winning.Bid.Id != "b1" || len(winning.RunnerUps) != 1 || winning.RunnerUps[0].Id != "b2" {
+		t.Errorf("Expected b2 as the only runner-up, got %+v", winning)
+	}
+
+	bids.bids = nil
+	winning, _ = useCase.FindWinningBidByAuctionId(ctx, "a", 5, "")
+	if winning.Bid != nil || len(winning.RunnerUps) != 0 {
+		t.Errorf("Expected no winner without bids, got %+v", winning)
+	}
+}
+
+func TestFindAuctionsHidesPrivateAuctionsFromUninvitedUsers(t *testing.T) {
+	useCase := NewAuctionUseCase(memory.NewAuctionRepository(
+		auction_entity.Auction{Id: "public", ProductName: "Notebook", Visibility: auction_entity.Public},
+		auction_entity.Auction{
+			Id:             "private",
+			ProductName:    "Guitar",
+			OwnerId:        "owner",
+			Visibility:     auction_entity.Private,
+			AllowedUserIds: []string{"invited"},
+		},
+	), nil)
+	ctx := context.Background()
+
+	for viewerId, expected := range map[string]int{"": 1, "stranger": 1, "invited": 2, "owner": 2} {
+		auctions, err := useCase.FindAuctions(ctx, 0, "", "", "", viewerId)
+		if err != nil {
+			t.Fatalf("Failed to find auctions: %v", err)
+		}
+		if len(auctions) != expected {
+			t.Errorf("Expected %d auctions for viewer %q, got %+v", expected, viewerId, auctions)
+		}
+	}
+}
+
+func TestFindRemainingTime(t *testing.T) {
+	now := time.Now()
+	useCase := NewAuctionUseCase(memory.NewAuctionRepository(
+		auction_entity.Auction{Id: "active", Status: auction_entity.Active, EndsAt: now.Add(90 * time.Second)},
+		auction_entity.Auction{Id: "ended", Status: auction_entity.Active, EndsAt: now.Add(-time.Second)},
+		auction_entity.Auction{Id: "completed", Status: auction_entity.Completed, EndsAt: now.Add(-time.Second)},
+	), nil)
+	ctx := context.Background()
+
+	active, err := useCase.FindRemainingTime(ctx, "active", "")
+	if err != nil {
+		t.Fatalf("Failed to find remaining time: %v", err)
+	}
+	if active.RemainingSeconds != 90 || active.Completed {
+		t.Errorf("Expected 90 seconds remaining, got %+v", active)
+	}
+
+	ended, _ := useCase.FindRemainingTime(ctx, "ended", "")
+	if ended.RemainingSeconds != 0 || ended.Completed {
+		t.Errorf("Expected an ended auction awaiting the sweep, got %+v", ended)
+	}
+
+	completed, _ := useCase.FindRemainingTime(ctx, "completed", "")
+	if completed.RemainingSeconds != 0 || !completed.Completed {
+		t.Errorf("Expected a completed auction with zero remaining, got %+v", completed)
+	}
+
+	if _, err := useCase.FindRemainingTime(ctx, "missing", ""); err == nil || err.Err != "not_found" {
+		t.Errorf("Expected not_found for a missing auction, got %v", err)
+	}
+}
+
+func TestFindByIdHidesPrivateAuctionsFromUninvitedUsers(t *testing.T) {
+	useCase := NewAuctionUseCase(memory.NewAuctionRepository(auction_entity.Auction{
+		Id:             "private",
+		ProductName:    "Guitar",
+		OwnerId:        "owner",
+		Status:         auction_entity.Active,
+		Visibility:     auction_entity.Private,
+		AllowedUserIds: []string{"invited"},
+	}), &topBidsRepository{})
+	ctx := context.Background()
+
+	for _, viewerId := range []string{"", "stranger"} {
+		if _, err := useCase.FindAuctionById(ctx, "private", viewerId); err == nil || err.Err != "not_found" {
+			t.Errorf("Expected not_found for viewer %q, got %v", viewerId, err)
+		}
+		if _, err := useCase.FindAuctionStatus(ctx, "private", viewerId); err == nil || err.Err != "not_found" {
+			t.Errorf("Expected not_found status for viewer %q, got %v", viewerId, err)
+		}
+		if _, err := useCase.FindRemainingTime(ctx, "private", viewerId); err == nil || err.Err != "not_found" {
+			t.Errorf("Expected not_found remaining time for viewer %q, got %v", viewerId, err)
+		}
+		if _, err := useCase.FindWinningBidByAuctionId(ctx, "private", 0, viewerId); err == nil || err.Err != "not_found" {
+			t.Errorf("Expected not_found winner for viewer %q, got %v", viewerId, err)
+		}
+	}
+
+	invited, err := useCase.FindAuctionById(ctx, "private", "invited")
+	if err != nil {
+		t.Fatalf("Failed to find auction for an invited user: %v", err)
+	}
+	if len(invited.AllowedUserIds) != 0 {
+		t.Errorf("Expected the guest list to stay hidden from guests, got %v", invited.AllowedUserIds)
+	}
+
+	owner, _ := useCase.FindAuctionById(ctx, "private", "owner")
+	if len(owner.AllowedUserIds) != 1 || owner.AllowedUserIds[0] != "invited" {
+		t.Errorf("Expected the owner to see the guest list, got %v", owner.AllowedUserIds)
+	}
+}
@@ -2,26 +2,60 @@ package bid_usecase
 
 import (
 	"context"
+	"fmt"
 	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
 	"fullcycle-auction_go/internal/entity/bid_entity"
 	"fullcycle-auction_go/internal/internal_error"
 	"os"
 	"strconv"
 	"time"
+
+	"go.uber.org/zap"
 )
 
 type BidInputDTO struct {
-	UserId    string  `json:"user_id"`
-	AuctionId string  `json:"auction_id"`
-	Amount    float64 `json:"amount"`
+	UserId    string               `json:"user_id"`
+	AuctionId string               `json:"auction_id"`
+	Amount    auction_entity.Money `json:"amount"`
+	Currency  string               `json:"currency"`
+	RequestId string               `json:"request_id"`
+
+	// MaxAmount, quando informado, é o lance máximo que o sistema pode dar pelo
+	// usuário, subindo só o necessário para mantê-lo na frente
+	MaxAmount auction_entity.Money `json:"max_amount"`
 }
 
 type BidOutputDTO struct {
-	Id        string    `json:"id"`
-	UserId    string    `json:"user_id"`
-	AuctionId string    `json:"auction_id"`
-	Amount    float64   `json:"amount"`
-	Timestamp time.Time `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+	Id        string               `json:"id"`
+	UserId    string               `json:"user_id"`
+	AuctionId string               `json:"auction_id"`
+	Amount    auction_entity.Money `json:"amount"`
+	Currency  string               `json:"currency"`
+	Timestamp time.Time            `json:"timestamp" time_format:"2006-01-02 15:04:05"`
+}
+
+func NewBidOutputDTO(bid bid_entity.Bid) BidOutputDTO {
+	return BidOutputDTO{
+		Id:        bid.Id,
+		UserId:    bid.UserId,
+		AuctionId: bid.AuctionId,
+		Amount:    bid.Amount,
+		Currency:  bid.Currency,
+		Timestamp: bid.Timestamp,
+	}
+}
+
+type PaginationOutputDTO struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+type BidListOutputDTO struct {
+	Data       []BidOutputDTO      `json:"data"`
+	Pagination PaginationOutputDTO `json:"pagination"`
 }
 
 type BidUseCase struct {
@@ -31,9 +65,20 @@ type BidUseCase struct {
 	maxBatchSize        int
 	batchInsertInterval time.Duration
 	bidChannel          chan bid_entity.Bid
+
+	rateLimiter       RateLimiter
+	auctionRepository auction_entity.AuctionRepositoryInterface
+
+	proxyBookRepository bid_entity.ProxyBookRepository
+	proxyIncrement      auction_entity.Money
+
+	maxAmount      auction_entity.Money
+	maxBidMultiple float64
 }
 
-func NewBidUseCase(bidRepository bid_entity.BidEntityRepository) BidUseCaseInterface {
+func NewBidUseCase(
+	bidRepository bid_entity.BidEntityRepository,
+	opts ...BidUseCaseOption) BidUseCaseInterface {
 	maxSizeInterval := getMaxBatchSizeInterval()
 	maxBatchSize := getMaxBatchSize()
 
@@ -43,6 +88,13 @@ func NewBidUseCase(bidRepository bid_entity.BidEntityRepository) BidUseCaseInter
 		batchInsertInterval: maxSizeInterval,
 		timer:               time.NewTimer(maxSizeInterval),
 		bidChannel:          make(chan bid_entity.Bid, maxBatchSize),
+		proxyIncrement:      getProxyIncrement(),
+		maxAmount:           getMaxAmount(),
+		maxBidMultiple:      getMaxBidMultiple(),
+	}
+
+	for _, opt := range opts {
+		opt(bidUseCase)
 	}
 
 	bidUseCase.triggerCreateRoutine(context.Background())
@@ -60,8 +112,22 @@ type BidUseCaseInterface interface {
 	FindWinningBidByAuctionId(
 		ctx context.Context, auctionId string) (*BidOutputDTO, *internal_error.InternalError)
 
+	// As buscas dos lances de um leilão respondem not_found para leilões privados que
+	// viewerId não pode ver
 	FindBidByAuctionId(
-		ctx context.Context, auctionId string) ([]BidOutputDTO, *internal_error.InternalError)
+		ctx context.Context, auctionId, viewerId string) ([]BidOutputDTO, *internal_error.InternalError)
+
+	FindBidsPageByAuctionId(
+		ctx context.Context,
+		auctionId string,
+		page, limit int,
+		viewerId string) (*BidListOutputDTO, *internal_error.InternalError)
+
+	FindProxyBid(
+		ctx context.Context, auctionId, userId string) (*ProxyBidOutputDTO, *internal_error.InternalError)
+
+	WithdrawBid(
+		ctx context.Context, bidId, userId string) *internal_error.InternalError
 }
 
 func (bu *BidUseCase) triggerCreateRoutine(ctx context.Context) {
@@ -95,6 +161,7 @@ func (bu *BidUseCase) triggerCreateRoutine(ctx context.Context) {
 					logger.Error("error trying to process bid batch list", err)
 				}
 				bidBatch = nil
+				bu.evictClosedProxyBooks(ctx)
 				bu.timer.Reset(bu.batchInsertInterval)
 			}
 		}
@@ -105,16 +172,91 @@ func (bu *BidUseCase) CreateBid(
 	ctx context.Context,
 	bidInputDTO BidInputDTO) *internal_error.InternalError {
 
-	bidEntity, err := bid_entity.CreateBid(bidInputDTO.UserId, bidInputDTO.AuctionId, bidInputDTO.Amount)
+	if err := bu.checkRateLimit(ctx, bidInputDTO.UserId); err != nil {
+		return err
+	}
+
+	bidEntity, err := bid_entity.CreateBid(bidInputDTO.UserId, bidInputDTO.AuctionId, bidInputDTO.Amount,
+		bid_entity.WithCurrency(bidInputDTO.Currency),
+		bid_entity.WithRequestId(bidInputDTO.RequestId))
+	if err != nil {
+		return err
+	}
+
+	if err := bidEntity.CheckMaxAmount(bu.maxAmount); err != nil {
+		return err
+	}
+
+	if err := bu.checkAuctionRules(ctx, bidEntity); err != nil {
+		return err
+	}
+
+	bids, err := bu.placeProxyBids(ctx, bidEntity, bidInputDTO.MaxAmount)
 	if err != nil {
 		return err
 	}
 
-	bu.bidChannel <- *bidEntity
+	for _, bid := range bids {
+		bu.bidChannel <- bid
+	}
 
 	return nil
 }
 
+// checkRateLimit rejeita o lance quando o usuário excedeu o limite. Falhas do
+// limitador não bloqueiam lances: são registradas e o lance segue
+func (bu *BidUseCase) checkRateLimit(
+	ctx context.Context, userId string) *internal_error.InternalError {
+	if bu.rateLimiter == nil {
+		return nil
+	}
+
+	allowed, err := bu.rateLimiter.Allow(ctx, userId)
+	if err != nil {
+		logger.ErrorContext(ctx, "Error trying to check bid rate limit", err,
+			zap.String("user_id", userId))
+		return nil
+	}
+
+	if !allowed {
+		return internal_error.NewTooManyRequestsError(
+			fmt.Sprintf("Too many bids from user %s, try again later", userId))
+	}
+
+	return nil
+}
+
+// checkAuctionRules rejeita de imediato lances de usuários não convidados para leilões
+// privados, em moeda diferente da do leilão, abaixo do lance mínimo ou muito acima do
+// maior lance. Sem um repositório de leilões configurado, a verificação fica a cargo
+// do repositório de lances
+func (bu *BidUseCase) checkAuctionRules(
+	ctx context.Context, bidEntity *bid_entity.Bid) *internal_error.InternalError {
+	if bu.auctionRepository == nil {
+		return nil
+	}
+
+	auction, err := bu.auctionRepository.FindAuctionByIdUncached(ctx, bidEntity.AuctionId)
+	if err != nil {
+		return err
+	}
+
+	if !auction.VisibleTo(bidEntity.UserId) {
+		return internal_error.NewForbiddenError(fmt.Sprintf(
+			"User %s is not invited to auction %s", bidEntity.UserId, bidEntity.AuctionId))
+	}
+
+	if err := bidEntity.ResolveCurrency(auction.Currency); err != nil {
+		return err
+	}
+
+	if err := bidEntity.CheckStartingBid(auction.StartingBid); err != nil {
+		return err
+	}
+
+	return bidEntity.CheckHighBidMultiple(auction.CurrentHighBid, bu.maxBidMultiple)
+}
+
 func getMaxBatchSizeInterval() time.Duration {
 	batchInsertInterval := os.Getenv("BATCH_INSERT_INTERVAL")
 	duration, err := time.ParseDuration(batchInsertInterval)
@@ -0,0 +1,176 @@
+package bid_usecase
+
+import (
+	"context"
+	"errors"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/database/memory"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type stubRateLimiter struct {
+	allowed bool
+	err     error
+}
+
+func (s stubRateLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	return s.allowed, s.err
+}
+
+func TestCheckRateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	blocked := &BidUseCase{rateLimiter: stubRateLimiter{allowed: false}}
+	if err := blocked.checkRateLimit(ctx, "user-1"); err == nil || err.Err != "too_many_requests" {
+		t.Errorf("Expected too_many_requests, got %v", err)
+	}
+
+	allowed := &BidUseCase{rateLimiter: stubRateLimiter{allowed: true}}
+	if err := allowed.checkRateLimit(ctx, "user-1"); err != nil {
+		t.Errorf("Expected bid to be allowed, got %v", err)
+	}
+
+	failing := &BidUseCase{rateLimiter: stubRateLimiter{err: errors.New("limiter unavailable")}}
+	if err := failing.checkRateLimit(ctx, "user-1"); err != nil {
+		t.Errorf("Expected limiter failures not to block bids, got %v", err)
+	}
+
+	if err := (&BidUseCase{}).checkRateLimit(ctx, "user-1"); err != nil {
+		t.Errorf("Expected no limit without a rate limiter, got %v", err)
+	}
+}
+
+func TestResolveCurrency(t *testing.T) {
+	ctx := context.Background()
+	auctions := memory.NewAuctionRepository(auction_entity.Auction{
+		Id:       "auction-1",
+		Status:   auction_entity.Active,
+		Currency: "USD",
+	})
+	useCase := &BidUseCase{auctionRepository: auctions}
+
+	sameCurrency := &bid_entity.Bid{AuctionId: "auction-1", Amount: 100, Currency: "USD"}
+	if err := useCase.checkAuctionRules(ctx, sameCurrency); err != nil {
+		t.Errorf("Expected a same-currency bid to be accepted, got %v", err)
+	}
+
+	inherited := &bid_entity.Bid{AuctionId: "auction-1", Amount: 100}
+	if err := useCase.checkAuctionRules(ctx, inherited); err != nil || inherited.Currency != "USD" {
+		t.Errorf("Expected a bid without currency to inherit USD, got %q (%v)", inherited.Currency, err)
+	}
+
+	crossCurrency := &bid_entity.Bid{AuctionId: "auction-1", Amount: 100, Currency: "EUR"}
+	if err := useCase.checkAuctionRules(ctx, crossCurrency); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for a cross-currency bid, got %v", err)
+	}
+}
+
+func TestCheckAuctionRulesEnforcesStartingBid(t *testing.T) {
+	ctx := context.Background()
+	auctions := memory.NewAuctionRepository(auction_entity.Auction{
+		Id:          "auction-1",
+		Status:      auction_entity.Active,
+		Currency:    "BRL",
+		StartingBid: auction_entity.MoneyFromCents(5000),
+	})
+	useCase := &BidUseCase{auctionRepository: auctions}
+
+	below := &bid_entity.Bid{AuctionId: "auction-1", Amount: auction_entity.MoneyFromCents(4999)}
+	if err := useCase.checkAuctionRules(ctx, below); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for a first bid below the starting bid, got %v", err)
+	}
+
+	for _, amount := range []auction_entity.Money{5000, 7500} {
+		bid := &bid_entity.Bid{AuctionId: "auction-1", Amount: amount}
+		if err := useCase.checkAuctionRules(ctx, bid); err != nil {
+			t.Errorf("Expected a bid of %s to be accepted, got %v", amount, err)
+		}
+	}
+}
+
+func TestCheckAuctionRulesEnforcesHighBidMultiple(t *testing.T) {
+	ctx := context.Background()
+	auctions := memory.NewAuctionRepository(
+		auction_entity.Auction{
+			Id:             "auction-1",
+			Status:         auction_entity.Active,
+			Currency:       "BRL",
+			CurrentHighBid: auction_entity.MoneyFromCents(5000),
+		},
+		auction_entity.Auction{
+			Id:       "auction-2",
+			Status:   auction_entity.Active,
+			Currency: "BRL",
+		})
+	useCase := &BidUseCase{auctionRepository: auctions, maxBidMultiple: 10}
+
+	// Um zero a mais leva o lance muito acima do maior lance atual
+	fatFinger := &bid_entity.Bid{AuctionId: "auction-1", Amount: auction_entity.MoneyFromCents(550000)}
+	if err := useCase.checkAuctionRules(ctx, fatFinger); err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for a bid far above the high bid, got %v", err)
+	}
+
+	raise := &bid_entity.Bid{AuctionId: "auction-1", Amount: auction_entity.MoneyFromCents(50000)}
+	if err := useCase.checkAuctionRules(ctx, raise); err != nil {
+		t.Errorf("Expected a bid within the multiple to be accepted, got %v", err)
+	}
+
+	firstBid := &bid_entity.Bid{AuctionId: "auction-2", Amount: auction_entity.MoneyFromCents(550000)}
+	if err := useCase.checkAuctionRules(ctx, firstBid); err != nil {
+		t.Errorf("Expected the first bid not to be capped, got %v", err)
+	}
+}
+
+func TestCreateBidRejectsAmountAboveMaximum(t *testing.T) {
+	useCase := &BidUseCase{maxAmount: auction_entity.MoneyFromCents(100000)}
+
+	err := useCase.CreateBid(context.Background(), BidInputDTO{
+		UserId:    uuid.New().String(),
+		AuctionId: uuid.New().String(),
+		Amount:    auction_entity.MoneyFromCents(1000000),
+	})
+	if err == nil || err.Err != "bad_request" {
+		t.Errorf("Expected bad_request for a bid above BID_MAX_AMOUNT, got %v", err)
+	}
+}
+
+func TestCheckAuctionRulesRejectsUninvitedUsers(t *testing.T) {
+	ctx := context.Background()
+	auctions := memory.NewAuctionRepository(auction_entity.Auction{
+		Id:             "auction-1",
+		OwnerId:        "owner",
+		Status:         auction_entity.Active,
+		Currency:       "BRL",
+		Visibility:     auction_entity.Private,
+		AllowedUserIds: []string{"invited"},
+	})
+	useCase := &BidUseCase{auctionRepository: auctions}
+
+	uninvited := &bid_entity.Bid{UserId: "stranger", AuctionId: "auction-1", Amount: 1000}
+	if err := useCase.checkAuctionRules(ctx, uninvited); err == nil || err.Err != "forbidden" {
+		t.Errorf("Expected forbidden for an uninvited user, got %v", err)
+	}
+
+	invited := &bid_entity.Bid{UserId: "invited", AuctionId: "auction-1", Amount: 1000}
+	if err := useCase.checkAuctionRules(ctx, invited); err != nil {
+		t.Errorf("Expected the invited user's bid to be accepted, got %v", err)
+	}
+}
+
+func TestCreateBidReportsAllInvalidFields(t *testing.T) {
+	err := (&BidUseCase{}).CreateBid(context.Background(), BidInputDTO{
+		UserId:    "not-a-uuid",
+		AuctionId: "auction-1",
+		Amount:    100,
+	})
+	if err == nil || err.Err != "bad_request" || len(err.Fields) != 2 {
+		t.Fatalf("Expected bad_request with two invalid fields, got %+v", err)
+	}
+
+	if err.Fields[0].Field != "user_id" || err.Fields[1].Field != "auction_id" {
+		t.Errorf("Expected user_id and auction_id to be invalid, got %+v", err.Fields)
+	}
+}
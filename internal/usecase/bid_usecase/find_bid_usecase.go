@@ -2,11 +2,37 @@ package bid_usecase
 
 import (
 	"context"
+	"fmt"
 	"fullcycle-auction_go/internal/internal_error"
 )
 
+// checkAuctionVisible responde not_found quando viewerId não pode ver o leilão, para
+// que os lances de um leilão privado não revelem a sua existência. Sem um repositório
+// de leilões configurado, a verificação não é feita
+func (bu *BidUseCase) checkAuctionVisible(
+	ctx context.Context, auctionId, viewerId string) *internal_error.InternalError {
+	if bu.auctionRepository == nil {
+		return nil
+	}
+
+	auction, err := bu.auctionRepository.FindAuctionById(ctx, auctionId)
+	if err != nil {
+		return err
+	}
+
+	if !auction.VisibleTo(viewerId) {
+		return internal_error.NewNotFoundError(fmt.Sprintf("Auction not found with this id = %s", auctionId))
+	}
+
+	return nil
+}
+
 func (bu *BidUseCase) FindBidByAuctionId(
-	ctx context.Context, auctionId string) ([]BidOutputDTO, *internal_error.InternalError) {
+	ctx context.Context, auctionId, viewerId string) ([]BidOutputDTO, *internal_error.InternalError) {
+	if err := bu.checkAuctionVisible(ctx, auctionId, viewerId); err != nil {
+		return nil, err
+	}
+
 	bidList, err := bu.BidRepository.FindBidByAuctionId(ctx, auctionId)
 	if err != nil {
 		return nil, err
@@ -14,13 +40,7 @@ func (bu *BidUseCase) FindBidByAuctionId(
 
 	var bidOutputList []BidOutputDTO
 	for _, bid := range bidList {
-		bidOutputList = append(bidOutputList, BidOutputDTO{
-			Id:        bid.Id,
-			UserId:    bid.UserId,
-			AuctionId: bid.AuctionId,
-			Amount:    bid.Amount,
-			Timestamp: bid.Timestamp,
-		})
+		bidOutputList = append(bidOutputList, NewBidOutputDTO(bid))
 	}
 
 	return bidOutputList, nil
@@ -33,13 +53,43 @@ func (bu *BidUseCase) FindWinningBidByAuctionId(
 		return nil, err
 	}
 
-	bidOutput := &BidOutputDTO{
-		Id:        bidEntity.Id,
-		UserId:    bidEntity.UserId,
-		AuctionId: bidEntity.AuctionId,
-		Amount:    bidEntity.Amount,
-		Timestamp: bidEntity.Timestamp,
+	bidOutput := NewBidOutputDTO(*bidEntity)
+
+	return &bidOutput, nil
+}
+
+// FindBidsPageByAuctionId retorna uma página dos lances do leilão, do maior para o menor valor
+func (bu *BidUseCase) FindBidsPageByAuctionId(
+	ctx context.Context,
+	auctionId string,
+	page, limit int,
+	viewerId string) (*BidListOutputDTO, *internal_error.InternalError) {
+	if page < 1 || limit < 1 {
+		return nil, internal_error.NewBadRequestError("page and limit must be greater than zero")
+	}
+
+	if err := bu.checkAuctionVisible(ctx, auctionId, viewerId); err != nil {
+		return nil, err
+	}
+
+	bidList, total, err := bu.BidRepository.FindBidsPageByAuctionId(
+		ctx, auctionId, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	bidOutputList := make([]BidOutputDTO, 0, len(bidList))
+	for _, bid := range bidList {
+		bidOutputList = append(bidOutputList, NewBidOutputDTO(bid))
 	}
 
-	return bidOutput, nil
+	return &BidListOutputDTO{
+		Data: bidOutputList,
+		Pagination: PaginationOutputDTO{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		},
+	}, nil
 }
@@ -0,0 +1,35 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/infra/database/memory"
+	"testing"
+)
+
+func TestFindBidsHidesPrivateAuctionsFromUninvitedUsers(t *testing.T) {
+	useCase := &BidUseCase{auctionRepository: memory.NewAuctionRepository(auction_entity.Auction{
+		Id:             "private",
+		OwnerId:        "owner",
+		Status:         auction_entity.Active,
+		Visibility:     auction_entity.Private,
+		AllowedUserIds: []string{"invited"},
+	})}
+	ctx := context.Background()
+
+	for _, viewerId := range []string{"", "stranger"} {
+		if _, err := useCase.FindBidByAuctionId(ctx, "private", viewerId); err == nil || err.Err != "not_found" {
+			t.Errorf("Expected not_found for viewer %q, got %v", viewerId, err)
+		}
+		if _, err := useCase.FindBidsPageByAuctionId(ctx, "private", 1, 20, viewerId); err == nil || err.Err != "not_found" {
+			t.Errorf("Expected not_found page for viewer %q, got %v", viewerId, err)
+		}
+		if _, err := useCase.FindProxyBid(ctx, "private", viewerId); err == nil || err.Err != "not_found" {
+			t.Errorf("Expected not_found proxy view for viewer %q, got %v", viewerId, err)
+		}
+	}
+
+	if _, err := useCase.FindProxyBid(ctx, "private", "invited"); err != nil {
+		t.Errorf("Expected an invited user to see the auction, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package bid_usecase
+
+import (
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"os"
+	"strconv"
+)
+
+// WithMaxAmount define o maior valor aceito para um lance. Zero desliga o limite
+func WithMaxAmount(maxAmount auction_entity.Money) BidUseCaseOption {
+	return func(bu *BidUseCase) {
+		bu.maxAmount = maxAmount
+	}
+}
+
+// WithMaxBidMultiple define quantas vezes o maior lance atual um novo lance pode valer.
+// Depende de WithAuctionRepository; zero desliga o limite
+func WithMaxBidMultiple(multiple float64) BidUseCaseOption {
+	return func(bu *BidUseCase) {
+		bu.maxBidMultiple = multiple
+	}
+}
+
+// getMaxAmount lê BID_MAX_AMOUNT (ex.: "100000.00"). O limite é opcional: sem a
+// variável, não há valor máximo
+func getMaxAmount() auction_entity.Money {
+	maxAmount, err := auction_entity.ParseMoney(os.Getenv("BID_MAX_AMOUNT"))
+	if err != nil || maxAmount <= 0 {
+		return 0
+	}
+
+	return maxAmount
+}
+
+// getMaxBidMultiple lê BID_MAX_HIGH_BID_MULTIPLE (ex.: "10"). O limite é opcional e
+// só vale para valores maiores que 1, já que os demais barrariam qualquer novo lance
+func getMaxBidMultiple() float64 {
+	multiple, err := strconv.ParseFloat(os.Getenv("BID_MAX_HIGH_BID_MULTIPLE"), 64)
+	if err != nil || multiple <= 1 {
+		return 0
+	}
+
+	return multiple
+}
@@ -0,0 +1,248 @@
+package bid_usecase
+
+import (
+	"context"
+	"fmt"
+	"fullcycle-auction_go/configuration/logger"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/internal_error"
+	"os"
+	"time"
+)
+
+// ProxyBidOutputDTO mostra o preço visível do leilão e quem lidera. MaxAmount só é
+// preenchido para o próprio dono do máximo, que continua oculto para os demais
+type ProxyBidOutputDTO struct {
+	AuctionId    string                `json:"auction_id"`
+	CurrentPrice auction_entity.Money  `json:"current_price"`
+	Winning      bool                  `json:"winning"`
+	MaxAmount    *auction_entity.Money `json:"max_amount,omitempty"`
+}
+
+// WithProxyBookRepository liga os lances máximos, guardando os livros no repositório
+func WithProxyBookRepository(proxyBookRepository bid_entity.ProxyBookRepository) BidUseCaseOption {
+	return func(bu *BidUseCase) {
+		bu.proxyBookRepository = proxyBookRepository
+	}
+}
+
+// WithProxyIncrement define quanto o lance automático supera o segundo maior máximo
+func WithProxyIncrement(increment auction_entity.Money) BidUseCaseOption {
+	return func(bu *BidUseCase) {
+		bu.proxyIncrement = increment
+	}
+}
+
+// getProxyIncrement lê BID_PROXY_INCREMENT (ex.: "1.00"). O padrão é 1.00
+func getProxyIncrement() auction_entity.Money {
+	increment, err := auction_entity.ParseMoney(os.Getenv("BID_PROXY_INCREMENT"))
+	if err != nil || increment <= 0 {
+		return auction_entity.MoneyFromCents(100)
+	}
+
+	return increment
+}
+
+// placeProxyBids passa o lance pelo livro de máximos do leilão e retorna os lances a
+// gravar. Enquanto ninguém registrou um máximo no leilão, o lance segue como veio. Os
+// livros ficam no repositório de livros, que serializa as atualizações de cada leilão,
+// então os máximos sobrevivem a reinícios e valem para todas as réplicas. Sem esse
+// repositório, lances com máximo são rejeitados. O máximo passa pelos mesmos limites
+// de um lance antes de entrar no livro e, como nenhum lance automático supera o máximo
+// do seu dono, os lances automáticos também respeitam BID_MAX_AMOUNT e
+// BID_MAX_HIGH_BID_MULTIPLE
+func (bu *BidUseCase) placeProxyBids(
+	ctx context.Context,
+	bidEntity *bid_entity.Bid,
+	maxAmount auction_entity.Money) ([]bid_entity.Bid, *internal_error.InternalError) {
+	if maxAmount != 0 && maxAmount < bidEntity.Amount {
+		return nil, invalidMaxAmount("MaxAmount must not be below the bid amount")
+	}
+
+	if bu.proxyBookRepository == nil {
+		if maxAmount > 0 {
+			return nil, invalidMaxAmount("Proxy bidding is not enabled")
+		}
+		return []bid_entity.Bid{*bidEntity}, nil
+	}
+
+	if maxAmount == 0 {
+		book, err := bu.proxyBookRepository.FindProxyBook(ctx, bidEntity.AuctionId)
+		if err != nil {
+			return nil, err
+		}
+		if book == nil {
+			return []bid_entity.Bid{*bidEntity}, nil
+		}
+	}
+
+	// O livro começa do maior lance atual
+	var highBid auction_entity.Money
+	if bu.auctionRepository != nil {
+		auction, err := bu.auctionRepository.FindAuctionByIdUncached(ctx, bidEntity.AuctionId)
+		if err != nil {
+			return nil, err
+		}
+		// O repositório de lances descarta o lance de um leilão encerrado, e o livro
+		// dele não tem mais uso
+		if biddable, _ := auction.Biddable(time.Now()); !biddable {
+			bu.evictProxyBook(ctx, bidEntity.AuctionId)
+			return []bid_entity.Bid{*bidEntity}, nil
+		}
+		highBid = auction.CurrentHighBid
+	}
+
+	if maxAmount > 0 {
+		ceiling := *bidEntity
+		ceiling.Amount = maxAmount
+		if err := ceiling.CheckMaxAmount(bu.maxAmount); err != nil {
+			return nil, invalidMaxAmount(fmt.Sprintf(
+				"MaxAmount must not be above the maximum allowed bid %s", bu.maxAmount))
+		}
+		if err := ceiling.CheckHighBidMultiple(highBid, bu.maxBidMultiple); err != nil {
+			return nil, invalidMaxAmount(fmt.Sprintf(
+				"MaxAmount must not be more than %gx the current high bid %s", bu.maxBidMultiple, highBid))
+		}
+	}
+
+	var bids []bid_entity.Bid
+	err := bu.proxyBookRepository.UpdateProxyBook(ctx, bidEntity.AuctionId,
+		func(book *bid_entity.ProxyBook) *bid_entity.ProxyBook {
+			if book == nil {
+				if maxAmount == 0 {
+					bids = []bid_entity.Bid{*bidEntity}
+					return nil
+				}
+				book = bid_entity.NewProxyBook(bidEntity.AuctionId, highBid)
+			}
+
+			bids = book.Place(*bidEntity, maxAmount, bu.proxyIncrement)
+			return book
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return bids, nil
+}
+
+func invalidMaxAmount(message string) *internal_error.InternalError {
+	return internal_error.NewValidationError("invalid bid object", []internal_error.FieldError{{
+		Field:   "max_amount",
+		Message: message,
+	}})
+}
+
+func (bu *BidUseCase) evictProxyBook(ctx context.Context, auctionId string) {
+	if err := bu.proxyBookRepository.DeleteProxyBook(ctx, auctionId); err != nil {
+		logger.Error(fmt.Sprintf("Error trying to evict proxy book of auction %s", auctionId), err)
+	}
+}
+
+// evictClosedProxyBooks descarta os livros de leilões encerrados ou removidos. Roda
+// junto com a gravação periódica dos lances, para que os livros de leilões que
+// ninguém consulta depois do fechamento não se acumulem no repositório
+func (bu *BidUseCase) evictClosedProxyBooks(ctx context.Context) {
+	if bu.auctionRepository == nil || bu.proxyBookRepository == nil {
+		return
+	}
+
+	auctionIds, err := bu.proxyBookRepository.FindProxyBookAuctionIds(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, auctionId := range auctionIds {
+		auction, err := bu.auctionRepository.FindAuctionByIdUncached(ctx, auctionId)
+		if err != nil && err.Code() != internal_error.NotFound {
+			continue
+		}
+		if err == nil {
+			if biddable, _ := auction.Biddable(time.Now()); biddable {
+				continue
+			}
+		}
+		bu.evictProxyBook(ctx, auctionId)
+	}
+}
+
+// withdrawProxyBid tira do livro o máximo de quem retirou um lance. A retirada já foi
+// gravada, então uma falha aqui só é registrada
+func (bu *BidUseCase) withdrawProxyBid(ctx context.Context, withdrawn *bid_entity.Bid) {
+	if bu.proxyBookRepository == nil {
+		return
+	}
+
+	var price auction_entity.Money
+	var leaderId string
+	winningBid, err := bu.BidRepository.FindWinningBidByAuctionId(ctx, withdrawn.AuctionId)
+	if err != nil && err.Code() != internal_error.NotFound {
+		logger.Error(fmt.Sprintf("Error trying to update proxy book of auction %s", withdrawn.AuctionId), err)
+		return
+	}
+	if winningBid != nil {
+		price, leaderId = winningBid.Amount, winningBid.UserId
+	}
+
+	err = bu.proxyBookRepository.UpdateProxyBook(ctx, withdrawn.AuctionId,
+		func(book *bid_entity.ProxyBook) *bid_entity.ProxyBook {
+			if book == nil {
+				return nil
+			}
+
+			book.Withdraw(withdrawn.UserId, price, leaderId)
+			return book
+		})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error trying to update proxy book of auction %s", withdrawn.AuctionId), err)
+	}
+}
+
+// FindProxyBid retorna o preço visível do leilão para userId, com o máximo dele
+// quando houver. Sem máximos registrados, o preço é o maior lance do leilão. O livro
+// de um leilão encerrado é descartado, e o preço passa a ser o maior lance gravado
+func (bu *BidUseCase) FindProxyBid(
+	ctx context.Context, auctionId, userId string) (*ProxyBidOutputDTO, *internal_error.InternalError) {
+	output := &ProxyBidOutputDTO{AuctionId: auctionId}
+
+	biddable := true
+	var auction *auction_entity.Auction
+	if bu.auctionRepository != nil {
+		var err *internal_error.InternalError
+		auction, err = bu.auctionRepository.FindAuctionById(ctx, auctionId)
+		if err != nil {
+			return nil, err
+		}
+		if !auction.VisibleTo(userId) {
+			return nil, internal_error.NewNotFoundError(
+				fmt.Sprintf("Auction not found with this id = %s", auctionId))
+		}
+		biddable, _ = auction.Biddable(time.Now())
+	}
+
+	if bu.proxyBookRepository != nil {
+		if !biddable {
+			bu.evictProxyBook(ctx, auctionId)
+		} else {
+			book, err := bu.proxyBookRepository.FindProxyBook(ctx, auctionId)
+			if err != nil {
+				return nil, err
+			}
+			if book != nil {
+				output.CurrentPrice = book.Price
+				output.Winning = userId != "" && book.LeaderId == userId
+				if maxAmount := book.MaxAmount(userId); maxAmount > 0 {
+					output.MaxAmount = &maxAmount
+				}
+				return output, nil
+			}
+		}
+	}
+
+	if auction != nil {
+		output.CurrentPrice = auction.CurrentHighBid
+	}
+
+	return output, nil
+}
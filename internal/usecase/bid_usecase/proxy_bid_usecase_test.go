@@ -0,0 +1,321 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+	"fullcycle-auction_go/internal/entity/bid_entity"
+	"fullcycle-auction_go/internal/infra/database/memory"
+	"fullcycle-auction_go/internal/internal_error"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func newProxyBidUseCase(auction auction_entity.Auction) *BidUseCase {
+	return &BidUseCase{
+		auctionRepository:   memory.NewAuctionRepository(auction),
+		proxyBookRepository: memory.NewProxyBookRepository(),
+		bidChannel:          make(chan bid_entity.Bid, 100),
+		proxyIncrement:      auction_entity.MoneyFromCents(100),
+	}
+}
+
+// hasProxyBook informa se o leilão ainda tem livro no repositório do caso de uso
+func hasProxyBook(useCase *BidUseCase, auctionId string) bool {
+	book, _ := useCase.proxyBookRepository.FindProxyBook(context.Background(), auctionId)
+	return book != nil
+}
+
+// drainBids retorna os lances enviados para gravação desde a última chamada
+func drainBids(useCase *BidUseCase) []bid_entity.Bid {
+	var bids []bid_entity.Bid
+	for {
+		select {
+		case bid := <-useCase.bidChannel:
+			bids = append(bids, bid)
+		default:
+			return bids
+		}
+	}
+}
+
+func TestCreateBidWithProxiesDueling(t *testing.T) {
+	ctx := context.Background()
+	auctionId, alice, bob := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	useCase := newProxyBidUseCase(auction_entity.Auction{
+		Id: auctionId, Status: auction_entity.Active, Currency: "BRL", CurrentHighBid: 1000,
+	})
+
+	if err := useCase.CreateBid(ctx, BidInputDTO{
+		UserId: alice, AuctionId: auctionId, Amount: 1100, MaxAmount: 5000,
+	}); err != nil {
+		t.Fatalf("Failed to create proxy bid: %v", err)
+	}
+	if bids := drainBids(useCase); len(bids) != 1 || bids[0].Amount != 1100 {
+		t.Fatalf("Expected alice's opening bid at 11.00, got %+v", bids)
+	}
+
+	if err := useCase.CreateBid(ctx, BidInputDTO{
+		UserId: bob, AuctionId: auctionId, Amount: 2000, MaxAmount: 3000,
+	}); err != nil {
+		t.Fatalf("Failed to create proxy bid: %v", err)
+	}
+
+	bids := drainBids(useCase)
+	if len(bids) != 2 || bids[0].UserId != bob || bids[0].Amount != 2000 ||
+		bids[1].UserId != alice || bids[1].Amount != 3100 {
+		t.Fatalf("Expected bob's bid and alice's automatic bid at 31.00, got %+v", bids)
+	}
+
+	aliceView, _ := useCase.FindProxyBid(ctx, auctionId, alice)
+	if aliceView.CurrentPrice != 3100 || !aliceView.Winning ||
+		aliceView.MaxAmount == nil || *aliceView.MaxAmount != 5000 {
+		t.Errorf("Expected alice winning at 31.00 with max 50.00, got %+v", aliceView)
+	}
+
+	bobView, _ := useCase.FindProxyBid(ctx, auctionId, bob)
+	if bobView.CurrentPrice != 3100 || bobView.Winning ||
+		bobView.MaxAmount == nil || *bobView.MaxAmount != 3000 {
+		t.Errorf("Expected bob outbid at 31.00 seeing only his own max, got %+v", bobView)
+	}
+
+	anonymousView, _ := useCase.FindProxyBid(ctx, auctionId, "")
+	if anonymousView.CurrentPrice != 3100 || anonymousView.MaxAmount != nil {
+		t.Errorf("Expected the max to stay hidden, got %+v", anonymousView)
+	}
+}
+
+func TestCreateBidRejectsMaxBelowAmount(t *testing.T) {
+	auctionId := uuid.NewString()
+	useCase := newProxyBidUseCase(auction_entity.Auction{
+		Id: auctionId, Status: auction_entity.Active, Currency: "BRL",
+	})
+
+	err := useCase.CreateBid(context.Background(), BidInputDTO{
+		UserId: uuid.NewString(), AuctionId: auctionId, Amount: 2000, MaxAmount: 1000,
+	})
+	if err == nil || err.Err != "bad_request" || err.Fields[0].Field != "max_amount" {
+		t.Errorf("Expected max_amount to be rejected, got %+v", err)
+	}
+}
+
+func TestCreateBidRejectsMaxAboveLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxAmount      auction_entity.Money
+		maxBidMultiple float64
+	}{
+		{"above BID_MAX_AMOUNT", auction_entity.MoneyFromCents(5000), 0},
+		{"above BID_MAX_HIGH_BID_MULTIPLE", 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			auctionId, alice, bob := uuid.NewString(), uuid.NewString(), uuid.NewString()
+			useCase := newProxyBidUseCase(auction_entity.Auction{
+				Id: auctionId, Status: auction_entity.Active, Currency: "BRL", CurrentHighBid: 1000,
+			})
+			useCase.maxAmount = tt.maxAmount
+			useCase.maxBidMultiple = tt.maxBidMultiple
+
+			if err := useCase.CreateBid(ctx, BidInputDTO{
+				UserId: alice, AuctionId: auctionId, Amount: 1100, MaxAmount: 1500,
+			}); err != nil {
+				t.Fatalf("Failed to create proxy bid: %v", err)
+			}
+			drainBids(useCase)
+
+			err := useCase.CreateBid(ctx, BidInputDTO{
+				UserId: bob, AuctionId: auctionId, Amount: 1200, MaxAmount: 9000,
+			})
+			if err == nil || err.Err != "bad_request" || err.Fields[0].Field != "max_amount" {
+				t.Fatalf("Expected max_amount to be rejected, got %+v", err)
+			}
+
+			// O máximo recusado não entra no livro nem gera lances automáticos
+			if bids := drainBids(useCase); len(bids) != 0 {
+				t.Errorf("Expected no bids, got %+v", bids)
+			}
+			if bobView, _ := useCase.FindProxyBid(ctx, auctionId, bob); bobView.MaxAmount != nil {
+				t.Errorf("Expected no max for bob, got %+v", bobView)
+			}
+		})
+	}
+}
+
+func TestCreateBidWithConcurrentProxies(t *testing.T) {
+	ctx := context.Background()
+	auctionId := uuid.NewString()
+	useCase := newProxyBidUseCase(auction_entity.Auction{
+		Id: auctionId, Status: auction_entity.Active, Currency: "BRL",
+	})
+
+	users := make([]string, 20)
+	var wg sync.WaitGroup
+	for i := range users {
+		users[i] = uuid.NewString()
+		wg.Add(1)
+		go func(userId string, maxAmount auction_entity.Money) {
+			defer wg.Done()
+			useCase.CreateBid(ctx, BidInputDTO{
+				UserId: userId, AuctionId: auctionId, Amount: 100, MaxAmount: maxAmount,
+			})
+		}(users[i], auction_entity.MoneyFromCents(int64(1000+i*100)))
+	}
+	wg.Wait()
+	drainBids(useCase)
+
+	// O maior máximo (29.00) vence pagando o segundo maior (28.00) mais o incremento
+	winner, _ := useCase.FindProxyBid(ctx, auctionId, users[len(users)-1])
+	if !winner.Winning || winner.CurrentPrice != 2900 {
+		t.Errorf("Expected the highest max to win at 29.00, got %+v", winner)
+	}
+}
+
+func TestProxyBooksAreEvictedAfterClose(t *testing.T) {
+	ctx := context.Background()
+	looked, swept, live := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	auctionRepository := memory.NewAuctionRepository(
+		auction_entity.Auction{Id: looked, Status: auction_entity.Active, Currency: "BRL"},
+		auction_entity.Auction{Id: swept, Status: auction_entity.Active, Currency: "BRL"},
+		auction_entity.Auction{Id: live, Status: auction_entity.Active, Currency: "BRL"},
+	)
+	useCase := &BidUseCase{
+		auctionRepository:   auctionRepository,
+		proxyBookRepository: memory.NewProxyBookRepository(),
+		bidChannel:          make(chan bid_entity.Bid, 100),
+		proxyIncrement:      auction_entity.MoneyFromCents(100),
+	}
+
+	alice := uuid.NewString()
+	for _, auctionId := range []string{looked, swept, live} {
+		if err := useCase.CreateBid(ctx, BidInputDTO{
+			UserId: alice, AuctionId: auctionId, Amount: 1000, MaxAmount: 5000,
+		}); err != nil {
+			t.Fatalf("Failed to create proxy bid: %v", err)
+		}
+	}
+	drainBids(useCase)
+
+	for _, auctionId := range []string{looked, swept} {
+		if err := auctionRepository.CloseAuction(ctx, auctionId); err != nil {
+			t.Fatalf("Failed to close auction: %v", err)
+		}
+	}
+
+	// O livro sai na primeira consulta depois do fechamento
+	view, err := useCase.FindProxyBid(ctx, looked, alice)
+	if err != nil {
+		t.Fatalf("Failed to find proxy bid: %v", err)
+	}
+	if view.MaxAmount != nil || hasProxyBook(useCase, looked) {
+		t.Errorf("Expected the book of a closed auction to be evicted, got %+v", view)
+	}
+
+	// Os demais saem na varredura, que mantém os leilões ainda abertos
+	useCase.evictClosedProxyBooks(ctx)
+	if hasProxyBook(useCase, swept) {
+		t.Errorf("Expected the sweep to evict the book of a closed auction")
+	}
+	if !hasProxyBook(useCase, live) {
+		t.Errorf("Expected the sweep to keep the book of an open auction")
+	}
+}
+
+func TestProxyMaximaAreSharedThroughTheRepository(t *testing.T) {
+	ctx := context.Background()
+	auctionId, alice, bob := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	auction := auction_entity.Auction{
+		Id: auctionId, Status: auction_entity.Active, Currency: "BRL", CurrentHighBid: 1000,
+	}
+	replica := newProxyBidUseCase(auction)
+
+	if err := replica.CreateBid(ctx, BidInputDTO{
+		UserId: alice, AuctionId: auctionId, Amount: 1100, MaxAmount: 5000,
+	}); err != nil {
+		t.Fatalf("Failed to create proxy bid: %v", err)
+	}
+	drainBids(replica)
+
+	// Outra instância, ou a mesma depois de reiniciar, lê o máximo de alice
+	restarted := newProxyBidUseCase(auction)
+	restarted.proxyBookRepository = replica.proxyBookRepository
+	if err := restarted.CreateBid(ctx, BidInputDTO{
+		UserId: bob, AuctionId: auctionId, Amount: 2000,
+	}); err != nil {
+		t.Fatalf("Failed to create bid: %v", err)
+	}
+	if bids := drainBids(restarted); len(bids) != 2 || bids[1].UserId != alice || bids[1].Amount != 2100 {
+		t.Errorf("Expected alice's max to answer bob's bid at 21.00, got %+v", bids)
+	}
+}
+
+func TestCreateBidRejectsMaxWithoutProxyBookRepository(t *testing.T) {
+	auctionId := uuid.NewString()
+	useCase := newProxyBidUseCase(auction_entity.Auction{
+		Id: auctionId, Status: auction_entity.Active, Currency: "BRL",
+	})
+	useCase.proxyBookRepository = nil
+
+	err := useCase.CreateBid(context.Background(), BidInputDTO{
+		UserId: uuid.NewString(), AuctionId: auctionId, Amount: 1000, MaxAmount: 5000,
+	})
+	if err == nil || err.Err != "bad_request" || err.Fields[0].Field != "max_amount" {
+		t.Errorf("Expected max_amount to be rejected without proxy books, got %+v", err)
+	}
+}
+
+// withdrawingBidRepository retira lances sem MongoDB e devolve o vencedor configurado
+type withdrawingBidRepository struct {
+	bid_entity.BidEntityRepository
+	withdrawn  bid_entity.Bid
+	winningBid *bid_entity.Bid
+}
+
+func (r *withdrawingBidRepository) WithdrawBid(
+	ctx context.Context, bidId, userId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	return &r.withdrawn, nil
+}
+
+func (r *withdrawingBidRepository) FindWinningBidByAuctionId(
+	ctx context.Context, auctionId string) (*bid_entity.Bid, *internal_error.InternalError) {
+	return r.winningBid, nil
+}
+
+func TestWithdrawBidDropsTheUsersMax(t *testing.T) {
+	ctx := context.Background()
+	auctionId, alice, bob := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	useCase := newProxyBidUseCase(auction_entity.Auction{
+		Id: auctionId, Status: auction_entity.Active, Currency: "BRL", CurrentHighBid: 1000,
+	})
+
+	for _, input := range []BidInputDTO{
+		{UserId: alice, AuctionId: auctionId, Amount: 1100, MaxAmount: 5000},
+		{UserId: bob, AuctionId: auctionId, Amount: 1200, MaxAmount: 3000},
+	} {
+		if err := useCase.CreateBid(ctx, input); err != nil {
+			t.Fatalf("Failed to create proxy bid: %v", err)
+		}
+	}
+	bids := drainBids(useCase)
+	aliceBid := bids[len(bids)-1]
+
+	// Alice liderava a 31.00; sem ela, o maior lance que resta é o de bob a 30.00
+	useCase.BidRepository = &withdrawingBidRepository{
+		withdrawn:  aliceBid,
+		winningBid: &bid_entity.Bid{UserId: bob, AuctionId: auctionId, Amount: 3000},
+	}
+	if err := useCase.WithdrawBid(ctx, aliceBid.Id, alice); err != nil {
+		t.Fatalf("Failed to withdraw bid: %v", err)
+	}
+
+	aliceView, _ := useCase.FindProxyBid(ctx, auctionId, alice)
+	if aliceView.MaxAmount != nil || aliceView.Winning {
+		t.Errorf("Expected alice's max to be dropped, got %+v", aliceView)
+	}
+	bobView, _ := useCase.FindProxyBid(ctx, auctionId, bob)
+	if !bobView.Winning || bobView.CurrentPrice != 3000 {
+		t.Errorf("Expected bob to lead at 30.00 after the withdrawal, got %+v", bobView)
+	}
+}
@@ -0,0 +1,30 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/entity/auction_entity"
+)
+
+// RateLimiter limita a quantidade de lances por chave (o id do usuário).
+// A implementação padrão é em memória; uma implementação compartilhada,
+// como Redis, pode ser injetada com WithRateLimiter
+type RateLimiter interface {
+	Allow(ctx context.Context, key string) (bool, error)
+}
+
+type BidUseCaseOption func(*BidUseCase)
+
+// WithAuctionRepository permite validar a moeda do lance contra a do leilão
+// no momento da criação, retornando o erro ao cliente
+func WithAuctionRepository(
+	auctionRepository auction_entity.AuctionRepositoryInterface) BidUseCaseOption {
+	return func(bu *BidUseCase) {
+		bu.auctionRepository = auctionRepository
+	}
+}
+
+func WithRateLimiter(rateLimiter RateLimiter) BidUseCaseOption {
+	return func(bu *BidUseCase) {
+		bu.rateLimiter = rateLimiter
+	}
+}
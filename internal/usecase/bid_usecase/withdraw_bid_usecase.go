@@ -0,0 +1,26 @@
+package bid_usecase
+
+import (
+	"context"
+	"fullcycle-auction_go/internal/internal_error"
+)
+
+// WithdrawBid retira o lance do usuário. As regras de quando a retirada é permitida
+// ficam no repositório, que consulta o leilão e o lance vencedor. O máximo do usuário
+// no leilão, se houver, é descartado junto
+func (bu *BidUseCase) WithdrawBid(
+	ctx context.Context, bidId, userId string) *internal_error.InternalError {
+	if userId == "" {
+		return internal_error.NewValidationError("Invalid fields", []internal_error.FieldError{
+			{Field: "user_id", Message: "is required to withdraw a bid"},
+		})
+	}
+
+	withdrawn, err := bu.BidRepository.WithdrawBid(ctx, bidId, userId)
+	if err != nil {
+		return err
+	}
+
+	bu.withdrawProxyBid(ctx, withdrawn)
+	return nil
+}
@@ -16,6 +16,8 @@ type UserUseCase struct {
 	UserRepository user_entity.UserRepositoryInterface
 }
 
+// UserOutputDTO é a resposta pública do usuário. O e-mail fica só na entidade, para
+// as notificações, e não é exposto
 type UserOutputDTO struct {
 	Id   string `json:"id"`
 	Name string `json:"name"`